@@ -14,6 +14,8 @@ type AwsS3StorageProvider struct {
 	region     string
 	accessKey  string
 	secretKey  string
+	endpoint   string
+	pathStyle  bool
 }
 
 func NewAwsS3StorageProvider(bucketName, region, accessKey, secretKey string) *AwsS3StorageProvider {
@@ -25,9 +27,24 @@ func NewAwsS3StorageProvider(bucketName, region, accessKey, secretKey string) *A
 	}
 }
 
+// NewS3CompatibleStorageProvider creates a provider for an S3-compatible object store with a custom endpoint, e.g. MinIO.
+func NewS3CompatibleStorageProvider(bucketName, region, endpoint, accessKey, secretKey string, pathStyle bool) *AwsS3StorageProvider {
+	return &AwsS3StorageProvider{
+		bucketName: bucketName,
+		region:     region,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		endpoint:   endpoint,
+		pathStyle:  pathStyle,
+	}
+}
+
 func (p *AwsS3StorageProvider) GetClient() (*s3.S3, error) {
 	s3Credentials := credentials.NewStaticCredentials(p.accessKey, p.secretKey, "")
 	awsConfig := aws.NewConfig().WithCredentials(s3Credentials).WithRegion(p.region)
+	if p.endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(p.endpoint).WithS3ForcePathStyle(p.pathStyle)
+	}
 	sess, err := session.NewSession(awsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error creating new session for s3 access: %w", err)
@@ -0,0 +1,206 @@
+// Package benchmark stores the results of standardized data service workloads and compares them with a baseline,
+// so releases can be blocked on throughput or latency regressions.
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/portworx/pds-integration-test/internal/loadtest"
+)
+
+// Metrics compared with the baseline.
+const (
+	MetricThroughput = "throughput"
+	MetricP50        = "p50"
+	MetricP99        = "p99"
+	MetricErrorRate  = "errorRate"
+)
+
+// Key identifies the results of a workload.
+type Key struct {
+	DataService  string `json:"dataService"`
+	Version      string `json:"version"`
+	Template     string `json:"template"`
+	ChartVersion string `json:"chartVersion"`
+	Workload     string `json:"workload"`
+}
+
+func (k Key) String() string {
+	return strings.Join([]string{k.DataService, k.Version, k.Template, k.ChartVersion, k.Workload}, "/")
+}
+
+// comparable returns true if the results were measured with the same workload, data service version and template.
+// Results of different chart versions are compared with each other.
+func (k Key) comparable(other Key) bool {
+	return k.DataService == other.DataService &&
+		k.Version == other.Version &&
+		k.Template == other.Template &&
+		k.Workload == other.Workload
+}
+
+// Result of a workload. Latencies are percentiles of the operation latencies in milliseconds, the throughput is in
+// operations per second.
+type Result struct {
+	Key
+	Time       time.Time `json:"time"`
+	Ops        int64     `json:"ops"`
+	Errors     int64     `json:"errors"`
+	Throughput float64   `json:"throughput"`
+	P50Millis  float64   `json:"p50Millis"`
+	P99Millis  float64   `json:"p99Millis"`
+	ErrorRate  float64   `json:"errorRate"`
+}
+
+// NewResult converts the results of a load test.
+func NewResult(key Key, results loadtest.Results) Result {
+	return Result{
+		Key:        key,
+		Time:       time.Now().UTC(),
+		Ops:        results.Ops,
+		Errors:     results.Errors,
+		Throughput: results.Throughput(),
+		P50Millis:  float64(results.P50) / float64(time.Millisecond),
+		P99Millis:  float64(results.P99) / float64(time.Millisecond),
+		ErrorRate:  results.ErrorRate(),
+	}
+}
+
+// Report is a set of results keyed by Key.String().
+type Report struct {
+	Results map[string]Result `json:"results"`
+}
+
+// NewReport returns an empty report.
+func NewReport() *Report {
+	return &Report{Results: make(map[string]Result)}
+}
+
+// Add adds the result, replacing a result with the same key.
+func (r *Report) Add(result Result) {
+	r.Results[result.Key.String()] = result
+}
+
+// Merge adds all results of the other report, e.g. to extend a baseline with the results of a release.
+func (r *Report) Merge(other *Report) {
+	for _, result := range other.Results {
+		r.Add(result)
+	}
+}
+
+// Load reads a report written by Save.
+func Load(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := NewReport()
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("parsing benchmark report %s: %w", path, err)
+	}
+	if report.Results == nil {
+		report.Results = make(map[string]Result)
+	}
+	return report, nil
+}
+
+// Save writes the report as JSON.
+func (r *Report) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// baselineFor returns the most recent comparable result of the report.
+func (r *Report) baselineFor(key Key) (Result, bool) {
+	var (
+		baseline Result
+		found    bool
+	)
+	for _, result := range r.Results {
+		if !result.Key.comparable(key) {
+			continue
+		}
+		if !found || result.Time.After(baseline.Time) {
+			baseline = result
+			found = true
+		}
+	}
+	return baseline, found
+}
+
+// Tolerances are the accepted regressions relative to the baseline, e.g. 0.1 accepts 10% lower throughput.
+// The error rate tolerance is absolute, e.g. 0.01 accepts one more failed operation in a hundred.
+type Tolerances struct {
+	Throughput float64
+	Latency    float64
+	ErrorRate  float64
+}
+
+// DefaultTolerances absorb the usual noise of shared test clusters.
+var DefaultTolerances = Tolerances{Throughput: 0.15, Latency: 0.25, ErrorRate: 0.001}
+
+// Regression is a metric of a result which is worse than the baseline by more than the tolerance.
+type Regression struct {
+	Key      Key
+	Metric   string
+	Baseline float64
+	Current  float64
+}
+
+func (r Regression) String() string {
+	change := "n/a"
+	if r.Baseline != 0 {
+		change = fmt.Sprintf("%+.1f%%", 100*(r.Current-r.Baseline)/r.Baseline)
+	}
+	return fmt.Sprintf("%s: %s %.3f, baseline %.3f (%s)", r.Key, r.Metric, r.Current, r.Baseline, change)
+}
+
+// Compare returns the regressions of the result against the most recent comparable result of the baseline.
+// It returns false if the baseline has no comparable result.
+func Compare(baseline *Report, result Result, tolerances Tolerances) ([]Regression, bool) {
+	base, ok := baseline.baselineFor(result.Key)
+	if !ok {
+		return nil, false
+	}
+
+	var regressions []Regression
+	add := func(metric string, baseline, current float64) {
+		regressions = append(regressions, Regression{Key: result.Key, Metric: metric, Baseline: baseline, Current: current})
+	}
+	if result.Throughput < base.Throughput*(1-tolerances.Throughput) {
+		add(MetricThroughput, base.Throughput, result.Throughput)
+	}
+	if base.P50Millis > 0 && result.P50Millis > base.P50Millis*(1+tolerances.Latency) {
+		add(MetricP50, base.P50Millis, result.P50Millis)
+	}
+	if base.P99Millis > 0 && result.P99Millis > base.P99Millis*(1+tolerances.Latency) {
+		add(MetricP99, base.P99Millis, result.P99Millis)
+	}
+	if result.ErrorRate > base.ErrorRate+tolerances.ErrorRate {
+		add(MetricErrorRate, base.ErrorRate, result.ErrorRate)
+	}
+	return regressions, true
+}
+
+// Sorted returns the results ordered by key.
+func (r *Report) Sorted() []Result {
+	results := make([]Result, 0, len(r.Results))
+	for _, result := range r.Results {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Key.String() < results[j].Key.String()
+	})
+	return results
+}
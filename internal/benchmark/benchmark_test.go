@@ -0,0 +1,67 @@
+package benchmark
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/loadtest"
+)
+
+func key(chartVersion string) Key {
+	return Key{DataService: "PostgreSQL", Version: "14.8", Template: "med", ChartVersion: chartVersion, Workload: "mixed"}
+}
+
+func TestNewResult(t *testing.T) {
+	result := NewResult(key("1.20.0"), loadtest.Results{
+		Ops:      1000,
+		Errors:   10,
+		P50:      1500 * time.Microsecond,
+		P99:      20 * time.Millisecond,
+		Duration: 10 * time.Second,
+	})
+	assert.Equal(t, 100.0, result.Throughput)
+	assert.Equal(t, 1.5, result.P50Millis)
+	assert.Equal(t, 20.0, result.P99Millis)
+	assert.Equal(t, 0.01, result.ErrorRate)
+	assert.Equal(t, "PostgreSQL/14.8/med/1.20.0/mixed", result.Key.String())
+}
+
+func TestReportSaveLoad(t *testing.T) {
+	report := NewReport()
+	report.Add(Result{Key: key("1.20.0"), Throughput: 100, P99Millis: 10})
+	path := filepath.Join(t.TempDir(), "results", "benchmark.json")
+	require.NoError(t, report.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, report.Sorted(), loaded.Sorted())
+}
+
+func TestCompare(t *testing.T) {
+	baseline := NewReport()
+	baseline.Add(Result{Key: key("1.19.0"), Time: time.Unix(100, 0), Throughput: 50, P50Millis: 1, P99Millis: 10})
+	baseline.Add(Result{Key: key("1.20.0"), Time: time.Unix(200, 0), Throughput: 100, P50Millis: 1, P99Millis: 10})
+
+	// Within the tolerances of the most recent baseline.
+	regressions, ok := Compare(baseline, Result{Key: key("1.21.0"), Throughput: 90, P50Millis: 1.2, P99Millis: 12}, DefaultTolerances)
+	require.True(t, ok)
+	assert.Empty(t, regressions)
+
+	regressions, ok = Compare(baseline, Result{Key: key("1.21.0"), Throughput: 80, P50Millis: 1, P99Millis: 20, ErrorRate: 0.01}, DefaultTolerances)
+	require.True(t, ok)
+	var metrics []string
+	for _, regression := range regressions {
+		metrics = append(metrics, regression.Metric)
+	}
+	assert.Equal(t, []string{MetricThroughput, MetricP99, MetricErrorRate}, metrics)
+	assert.Contains(t, regressions[0].String(), "-20.0%")
+
+	other := key("1.21.0")
+	other.Template = "small"
+	_, ok = Compare(baseline, Result{Key: other}, DefaultTolerances)
+	assert.False(t, ok)
+}
@@ -0,0 +1,48 @@
+// Package chaos provides faults which can be injected into a target cluster to test the resilience of data services.
+package chaos
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Fault is a disruption of the target cluster which can be reverted.
+type Fault interface {
+	// Name returns a short human-readable description of the fault.
+	Name() string
+	// Inject starts the fault.
+	Inject(ctx context.Context) error
+	// Revert undoes the fault. It must be safe to call even if Inject failed or was only partially applied.
+	Revert(ctx context.Context) error
+}
+
+// MustInjectFor injects the fault, keeps it active for the given duration and then reverts it.
+// The revert is also registered as a test cleanup, so the fault is reverted even if the test fails or panics meanwhile.
+func MustInjectFor(ctx context.Context, t *testing.T, fault Fault, duration time.Duration) {
+	revert := MustInject(ctx, t, fault)
+	t.Logf("Fault %q injected for %s.", fault.Name(), duration)
+	time.Sleep(duration)
+	revert()
+}
+
+// MustInject injects the fault and returns a function reverting it.
+// The revert is registered as a test cleanup as well, it is executed only once.
+func MustInject(ctx context.Context, t *testing.T, fault Fault) func() {
+	var once sync.Once
+	revert := func() {
+		once.Do(func() {
+			err := fault.Revert(ctx)
+			require.NoErrorf(t, err, "Reverting fault %q.", fault.Name())
+			t.Logf("Fault %q reverted.", fault.Name())
+		})
+	}
+	t.Cleanup(revert)
+
+	err := fault.Inject(ctx)
+	require.NoErrorf(t, err, "Injecting fault %q.", fault.Name())
+	return revert
+}
@@ -0,0 +1,71 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+// NetworkBlock blocks all ingress and egress traffic of the selected pods using a NetworkPolicy.
+// It requires a CNI plugin which enforces network policies.
+type NetworkBlock struct {
+	targetCluster *targetcluster.TargetCluster
+	namespace     string
+	policyName    string
+	podSelector   map[string]string
+
+	// created is true while the policy created by Inject exists, a policy of the same name created by anyone else is
+	// not deleted by Revert.
+	created bool
+}
+
+// NewNetworkBlock creates a fault isolating the pods matching the selector in the namespace.
+func NewNetworkBlock(tc *targetcluster.TargetCluster, namespace, policyName string, podSelector map[string]string) *NetworkBlock {
+	return &NetworkBlock{
+		targetCluster: tc,
+		namespace:     namespace,
+		policyName:    policyName,
+		podSelector:   podSelector,
+	}
+}
+
+func (f *NetworkBlock) Name() string {
+	return fmt.Sprintf("block traffic of pods %v in %s", f.podSelector, f.namespace)
+}
+
+func (f *NetworkBlock) Inject(ctx context.Context) error {
+	// A policy with both types and no rules denies all traffic of the selected pods.
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      f.policyName,
+			Namespace: f.namespace,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: f.podSelector},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+	}
+	_, err := f.targetCluster.Clientset.NetworkingV1().NetworkPolicies(f.namespace).Create(ctx, policy, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("creating network policy %s: %w", f.policyName, err)
+	}
+	f.created = true
+	return nil
+}
+
+func (f *NetworkBlock) Revert(ctx context.Context) error {
+	if !f.created {
+		return nil
+	}
+	err := f.targetCluster.Clientset.NetworkingV1().NetworkPolicies(f.namespace).Delete(ctx, f.policyName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting network policy %s: %w", f.policyName, err)
+	}
+	f.created = false
+	return nil
+}
@@ -0,0 +1,47 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/fixtures"
+)
+
+const testPolicyName = "pg-chaos-deny-all"
+
+func TestNetworkBlock_InjectRevert(t *testing.T) {
+	tc := fixtures.NewFakeTargetCluster(t)
+	ctx := context.Background()
+	block := NewNetworkBlock(tc, "pds-test", testPolicyName, map[string]string{"pds/deployment-id": "a"})
+
+	require.NoError(t, block.Inject(ctx))
+	_, err := tc.Clientset.NetworkingV1().NetworkPolicies("pds-test").Get(ctx, testPolicyName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, block.Revert(ctx))
+	_, err = tc.Clientset.NetworkingV1().NetworkPolicies("pds-test").Get(ctx, testPolicyName, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "Network policy is not deleted.")
+	// Reverting twice is a no-op, e.g. when the revert is also registered as a test cleanup.
+	require.NoError(t, block.Revert(ctx))
+}
+
+func TestNetworkBlock_RevertKeepsExistingPolicy(t *testing.T) {
+	existing := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "pds-test", Name: testPolicyName}}
+	tc := fixtures.NewFakeTargetCluster(t, existing)
+	ctx := context.Background()
+	block := NewNetworkBlock(tc, "pds-test", testPolicyName, map[string]string{"pds/deployment-id": "a"})
+
+	err := block.Inject(ctx)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsAlreadyExists(err))
+
+	require.NoError(t, block.Revert(ctx))
+	_, err = tc.Clientset.NetworkingV1().NetworkPolicies("pds-test").Get(ctx, testPolicyName, metav1.GetOptions{})
+	assert.NoError(t, err, "Network policy which was not created by the fault is deleted.")
+}
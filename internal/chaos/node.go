@@ -0,0 +1,151 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+// cordonedNodes tracks the nodes cordoned by faults of this process. Faults cordoning the same node share
+// the entry, the original state is recorded by the first one and restored by the last revert.
+var cordonedNodes = struct {
+	sync.Mutex
+	nodes map[string]*cordonedNode
+}{nodes: map[string]*cordonedNode{}}
+
+type cordonedNode struct {
+	faults           int
+	wasUnschedulable bool
+}
+
+// NodeDrain cordons a node and optionally evicts the selected pods running on it. The revert uncordons the node
+// unless it was unschedulable before the first fault cordoned it.
+type NodeDrain struct {
+	targetCluster *targetcluster.TargetCluster
+	nodeName      string
+	evict         bool
+	podSelector   map[string]string
+
+	cordoned bool
+}
+
+// NewNodeCordon creates a fault marking the node as unschedulable.
+func NewNodeCordon(tc *targetcluster.TargetCluster, nodeName string) *NodeDrain {
+	return &NodeDrain{targetCluster: tc, nodeName: nodeName}
+}
+
+// NewNodeDrain creates a fault cordoning the node and evicting its pods matching the selector, e.g. the pods of
+// a single deployment. The selector must not be empty, pods of other tests and system components are never evicted.
+// Evictions respect pod disruption budgets, pods protected by a budget are left running.
+func NewNodeDrain(tc *targetcluster.TargetCluster, nodeName string, podSelector map[string]string) *NodeDrain {
+	return &NodeDrain{targetCluster: tc, nodeName: nodeName, evict: true, podSelector: podSelector}
+}
+
+func (f *NodeDrain) Name() string {
+	if f.evict {
+		return fmt.Sprintf("drain node %s", f.nodeName)
+	}
+	return fmt.Sprintf("cordon node %s", f.nodeName)
+}
+
+func (f *NodeDrain) Inject(ctx context.Context) error {
+	if f.evict && len(f.podSelector) == 0 {
+		return fmt.Errorf("draining node %s: pod selector is empty", f.nodeName)
+	}
+	err := f.cordon(ctx)
+	if err != nil {
+		return err
+	}
+	if !f.evict {
+		return nil
+	}
+
+	pods, err := f.targetCluster.Clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + f.nodeName,
+		LabelSelector: labels.SelectorFromSet(f.podSelector).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("listing pods of node %s: %w", f.nodeName, err)
+	}
+	var evictErr error
+	for _, pod := range pods.Items {
+		if isDaemonSetPod(pod.OwnerReferences) {
+			continue
+		}
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		}
+		err := f.targetCluster.Clientset.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+		// Too many requests means the eviction is blocked by a pod disruption budget.
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsTooManyRequests(err) {
+			evictErr = multierror.Append(evictErr, fmt.Errorf("evicting pod %s/%s: %w", pod.Namespace, pod.Name, err))
+		}
+	}
+	return evictErr
+}
+
+func (f *NodeDrain) Revert(ctx context.Context) error {
+	if !f.cordoned {
+		return nil
+	}
+	cordonedNodes.Lock()
+	defer cordonedNodes.Unlock()
+	node := cordonedNodes.nodes[f.nodeName]
+	node.faults--
+	f.cordoned = false
+	if node.faults > 0 {
+		return nil
+	}
+	delete(cordonedNodes.nodes, f.nodeName)
+	return f.setUnschedulable(ctx, node.wasUnschedulable)
+}
+
+// cordon marks the node as unschedulable. Only the first fault cordoning the node records its original state.
+func (f *NodeDrain) cordon(ctx context.Context) error {
+	cordonedNodes.Lock()
+	defer cordonedNodes.Unlock()
+	if node, ok := cordonedNodes.nodes[f.nodeName]; ok {
+		node.faults++
+		f.cordoned = true
+		return nil
+	}
+
+	node, err := f.targetCluster.Clientset.CoreV1().Nodes().Get(ctx, f.nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("getting node %s: %w", f.nodeName, err)
+	}
+	err = f.setUnschedulable(ctx, true)
+	if err != nil {
+		return err
+	}
+	cordonedNodes.nodes[f.nodeName] = &cordonedNode{faults: 1, wasUnschedulable: node.Spec.Unschedulable}
+	f.cordoned = true
+	return nil
+}
+
+func (f *NodeDrain) setUnschedulable(ctx context.Context, unschedulable bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	_, err := f.targetCluster.Clientset.CoreV1().Nodes().Patch(ctx, f.nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("setting node %s unschedulable=%t: %w", f.nodeName, unschedulable, err)
+	}
+	return nil
+}
+
+func isDaemonSetPod(owners []metav1.OwnerReference) bool {
+	for _, owner := range owners {
+		if owner.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/fixtures"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+const testNodeName = "node-1"
+
+func TestNodeDrain_SharedCordon(t *testing.T) {
+	for _, wasUnschedulable := range []bool{false, true} {
+		tc := fixtures.NewFakeTargetCluster(t, node(testNodeName, wasUnschedulable))
+		ctx := context.Background()
+		first := NewNodeDrain(tc, testNodeName, map[string]string{"pds/deployment-id": "a"})
+		second := NewNodeDrain(tc, testNodeName, map[string]string{"pds/deployment-id": "b"})
+
+		require.NoError(t, first.Inject(ctx))
+		require.NoError(t, second.Inject(ctx))
+		assert.True(t, isUnschedulable(t, tc))
+
+		require.NoError(t, first.Revert(ctx))
+		assert.True(t, isUnschedulable(t, tc), "Node is uncordoned while another fault is active.")
+		require.NoError(t, second.Revert(ctx))
+		assert.Equal(t, wasUnschedulable, isUnschedulable(t, tc), "Original state of the node is not restored.")
+		// Reverting twice doesn't change the node, e.g. when the revert is also registered as a test cleanup.
+		require.NoError(t, second.Revert(ctx))
+		assert.Equal(t, wasUnschedulable, isUnschedulable(t, tc))
+		assert.Empty(t, cordonedNodes.nodes)
+	}
+}
+
+func TestNodeDrain_EvictsSelectedPods(t *testing.T) {
+	tc := fixtures.NewFakeTargetCluster(t,
+		node(testNodeName, false),
+		pod("pds-test", "deployment-0", map[string]string{"pds/deployment-id": "a"}),
+		pod("pds-test", "other-0", map[string]string{"pds/deployment-id": "b"}),
+		pod("kube-system", "coredns", map[string]string{"k8s-app": "kube-dns"}),
+	)
+	ctx := context.Background()
+	drain := NewNodeDrain(tc, testNodeName, map[string]string{"pds/deployment-id": "a"})
+
+	require.NoError(t, drain.Inject(ctx))
+	t.Cleanup(func() { _ = drain.Revert(ctx) })
+
+	var evicted []string
+	for _, action := range tc.Clientset.(interface{ Actions() []k8stesting.Action }).Actions() {
+		if create, ok := action.(k8stesting.CreateAction); ok && action.GetSubresource() == "eviction" {
+			evicted = append(evicted, create.GetObject().(metav1.Object).GetName())
+		}
+	}
+	assert.Equal(t, []string{"deployment-0"}, evicted)
+}
+
+func TestNodeDrain_RequiresPodSelector(t *testing.T) {
+	tc := fixtures.NewFakeTargetCluster(t, node(testNodeName, false))
+	ctx := context.Background()
+
+	err := NewNodeDrain(tc, testNodeName, nil).Inject(ctx)
+
+	require.Error(t, err)
+	assert.False(t, isUnschedulable(t, tc))
+}
+
+func isUnschedulable(t *testing.T, tc *targetcluster.TargetCluster) bool {
+	node, err := tc.Clientset.CoreV1().Nodes().Get(context.Background(), testNodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	return node.Spec.Unschedulable
+}
+
+func node(name string, unschedulable bool) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+	}
+}
+
+func pod(namespace, name string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       corev1.PodSpec{NodeName: testNodeName},
+	}
+}
@@ -0,0 +1,99 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+const (
+	pollInterval = 5 * time.Second
+	// RecoveryTimeout is the maximum time a revert waits for the cluster to get back to the original state.
+	RecoveryTimeout = 10 * time.Minute
+)
+
+// PodKill deletes the pods of a stateful set with the given ordinals.
+// The pods are recreated by the stateful set controller, the revert waits until they are ready again.
+type PodKill struct {
+	targetCluster   *targetcluster.TargetCluster
+	namespace       string
+	statefulSetName string
+	ordinals        []int
+
+	killedAt map[string]metav1.Time
+}
+
+// NewPodKill creates a fault killing pods <statefulSetName>-<ordinal> in the namespace.
+func NewPodKill(tc *targetcluster.TargetCluster, namespace, statefulSetName string, ordinals ...int) *PodKill {
+	return &PodKill{
+		targetCluster:   tc,
+		namespace:       namespace,
+		statefulSetName: statefulSetName,
+		ordinals:        ordinals,
+	}
+}
+
+func (f *PodKill) Name() string {
+	return fmt.Sprintf("kill pods %v of %s/%s", f.ordinals, f.namespace, f.statefulSetName)
+}
+
+func (f *PodKill) Inject(ctx context.Context) error {
+	f.killedAt = make(map[string]metav1.Time)
+	gracePeriod := int64(0)
+	for _, podName := range f.podNames() {
+		pod, err := f.targetCluster.Clientset.CoreV1().Pods(f.namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("getting pod %s: %w", podName, err)
+		}
+		err = f.targetCluster.Clientset.CoreV1().Pods(f.namespace).Delete(ctx, podName, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting pod %s: %w", podName, err)
+		}
+		f.killedAt[podName] = pod.CreationTimestamp
+	}
+	return nil
+}
+
+func (f *PodKill) Revert(ctx context.Context) error {
+	return wait.PollUntilContextTimeout(ctx, pollInterval, RecoveryTimeout, true, func(ctx context.Context) (bool, error) {
+		for podName, oldCreationTime := range f.killedAt {
+			pod, err := f.targetCluster.Clientset.CoreV1().Pods(f.namespace).Get(ctx, podName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			} else if err != nil {
+				return false, err
+			}
+			if !pod.CreationTimestamp.After(oldCreationTime.Time) || !isPodReady(pod) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+func (f *PodKill) podNames() []string {
+	names := make([]string, 0, len(f.ordinals))
+	for _, ordinal := range f.ordinals {
+		names = append(names, fmt.Sprintf("%s-%d", f.statefulSetName, ordinal))
+	}
+	return names
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
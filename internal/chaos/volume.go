@@ -0,0 +1,189 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+const (
+	fillFileName = ".chaos-fill"
+	// cleanupMountPath is the mount path of the filled volume in the cleanup pod.
+	cleanupMountPath = "/volume"
+)
+
+// VolumeFill allocates a file on the volume mounted in a pod to simulate a full disk.
+// The revert removes the file. If the container can't be exec'd into, e.g. because the data service crashes on the
+// full volume, the file is removed by a cleanup pod mounting the same persistent volume claim on the same node.
+type VolumeFill struct {
+	targetCluster *targetcluster.TargetCluster
+	namespace     string
+	podName       string
+	container     string
+	mountPath     string
+	sizeMB        int
+}
+
+// NewVolumeFill creates a fault writing a file of sizeMB megabytes to the mount path in the pod's container.
+// The mount path has to be on a persistent volume claim. If sizeMB is 0, the volume is filled up completely.
+func NewVolumeFill(tc *targetcluster.TargetCluster, namespace, podName, container, mountPath string, sizeMB int) *VolumeFill {
+	return &VolumeFill{
+		targetCluster: tc,
+		namespace:     namespace,
+		podName:       podName,
+		container:     container,
+		mountPath:     mountPath,
+		sizeMB:        sizeMB,
+	}
+}
+
+func (f *VolumeFill) Name() string {
+	size := "all space"
+	if f.sizeMB > 0 {
+		size = fmt.Sprintf("%dMB", f.sizeMB)
+	}
+	return fmt.Sprintf("fill %s of %s in %s/%s", size, f.mountPath, f.namespace, f.podName)
+}
+
+func (f *VolumeFill) Inject(ctx context.Context) error {
+	file := path.Join(f.mountPath, fillFileName)
+	var script string
+	if f.sizeMB > 0 {
+		script = fmt.Sprintf("fallocate -l %dM %[2]s || dd if=/dev/zero of=%[2]s bs=1M count=%[1]d", f.sizeMB, file)
+	} else {
+		// dd fails with "No space left on device" once the volume is full, which is the expected result.
+		script = fmt.Sprintf("dd if=/dev/zero of=%s bs=1M || true", file)
+	}
+	_, stderr, err := f.targetCluster.ExecInPod(ctx, f.namespace, f.podName, f.container, []string{"sh", "-c", script})
+	if err != nil {
+		return fmt.Errorf("filling volume %s in pod %s: %w: %s", f.mountPath, f.podName, err, stderr)
+	}
+	return nil
+}
+
+func (f *VolumeFill) Revert(ctx context.Context) error {
+	file := path.Join(f.mountPath, fillFileName)
+	_, stderr, err := f.targetCluster.ExecInPod(ctx, f.namespace, f.podName, f.container, []string{"rm", "-f", file})
+	if err == nil {
+		return nil
+	}
+	if cleanupErr := f.removeWithCleanupPod(ctx); cleanupErr != nil {
+		return fmt.Errorf("removing %s in pod %s: %v: %s, removing it with a cleanup pod: %w", file, f.podName, err, stderr, cleanupErr)
+	}
+	return nil
+}
+
+// removeWithCleanupPod removes the file in a pod running on the node of the filled pod, so the volume can be mounted
+// even if its access mode is ReadWriteOnce.
+func (f *VolumeFill) removeWithCleanupPod(ctx context.Context) error {
+	pods := f.targetCluster.Clientset.CoreV1().Pods(f.namespace)
+	pod, err := pods.Get(ctx, f.podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("getting pod %s: %w", f.podName, err)
+	}
+	cleanup, err := newVolumeCleanupPod(pod, f.container, f.mountPath)
+	if err != nil {
+		return err
+	}
+
+	err = pods.Delete(ctx, cleanup.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting previous cleanup pod %s: %w", cleanup.Name, err)
+	}
+	if _, err := pods.Create(ctx, cleanup, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating cleanup pod %s: %w", cleanup.Name, err)
+	}
+	defer func() {
+		_ = pods.Delete(context.Background(), cleanup.Name, metav1.DeleteOptions{})
+	}()
+
+	return wait.PollUntilContextTimeout(ctx, pollInterval, RecoveryTimeout, true, func(ctx context.Context) (bool, error) {
+		current, err := pods.Get(ctx, cleanup.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		switch current.Status.Phase {
+		case corev1.PodSucceeded:
+			return true, nil
+		case corev1.PodFailed:
+			return false, fmt.Errorf("cleanup pod %s failed", cleanup.Name)
+		}
+		return false, nil
+	})
+}
+
+// newVolumeCleanupPod returns a pod removing the fill file from the persistent volume claim mounted at mountPath in
+// the container of the pod. It runs the image of the container as the same user on the same node.
+func newVolumeCleanupPod(pod *corev1.Pod, containerName, mountPath string) (*corev1.Pod, error) {
+	var container *corev1.Container
+	for i := range pod.Spec.Containers {
+		if containerName == "" || pod.Spec.Containers[i].Name == containerName {
+			container = &pod.Spec.Containers[i]
+			break
+		}
+	}
+	if container == nil {
+		return nil, fmt.Errorf("pod %s has no container %q", pod.Name, containerName)
+	}
+
+	// The mount closest to the path contains it.
+	var mount *corev1.VolumeMount
+	for i, candidate := range container.VolumeMounts {
+		root := strings.TrimSuffix(candidate.MountPath, "/")
+		if mountPath != root && !strings.HasPrefix(mountPath, root+"/") {
+			continue
+		}
+		if mount == nil || len(candidate.MountPath) > len(mount.MountPath) {
+			mount = &container.VolumeMounts[i]
+		}
+	}
+	if mount == nil {
+		return nil, fmt.Errorf("no volume mounted at %s in container %s of pod %s", mountPath, container.Name, pod.Name)
+	}
+	var claim *corev1.PersistentVolumeClaimVolumeSource
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == mount.Name {
+			claim = volume.PersistentVolumeClaim
+		}
+	}
+	if claim == nil {
+		return nil, fmt.Errorf("volume %s of pod %s is not a persistent volume claim", mount.Name, pod.Name)
+	}
+
+	relativePath := strings.TrimPrefix(mountPath, strings.TrimSuffix(mount.MountPath, "/"))
+	file := path.Join(cleanupMountPath, relativePath, fillFileName)
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name + "-chaos-cleanup",
+			Namespace: pod.Namespace,
+		},
+		Spec: corev1.PodSpec{
+			NodeName:        pod.Spec.NodeName,
+			RestartPolicy:   corev1.RestartPolicyNever,
+			SecurityContext: pod.Spec.SecurityContext,
+			Containers: []corev1.Container{{
+				Name:            "cleanup",
+				Image:           container.Image,
+				Command:         []string{"rm", "-f", file},
+				SecurityContext: container.SecurityContext,
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      mount.Name,
+					MountPath: cleanupMountPath,
+					SubPath:   mount.SubPath,
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         mount.Name,
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: claim},
+			}},
+		},
+	}, nil
+}
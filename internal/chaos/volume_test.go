@@ -0,0 +1,56 @@
+package chaos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestNewVolumeCleanupPod(t *testing.T) {
+	securityContext := &corev1.SecurityContext{RunAsUser: pointer.Int64(1000)}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "pds-test", Name: "pg-0"},
+		Spec: corev1.PodSpec{
+			NodeName: testNodeName,
+			Containers: []corev1.Container{
+				{Name: "sidecar", Image: "sidecar:1"},
+				{
+					Name:            "postgresql",
+					Image:           "postgresql:14",
+					SecurityContext: securityContext,
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "config", MountPath: "/etc/postgresql"},
+						{Name: "pxd", MountPath: "/pgdata", SubPath: "data"},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+				{Name: "pxd", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pxd-pg-0"}}},
+			},
+		},
+	}
+
+	cleanup, err := newVolumeCleanupPod(pod, "postgresql", "/pgdata/pg14")
+	require.NoError(t, err)
+	assert.Equal(t, "pg-0-chaos-cleanup", cleanup.Name)
+	assert.Equal(t, testNodeName, cleanup.Spec.NodeName, "The volume can be mounted on the node of the pod only.")
+	require.Len(t, cleanup.Spec.Containers, 1)
+	container := cleanup.Spec.Containers[0]
+	assert.Equal(t, "postgresql:14", container.Image)
+	assert.Equal(t, securityContext, container.SecurityContext)
+	assert.Equal(t, []string{"rm", "-f", "/volume/pg14/.chaos-fill"}, container.Command)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "pxd", MountPath: "/volume", SubPath: "data"}}, container.VolumeMounts)
+	assert.Equal(t, "pxd-pg-0", cleanup.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+	_, err = newVolumeCleanupPod(pod, "postgresql", "/etc/postgresql")
+	assert.Error(t, err, "Volume is not a persistent volume claim.")
+	_, err = newVolumeCleanupPod(pod, "postgresql", "/var/lib")
+	assert.Error(t, err, "No volume is mounted at the path.")
+	_, err = newVolumeCleanupPod(pod, "mysql", "/pgdata")
+	assert.Error(t, err, "Pod has no such container.")
+}
@@ -2,16 +2,30 @@ package controlplane
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	apiv1 "github.com/portworx/pds-api-go-client/pds/v1alpha1"
 
 	"github.com/portworx/pds-integration-test/internal/api"
 )
 
+// ObjectStoreKind is a kind of the object store used as a backup target.
+type ObjectStoreKind string
+
+const (
+	ObjectStoreS3           ObjectStoreKind = "s3"
+	ObjectStoreS3Compatible ObjectStoreKind = "s3-compatible"
+	ObjectStoreAzure        ObjectStoreKind = "azure"
+)
+
 type BackupCredentials struct {
-	S3 S3Credentials
+	S3           S3Credentials
+	S3Compatible S3CompatibleCredentials
+	Azure        AzureCredentials
 }
 
 type S3Credentials struct {
@@ -20,6 +34,51 @@ type S3Credentials struct {
 	SecretKey string
 }
 
+// S3CompatibleCredentials are credentials for an S3-compatible object store with a custom endpoint, e.g. MinIO.
+type S3CompatibleCredentials struct {
+	AccessKey string
+	Endpoint  string
+	SecretKey string
+	// PathStyle forces path-style bucket addressing (http://endpoint/bucket) instead of virtual-hosted style.
+	PathStyle bool
+}
+
+type AzureCredentials struct {
+	AccountName string
+	AccountKey  string
+}
+
+// ToControllersCredentials converts credentials of the given object store kind to the PDS API model.
+func (c BackupCredentials) ToControllersCredentials(kind ObjectStoreKind) (apiv1.ControllersCredentials, error) {
+	switch kind {
+	case ObjectStoreS3:
+		return apiv1.ControllersCredentials{
+			S3: &apiv1.ModelsS3Credentials{
+				Endpoint:  &c.S3.Endpoint,
+				AccessKey: &c.S3.AccessKey,
+				SecretKey: &c.S3.SecretKey,
+			},
+		}, nil
+	case ObjectStoreS3Compatible:
+		return apiv1.ControllersCredentials{
+			S3Compatible: &apiv1.ModelsS3CompatibleCredentials{
+				Endpoint:  &c.S3Compatible.Endpoint,
+				AccessKey: &c.S3Compatible.AccessKey,
+				SecretKey: &c.S3Compatible.SecretKey,
+			},
+		}, nil
+	case ObjectStoreAzure:
+		return apiv1.ControllersCredentials{
+			Azure: &apiv1.ModelsAzureCredentials{
+				AccountName: &c.Azure.AccountName,
+				AccountKey:  &c.Azure.AccountKey,
+			},
+		}, nil
+	default:
+		return apiv1.ControllersCredentials{}, fmt.Errorf("unsupported object store kind %q", kind)
+	}
+}
+
 func (c *ControlPlane) MustCreateS3BackupCredentials(ctx context.Context, t *testing.T, s3Creds S3Credentials, credName string) *apiv1.ModelsBackupCredentials {
 	credentials := apiv1.ControllersCredentials{
 		S3: &apiv1.ModelsS3Credentials{
@@ -32,6 +91,22 @@ func (c *ControlPlane) MustCreateS3BackupCredentials(ctx context.Context, t *tes
 	return c.MustCreateBackupCredentials(ctx, t, credName, credentials)
 }
 
+func (c *ControlPlane) MustCreateS3CompatibleBackupCredentials(ctx context.Context, t *testing.T, s3Creds S3CompatibleCredentials, credName string) *apiv1.ModelsBackupCredentials {
+	return c.MustCreateBackupCredentialsForKind(ctx, t, ObjectStoreS3Compatible, BackupCredentials{S3Compatible: s3Creds}, credName)
+}
+
+func (c *ControlPlane) MustCreateAzureBackupCredentials(ctx context.Context, t *testing.T, azureCreds AzureCredentials, credName string) *apiv1.ModelsBackupCredentials {
+	return c.MustCreateBackupCredentialsForKind(ctx, t, ObjectStoreAzure, BackupCredentials{Azure: azureCreds}, credName)
+}
+
+// MustCreateBackupCredentialsForKind creates backup credentials for the given object store kind.
+func (c *ControlPlane) MustCreateBackupCredentialsForKind(ctx context.Context, t *testing.T, kind ObjectStoreKind, creds BackupCredentials, credName string) *apiv1.ModelsBackupCredentials {
+	credentials, err := creds.ToControllersCredentials(kind)
+	require.NoError(t, err)
+
+	return c.MustCreateBackupCredentials(ctx, t, credName, credentials)
+}
+
 func (s *ControlPlane) MustCreateGoogleBackupCredentials(ctx context.Context, t *testing.T, credName string) *apiv1.ModelsBackupCredentials {
 	myCreds := "{\"creds\": \"fake-creds\"}"
 	credentials := apiv1.ControllersCredentials{
@@ -3,6 +3,7 @@ package controlplane
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -83,3 +84,23 @@ func (c *ControlPlane) MustEnsureNBackupJobsSuccessFromSchedule(ctx context.Cont
 		require.GreaterOrEqual(t, successfulBackupJobs, expectedBackups, "Expected at least %v successful backup jobs", expectedBackups)
 	})
 }
+
+// MustWaitForBackupJobSuccessAfter waits for a succeeded backup job of the backup that started after the given time,
+// e.g. a scheduled backup after an upgrade. Counting jobs is not reliable because the retention of the backup policy
+// removes old jobs.
+func (c *ControlPlane) MustWaitForBackupJobSuccessAfter(ctx context.Context, t tests.T, projectID, backupID string, after time.Time) {
+	wait.For(t, wait.LongTimeout, wait.RetryInterval, func(t tests.T) {
+		backupJobs := c.MustListBackupJobsInProject(ctx, t, projectID, WithListBackupJobsInProjectBackupID(backupID))
+		for _, backupJob := range backupJobs {
+			if backupJob.GetCompletionStatus() != string(backupsv1.BackupJobSucceeded) {
+				continue
+			}
+			startTime, err := time.Parse(time.RFC3339, backupJob.GetStartTime())
+			require.NoErrorf(t, err, "Parsing start time of backup job %s.", backupJob.GetName())
+			if startTime.After(after) {
+				return
+			}
+		}
+		require.Failf(t, "No successful backup job.", "Backup %s has no successful backup job started after %s.", backupID, after.Format(time.RFC3339))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,37 +18,72 @@ import (
 	"github.com/portworx/pds-integration-test/internal/wait"
 )
 
+const (
+	// BackupTargetStateSuccessful is the state of a backup target successfully created in the deployment target.
+	BackupTargetStateSuccessful   = "successful"
+	backupTargetStateFailedPrefix = "failed"
+)
+
 func (c *ControlPlane) CreateS3BackupTarget(ctx context.Context, backupCredentialsID, bucket, region string) (*pds.ModelsBackupTarget, *http.Response, error) {
+	return c.CreateBackupTargetForKind(ctx, ObjectStoreS3, backupCredentialsID, bucket, region)
+}
+
+func (c *ControlPlane) MustCreateS3BackupTarget(ctx context.Context, t tests.T, backupCredentialsID, bucket, region string) *pds.ModelsBackupTarget {
+	return c.MustCreateBackupTargetForKind(ctx, t, ObjectStoreS3, backupCredentialsID, bucket, region)
+}
+
+// CreateBackupTargetForKind creates a backup target of the given object store kind.
+// For Azure the bucket is the name of the blob container and the region is ignored.
+func (c *ControlPlane) CreateBackupTargetForKind(ctx context.Context, kind ObjectStoreKind, backupCredentialsID, bucket, region string) (*pds.ModelsBackupTarget, *http.Response, error) {
 	tenantID := c.TestPDSTenantID
 	nameSuffix := random.AlphaNumericString(random.NameSuffixLength)
-	name := fmt.Sprintf("integration-test-s3-%s", nameSuffix)
+	name := fmt.Sprintf("integration-test-%s-%s", kind, nameSuffix)
 
 	requestBody := pds.ControllersCreateTenantBackupTarget{
 		Name:                &name,
 		BackupCredentialsId: &backupCredentialsID,
 		Bucket:              &bucket,
-		Region:              &region,
-		Type:                pointer.String("s3"),
+		Type:                pointer.String(string(kind)),
+	}
+	if kind != ObjectStoreAzure {
+		requestBody.Region = &region
 	}
 	return c.PDS.BackupTargetsApi.ApiTenantsIdBackupTargetsPost(ctx, tenantID).Body(requestBody).Execute()
 }
 
-func (c *ControlPlane) MustCreateS3BackupTarget(ctx context.Context, t tests.T, backupCredentialsID, bucket, region string) *pds.ModelsBackupTarget {
-	backupTarget, resp, err := c.CreateS3BackupTarget(ctx, backupCredentialsID, bucket, region)
+func (c *ControlPlane) MustCreateBackupTargetForKind(ctx context.Context, t tests.T, kind ObjectStoreKind, backupCredentialsID, bucket, region string) *pds.ModelsBackupTarget {
+	backupTarget, resp, err := c.CreateBackupTargetForKind(ctx, kind, backupCredentialsID, bucket, region)
 	api.RequireNoError(t, resp, err)
 	return backupTarget
 }
 
 func (c *ControlPlane) MustEnsureBackupTargetCreatedInTC(ctx context.Context, t tests.T, backupTargetID string) {
-	c.MustWaitForBackupTargetState(ctx, t, backupTargetID, "successful")
+	c.MustWaitForBackupTargetState(ctx, t, backupTargetID, BackupTargetStateSuccessful)
 }
 
+// MustWaitForBackupTargetState waits until the backup target reaches a final state and requires it to be the expected one.
 func (c *ControlPlane) MustWaitForBackupTargetState(ctx context.Context, t tests.T, backupTargetID, expectedFinalState string) {
+	backupTargetState := c.MustWaitForBackupTargetFinalState(ctx, t, backupTargetID)
+	require.Equalf(t, expectedFinalState, backupTargetState.GetState(),
+		"Backup target %s failed to end up in %s state to deployment target %s.", backupTargetID, expectedFinalState, c.testPDSDeploymentTargetID)
+}
+
+// MustWaitForBackupTargetFinalState waits until the backup target is either successfully created in the deployment
+// target or failed, and returns its state.
+func (c *ControlPlane) MustWaitForBackupTargetFinalState(ctx context.Context, t tests.T, backupTargetID string) pds.ModelsBackupTargetState {
+	var backupTargetState pds.ModelsBackupTargetState
 	wait.For(t, wait.ShortTimeout, wait.ShortRetryInterval, func(t tests.T) {
-		backupTargetState := c.MustGetBackupTargetState(ctx, t, backupTargetID)
-		require.Equalf(t, expectedFinalState, backupTargetState.GetState(),
-			"Backup target %s failed to end up in %s state to deployment target %s.", backupTargetID, expectedFinalState, c.testPDSDeploymentTargetID)
+		backupTargetState = c.MustGetBackupTargetState(ctx, t, backupTargetID)
+		require.Truef(t, IsBackupTargetStateFinal(backupTargetState.GetState()),
+			"Backup target %s is still in state %s in deployment target %s.", backupTargetID, backupTargetState.GetState(), c.testPDSDeploymentTargetID)
 	})
+	return backupTargetState
+}
+
+// IsBackupTargetStateFinal returns true if the backup target state doesn't change anymore, i.e. it is successful or
+// failed, e.g. "failed_create".
+func IsBackupTargetStateFinal(state string) bool {
+	return state == BackupTargetStateSuccessful || strings.HasPrefix(state, backupTargetStateFailedPrefix)
 }
 
 func (c *ControlPlane) MustGetBackupTargetState(ctx context.Context, t tests.T, backupTargetID string) pds.ModelsBackupTargetState {
@@ -41,7 +41,21 @@ func (c *ControlPlane) MustDeployDeploymentSpecIntoNamespace(ctx context.Context
 	return deploymentID
 }
 
+// MustDeployDeploymentSpecToTarget deploys the deployment to the namespace of the given deployment target.
+func (c *ControlPlane) MustDeployDeploymentSpecToTarget(ctx context.Context, t *testing.T, deployment *api.ShortDeploymentSpec, deploymentTargetID, namespaceID string) string {
+	deploymentID, err := c.DeployDeploymentSpecToTarget(ctx, deployment, deploymentTargetID, namespaceID)
+	require.NoError(t, err, "Error while creating deployment %s on deployment target %s.", deployment.DataServiceName, deploymentTargetID)
+	require.NotEmpty(t, deploymentID, "Deployment ID is empty.")
+
+	return deploymentID
+}
+
 func (c *ControlPlane) DeployDeploymentSpec(ctx context.Context, deployment *api.ShortDeploymentSpec, namespaceID string) (string, error) {
+	return c.DeployDeploymentSpecToTarget(ctx, deployment, c.testPDSDeploymentTargetID, namespaceID)
+}
+
+// DeployDeploymentSpecToTarget deploys the deployment to the namespace of the given deployment target.
+func (c *ControlPlane) DeployDeploymentSpecToTarget(ctx context.Context, deployment *api.ShortDeploymentSpec, deploymentTargetID, namespaceID string) (string, error) {
 	image := c.findImageVersionForRecord(deployment)
 	if image == nil {
 		return "", fmt.Errorf("no image found for deployment %s %s %s", deployment.DataServiceName, deployment.ImageVersionTag, deployment.ImageVersionBuild)
@@ -49,7 +63,7 @@ func (c *ControlPlane) DeployDeploymentSpec(ctx context.Context, deployment *api
 
 	c.setDeploymentDefaults(deployment)
 
-	return c.PDS.CreateDeployment(ctx, deployment, image, c.TestPDSTenantID, c.testPDSDeploymentTargetID, c.TestPDSProjectID, namespaceID)
+	return c.PDS.CreateDeployment(ctx, deployment, image, c.TestPDSTenantID, deploymentTargetID, c.TestPDSProjectID, namespaceID)
 }
 
 func (c *ControlPlane) setDeploymentDefaults(deployment *api.ShortDeploymentSpec) {
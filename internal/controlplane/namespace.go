@@ -20,12 +20,17 @@ func (c *ControlPlane) MustWaitForTestNamespace(ctx context.Context, t tests.T,
 }
 
 func (c *ControlPlane) MustWaitForNamespaceStatus(ctx context.Context, t tests.T, name, expectedStatus string) *pds.ModelsNamespace {
+	return c.MustWaitForNamespaceStatusOnTarget(ctx, t, c.testPDSDeploymentTargetID, name, expectedStatus)
+}
+
+// MustWaitForNamespaceStatusOnTarget waits for the namespace of the given deployment target to reach the expected status.
+func (c *ControlPlane) MustWaitForNamespaceStatusOnTarget(ctx context.Context, t tests.T, deploymentTargetID, name, expectedStatus string) *pds.ModelsNamespace {
 	var (
 		namespace *pds.ModelsNamespace
 		err       error
 	)
 	wait.For(t, wait.ShortTimeout, wait.ShortRetryInterval, func(t tests.T) {
-		namespace, err = c.PDS.GetNamespaceByName(ctx, c.testPDSDeploymentTargetID, name)
+		namespace, err = c.PDS.GetNamespaceByName(ctx, deploymentTargetID, name)
 		require.NoErrorf(t, err, "Getting namespace %s.", name)
 		require.NotNilf(t, namespace, "Could not find namespace %s.", name)
 		require.Equalf(t, expectedStatus, namespace.GetStatus(), "Namespace %s not in status %s.", name, expectedStatus)
@@ -0,0 +1,71 @@
+package controlplane
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/utils/pointer"
+
+	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
+
+	"github.com/portworx/pds-integration-test/internal/pairwise"
+)
+
+// StorageOptions is a single combination of storage options template settings.
+type StorageOptions struct {
+	Repl        int32
+	Fs          string
+	Secure      bool
+	ForceSpread bool
+	Provisioner string
+}
+
+// StorageOptionsDimensions holds all values of every storage option which should be combined.
+type StorageOptionsDimensions struct {
+	Repl        []int32
+	Fs          []string
+	Secure      []bool
+	ForceSpread []bool
+	Provisioner []string
+}
+
+// String returns a short human-readable name of the combination, e.g. "repl2-ext4-secure-fg-auto".
+func (o StorageOptions) String() string {
+	parts := []string{fmt.Sprintf("repl%d", o.Repl), o.Fs}
+	if o.Secure {
+		parts = append(parts, "secure")
+	}
+	if o.ForceSpread {
+		parts = append(parts, "fg")
+	}
+	parts = append(parts, o.Provisioner)
+	return strings.Join(parts, "-")
+}
+
+// ToTemplateRequest converts the combination to a storage options template request with the given name.
+func (o StorageOptions) ToTemplateRequest(name string) pds.ControllersCreateStorageOptionsTemplateRequest {
+	return pds.ControllersCreateStorageOptionsTemplateRequest{
+		Name:        pointer.String(name),
+		Repl:        pointer.Int32(o.Repl),
+		Secure:      pointer.Bool(o.Secure),
+		Fs:          pointer.String(o.Fs),
+		Fg:          pointer.Bool(o.ForceSpread),
+		Provisioner: pointer.String(o.Provisioner),
+	}
+}
+
+// PairwiseStorageOptions returns combinations of the dimensions values which cover every pair of values at least once.
+func PairwiseStorageOptions(d StorageOptionsDimensions) []StorageOptions {
+	var storageOptions []StorageOptions
+	combinations := pairwise.Combinations(len(d.Repl), len(d.Fs), len(d.Secure), len(d.ForceSpread), len(d.Provisioner))
+	for _, c := range combinations {
+		storageOptions = append(storageOptions, StorageOptions{
+			Repl:        d.Repl[c[0]],
+			Fs:          d.Fs[c[1]],
+			Secure:      d.Secure[c[2]],
+			ForceSpread: d.ForceSpread[c[3]],
+			Provisioner: d.Provisioner[c[4]],
+		})
+	}
+	return storageOptions
+}
@@ -21,8 +21,10 @@ func (c *CrossClusterHelper) MustEnsureBackupSuccessful(ctx context.Context, t t
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
 
-	namespace := namespaceModel.GetName()
+	return c.forDeployment(deployment).mustEnsureBackupSuccessfulInNamespace(ctx, t, namespaceModel.GetName(), deploymentID, backupName)
+}
 
+func (c *CrossClusterHelper) mustEnsureBackupSuccessfulInNamespace(ctx context.Context, t tests.T, namespace, deploymentID, backupName string) (needsRetry bool) {
 	// 1. Wait for the backup to finish.
 	wait.For(t, wait.LongTimeout, wait.RetryInterval, func(t tests.T) {
 		pdsBackup, err := c.targetCluster.GetPDSBackup(ctx, namespace, backupName)
@@ -34,18 +36,14 @@ func (c *CrossClusterHelper) MustEnsureBackupSuccessful(ctx context.Context, t t
 	pdsBackup, err := c.targetCluster.GetPDSBackup(ctx, namespace, backupName)
 	require.NoError(t, err)
 
-	if isBackupFailed(pdsBackup) {
+	// A successful retry of a failed backup job makes the backup successful.
+	if isBackupFailed(pdsBackup) && !isBackupSucceeded(pdsBackup) {
 		// Backup failed.
-		backupJobs := pdsBackup.Status.BackupJobs
-		var backupJobName string
-		if len(backupJobs) > 0 {
-			backupJobName = backupJobs[0].Name
-		}
+		backupJobName := lastFailedBackupJobName(pdsBackup)
 		logs, err := c.targetCluster.GetJobLogs(ctx, namespace, backupJobName, c.startTime)
 		if err != nil {
 			require.Fail(t, fmt.Sprintf("Backup '%s' failed.", backupName))
-		} else if strings.Contains(logs, "HINT: is another pgBackRest process running?") {
-			// Backup failed on PostgreSQL as the automatic initial pgbackrest backup after deployment is still running.
+		} else if isBackupRetryable(logs) {
 			// This is not a backup failure, let's retry the backup call a bit later.
 			needsRetry = true
 			return
@@ -57,6 +55,18 @@ func (c *CrossClusterHelper) MustEnsureBackupSuccessful(ctx context.Context, t t
 	return
 }
 
+// MustPassBackupPodSecurityAudit checks that the jobs of the backup and their pods comply with the pod security level.
+func (c *CrossClusterHelper) MustPassBackupPodSecurityAudit(ctx context.Context, t tests.T, namespace, backupName, level string) {
+	pdsBackup, err := c.targetCluster.GetPDSBackup(ctx, namespace, backupName)
+	require.NoErrorf(t, err, "Getting backup %s/%s from target cluster.", namespace, backupName)
+
+	var jobNames []string
+	for _, backupJob := range pdsBackup.Status.BackupJobs {
+		jobNames = append(jobNames, backupJob.Name)
+	}
+	c.targetCluster.MustPassJobsPodSecurityAudit(ctx, t, namespace, level, jobNames)
+}
+
 func GetBackupSnapshotID(backup *backupsv1.Backup) (string, error) {
 	if !isBackupSucceeded(backup) {
 		return "", fmt.Errorf("no succeded backups")
@@ -71,6 +81,22 @@ func GetBackupSnapshotID(backup *backupsv1.Backup) (string, error) {
 	return snapshotID, nil
 }
 
+// isBackupRetryable checks whether the backup job failed only because of a conflicting operation,
+// e.g. on PostgreSQL the automatic initial pgbackrest backup after deployment is still running.
+func isBackupRetryable(logs string) bool {
+	return strings.Contains(logs, "HINT: is another pgBackRest process running?")
+}
+
+func lastFailedBackupJobName(backup *backupsv1.Backup) string {
+	backupJobs := backup.Status.BackupJobs
+	for i := len(backupJobs) - 1; i >= 0; i-- {
+		if backupJobs[i].CompletionStatus == backupsv1.BackupJobFailed {
+			return backupJobs[i].Name
+		}
+	}
+	return ""
+}
+
 func isBackupFinished(backup *backupsv1.Backup) bool {
 	return isBackupSucceeded(backup) || isBackupFailed(backup)
 }
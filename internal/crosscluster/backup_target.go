@@ -0,0 +1,202 @@
+package crosscluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+
+	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
+
+	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/portworx"
+	"github.com/portworx/pds-integration-test/internal/tests"
+)
+
+// BackupTargetFailureCause is the error code reported by the control plane for a failed backup target state.
+type BackupTargetFailureCause string
+
+const (
+	BackupTargetFailurePXCredentials BackupTargetFailureCause = "failed_to_create_px_credentials"
+	BackupTargetFailureUnknown       BackupTargetFailureCause = "unknown"
+)
+
+// BackupTargetFailureReason is the reason of the failure recognized from the error details.
+type BackupTargetFailureReason string
+
+const (
+	BackupTargetReasonBucketNotFound      BackupTargetFailureReason = "bucket_not_found"
+	BackupTargetReasonWrongRegion         BackupTargetFailureReason = "wrong_region"
+	BackupTargetReasonInvalidCredentials  BackupTargetFailureReason = "invalid_credentials"
+	BackupTargetReasonEndpointUnreachable BackupTargetFailureReason = "endpoint_unreachable"
+	BackupTargetReasonUnknown             BackupTargetFailureReason = "unknown"
+)
+
+// backupTargetReasonPatterns maps known object store error messages to failure reasons.
+// The reasons are checked in order, the first matching one wins.
+var backupTargetReasonPatterns = []struct {
+	reason   BackupTargetFailureReason
+	patterns []string
+}{
+	{BackupTargetReasonBucketNotFound, []string{"NoSuchBucket", "bucket does not exist", "ContainerNotFound"}},
+	{BackupTargetReasonWrongRegion, []string{"AuthorizationHeaderMalformed", "PermanentRedirect", "IllegalLocationConstraintException"}},
+	{BackupTargetReasonInvalidCredentials, []string{"InvalidAccessKeyId", "SignatureDoesNotMatch", "AccessDenied", "AuthenticationFailed", "Forbidden"}},
+	{BackupTargetReasonEndpointUnreachable, []string{"no such host", "dial tcp", "connection refused", "i/o timeout"}},
+}
+
+// BackupTargetFailure holds diagnostics of a backup target which failed in the target cluster.
+type BackupTargetFailure struct {
+	BackupTargetID string
+	State          string
+	Cause          BackupTargetFailureCause
+	Reason         BackupTargetFailureReason
+	ErrorCode      string
+	ErrorMessage   string
+	ErrorDetails   string
+
+	// PXCloudCredential is the corresponding Portworx cloud credential, nil if it doesn't exist.
+	PXCloudCredential      *portworx.PXCloudCredential
+	PXCloudCredentialError error
+
+	// OperatorLogs are logs of the PDS operator pods keyed by pod name.
+	OperatorLogs map[string]string
+}
+
+func (f *BackupTargetFailure) Error() string {
+	return fmt.Sprintf("backup target %s is in state %s (cause: %s, reason: %s): %s: %s",
+		f.BackupTargetID, f.State, f.Cause, f.Reason, f.ErrorMessage, f.ErrorDetails)
+}
+
+// String returns a human-readable summary of the diagnostics. The operator logs are only listed by pod name,
+// see WriteOperatorLogs.
+func (f *BackupTargetFailure) String() string {
+	var sb strings.Builder
+	sb.WriteString(f.Error())
+	sb.WriteString("\n")
+	if f.PXCloudCredential != nil {
+		fmt.Fprintf(&sb, "PX cloud credential: id=%s name=%s bucket=%s\n", f.PXCloudCredential.ID, f.PXCloudCredential.Name, f.PXCloudCredential.Bucket)
+	} else {
+		fmt.Fprintf(&sb, "PX cloud credential not found: %v\n", f.PXCloudCredentialError)
+	}
+	fmt.Fprintf(&sb, "Operator logs collected from: %s\n", strings.Join(f.operatorPodNames(), ", "))
+	return sb.String()
+}
+
+// WriteOperatorLogs writes the logs of each PDS operator pod to a separate file in the directory.
+// It returns the paths of the written files.
+func (f *BackupTargetFailure) WriteOperatorLogs(dir string) ([]string, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("creating directory %s: %w", dir, err)
+	}
+	var paths []string
+	for _, podName := range f.operatorPodNames() {
+		path := filepath.Join(dir, podName+".log")
+		err = os.WriteFile(path, []byte(f.OperatorLogs[podName]), 0o644)
+		if err != nil {
+			return paths, fmt.Errorf("writing logs of %s: %w", podName, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func (f *BackupTargetFailure) operatorPodNames() []string {
+	podNames := make([]string, 0, len(f.OperatorLogs))
+	for podName := range f.OperatorLogs {
+		podNames = append(podNames, podName)
+	}
+	sort.Strings(podNames)
+	return podNames
+}
+
+// MustEnsureBackupTargetCreatedInTC waits for the backup target to be successfully created in the target cluster.
+// If the backup target fails, the test fails immediately with the failure diagnostics.
+func (c *CrossClusterHelper) MustEnsureBackupTargetCreatedInTC(ctx context.Context, t tests.T, backupTargetID string) {
+	state := c.controlPlane.MustWaitForBackupTargetFinalState(ctx, t, backupTargetID)
+	if state.GetState() == controlplane.BackupTargetStateSuccessful {
+		return
+	}
+	failure := c.GetBackupTargetFailure(ctx, state)
+	c.writeBackupTargetFailureLogs(t, failure)
+	require.Fail(t, "Backup target was not created in target cluster.", failure.String())
+}
+
+// MustWaitForBackupTargetFailure waits for the backup target to fail and returns the failure diagnostics.
+func (c *CrossClusterHelper) MustWaitForBackupTargetFailure(ctx context.Context, t tests.T, backupTargetID string) *BackupTargetFailure {
+	state := c.controlPlane.MustWaitForBackupTargetFinalState(ctx, t, backupTargetID)
+	require.NotEqualf(t, controlplane.BackupTargetStateSuccessful, state.GetState(), "Backup target %s was expected to fail.", backupTargetID)
+
+	failure := c.GetBackupTargetFailure(ctx, state)
+	c.writeBackupTargetFailureLogs(t, failure)
+	t.Logf("Backup target failure diagnostics: %s", failure)
+	return failure
+}
+
+// writeBackupTargetFailureLogs writes the operator logs of the failure to the artifacts directory, if it is set.
+func (c *CrossClusterHelper) writeBackupTargetFailureLogs(t tests.T, failure *BackupTargetFailure) {
+	if c.artifactsDir == "" {
+		return
+	}
+	dir := filepath.Join(targetcluster.TestArtifactsDir(c.artifactsDir, t.Name()), "backup-target-"+failure.BackupTargetID)
+	paths, err := failure.WriteOperatorLogs(dir)
+	if err != nil {
+		t.Logf("Writing operator logs of backup target %s failed: %v", failure.BackupTargetID, err)
+	}
+	for _, path := range paths {
+		t.Logf("Diagnostics artifact: %s", path)
+	}
+}
+
+// GetBackupTargetFailure collects the diagnostics of the failed backup target state.
+func (c *CrossClusterHelper) GetBackupTargetFailure(ctx context.Context, state pds.ModelsBackupTargetState) *BackupTargetFailure {
+	failure := &BackupTargetFailure{
+		BackupTargetID: state.GetBackupTargetId(),
+		State:          state.GetState(),
+		Cause:          BackupTargetFailureCause(state.GetErrorCode()),
+		ErrorCode:      state.GetErrorCode(),
+		ErrorMessage:   state.GetErrorMessage(),
+		ErrorDetails:   state.GetErrorDetails(),
+		Reason:         backupTargetFailureReason(state.GetErrorMessage() + " " + state.GetErrorDetails()),
+	}
+	if failure.Cause == "" {
+		failure.Cause = BackupTargetFailureUnknown
+	}
+
+	targetCluster := c.targetClusterFor(state.GetDeploymentTargetId())
+
+	if credentialsName := state.GetPxCredentialsName(); credentialsName != "" {
+		failure.PXCloudCredential, failure.PXCloudCredentialError = targetCluster.FindCloudCredentialByName(ctx, credentialsName)
+	} else {
+		failure.PXCloudCredentialError = fmt.Errorf("backup target state has no PX credentials name")
+	}
+
+	failure.OperatorLogs = make(map[string]string)
+	for _, operator := range []string{"backup", "target"} {
+		logs, err := targetCluster.GetPDSOperatorLogs(ctx, operator, c.startTime)
+		if err != nil {
+			failure.OperatorLogs[operator] = fmt.Sprintf("failed to get logs: %v", err)
+			continue
+		}
+		for podName, podLogs := range logs {
+			failure.OperatorLogs[podName] = podLogs
+		}
+	}
+	return failure
+}
+
+func backupTargetFailureReason(message string) BackupTargetFailureReason {
+	for _, r := range backupTargetReasonPatterns {
+		for _, pattern := range r.patterns {
+			if strings.Contains(strings.ToLower(message), strings.ToLower(pattern)) {
+				return r.reason
+			}
+		}
+	}
+	return BackupTargetReasonUnknown
+}
@@ -0,0 +1,61 @@
+package crosscluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupTargetFailureReason(t *testing.T) {
+	testCases := []struct {
+		message  string
+		expected BackupTargetFailureReason
+	}{
+		{"NoSuchBucket: The specified bucket does not exist", BackupTargetReasonBucketNotFound},
+		{"ContainerNotFound: The specified container does not exist.", BackupTargetReasonBucketNotFound},
+		{"AuthorizationHeaderMalformed: the region 'us-east-1' is wrong; expecting 'eu-west-1'", BackupTargetReasonWrongRegion},
+		{"PermanentRedirect: The bucket you are attempting to access must be addressed using the specified endpoint.", BackupTargetReasonWrongRegion},
+		{"InvalidAccessKeyId: The AWS Access Key Id you provided does not exist in our records.", BackupTargetReasonInvalidCredentials},
+		{"SignatureDoesNotMatch: The request signature we calculated does not match the signature you provided.", BackupTargetReasonInvalidCredentials},
+		{"AuthenticationFailed: Server failed to authenticate the request.", BackupTargetReasonInvalidCredentials},
+		{"accessdenied: access denied", BackupTargetReasonInvalidCredentials},
+		{`RequestError: send request failed: dial tcp: lookup xxx on 10.96.0.10:53: no such host`, BackupTargetReasonEndpointUnreachable},
+		{"connect: connection refused", BackupTargetReasonEndpointUnreachable},
+		// The bucket is checked before the credentials, both may be reported for a missing bucket.
+		{"AccessDenied: NoSuchBucket", BackupTargetReasonBucketNotFound},
+		{"failed to create credentials", BackupTargetReasonUnknown},
+		{"", BackupTargetReasonUnknown},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.message, func(t *testing.T) {
+			assert.Equal(t, tc.expected, backupTargetFailureReason(tc.message))
+		})
+	}
+}
+
+func TestBackupTargetFailure_WriteOperatorLogs(t *testing.T) {
+	failure := &BackupTargetFailure{
+		BackupTargetID: "target-id",
+		OperatorLogs: map[string]string{
+			"pds-target-operator-1": "target logs",
+			"pds-backup-operator-1": "backup logs",
+		},
+	}
+	dir := filepath.Join(t.TempDir(), "backup-target")
+
+	paths, err := failure.WriteOperatorLogs(dir)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "pds-backup-operator-1.log"),
+		filepath.Join(dir, "pds-target-operator-1.log"),
+	}, paths)
+	content, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "backup logs", string(content))
+	assert.NotContains(t, failure.String(), "backup logs")
+	assert.Contains(t, failure.String(), "pds-backup-operator-1, pds-target-operator-1")
+}
@@ -0,0 +1,178 @@
+package crosscluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	backupsv1 "github.com/portworx/pds-operator-backups/api/v1"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/fixtures"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+const (
+	testNamespace    = "pds-test"
+	testDeploymentID = "deployment-id"
+	testBackupName   = "backup"
+)
+
+func TestMustEnsureBackupSuccessful_Succeeded(t *testing.T) {
+	backup := fixtures.Backup(testNamespace, testBackupName, testDeploymentID,
+		fixtures.BackupJobStatus("job-1", backupsv1.BackupJobSucceeded, "snap-1"))
+	helper := newFakeHelper(t, backup)
+
+	rt := &recordingT{name: t.Name()}
+	needsRetry := helper.mustEnsureBackupSuccessfulInNamespace(context.Background(), rt, testNamespace, testDeploymentID, testBackupName)
+
+	assert.False(t, rt.Failed(), rt.messages)
+	assert.False(t, needsRetry)
+}
+
+func TestMustEnsureBackupSuccessful_FailedWithoutLogs(t *testing.T) {
+	backup := fixtures.Backup(testNamespace, testBackupName, testDeploymentID,
+		fixtures.BackupJobStatus("job-1", backupsv1.BackupJobFailed, ""))
+	helper := newFakeHelper(t, backup)
+
+	rt := &recordingT{name: t.Name()}
+	rt.run(func() {
+		helper.mustEnsureBackupSuccessfulInNamespace(context.Background(), rt, testNamespace, testDeploymentID, testBackupName)
+	})
+
+	assert.True(t, rt.Failed())
+	assert.Contains(t, rt.messages, fmt.Sprintf("Backup '%s' failed.", testBackupName))
+}
+
+func TestMustEnsureBackupSuccessful_RetryableFailure(t *testing.T) {
+	ctx := context.Background()
+	backup := fixtures.Backup(testNamespace, testBackupName, testDeploymentID,
+		fixtures.BackupJobStatus("job-1", backupsv1.BackupJobFailed, ""))
+	helper := newFakeHelper(t, backup, fixtures.JobPod(testNamespace, "job-1-abcde", "job-1"))
+	err := targetcluster.SetFakePodLogs(helper.targetCluster, testNamespace, "job-1-abcde",
+		"ERROR: [050]: unable to acquire lock\nHINT: is another pgBackRest process running?")
+	require.NoError(t, err)
+
+	// 1. The first job conflicts with another pgBackRest process, the backup has to be retried.
+	rt := &recordingT{name: t.Name()}
+	needsRetry := helper.mustEnsureBackupSuccessfulInNamespace(ctx, rt, testNamespace, testDeploymentID, testBackupName)
+
+	assert.False(t, rt.Failed(), rt.messages)
+	assert.True(t, needsRetry)
+
+	// 2. The retried job succeeds.
+	retried := fixtures.Backup(testNamespace, testBackupName, testDeploymentID,
+		fixtures.BackupJobStatus("job-1", backupsv1.BackupJobFailed, ""),
+		fixtures.BackupJobStatus("job-2", backupsv1.BackupJobSucceeded, "snap-2"))
+	mustUpdateBackupStatus(t, helper.targetCluster, retried)
+
+	rt = &recordingT{name: t.Name()}
+	needsRetry = helper.mustEnsureBackupSuccessfulInNamespace(ctx, rt, testNamespace, testDeploymentID, testBackupName)
+
+	assert.False(t, rt.Failed(), rt.messages)
+	assert.False(t, needsRetry)
+}
+
+func TestMustEnsureBackupSuccessful_FailedWithLogs(t *testing.T) {
+	backup := fixtures.Backup(testNamespace, testBackupName, testDeploymentID,
+		fixtures.BackupJobStatus("job-1", backupsv1.BackupJobFailed, ""),
+		fixtures.BackupJobStatus("job-2", backupsv1.BackupJobFailed, ""))
+	helper := newFakeHelper(t, backup,
+		fixtures.JobPod(testNamespace, "job-1-abcde", "job-1"),
+		fixtures.JobPod(testNamespace, "job-2-abcde", "job-2"))
+	require.NoError(t, targetcluster.SetFakePodLogs(helper.targetCluster, testNamespace, "job-1-abcde", "HINT: is another pgBackRest process running?"))
+	require.NoError(t, targetcluster.SetFakePodLogs(helper.targetCluster, testNamespace, "job-2-abcde", "ERROR: access denied"))
+
+	rt := &recordingT{name: t.Name()}
+	var needsRetry bool
+	rt.run(func() {
+		needsRetry = helper.mustEnsureBackupSuccessfulInNamespace(context.Background(), rt, testNamespace, testDeploymentID, testBackupName)
+	})
+
+	// Only the logs of the last failed job are checked.
+	assert.True(t, rt.Failed())
+	assert.False(t, needsRetry)
+	assert.Contains(t, rt.messages, "Backup job 'job-2' failed.")
+	assert.Contains(t, rt.messages, "ERROR: access denied")
+}
+
+func TestIsBackupRetryable(t *testing.T) {
+	assert.True(t, isBackupRetryable("ERROR: [050]: unable to acquire lock\nHINT: is another pgBackRest process running?"))
+	assert.False(t, isBackupRetryable("ERROR: access denied"))
+}
+
+func TestGetBackupSnapshotID(t *testing.T) {
+	succeeded := fixtures.Backup(testNamespace, testBackupName, testDeploymentID,
+		fixtures.BackupJobStatus("job-1", backupsv1.BackupJobSucceeded, "snap-1"))
+	snapshotID, err := GetBackupSnapshotID(succeeded)
+	require.NoError(t, err)
+	assert.Equal(t, "snap-1", snapshotID)
+
+	failed := fixtures.Backup(testNamespace, testBackupName, testDeploymentID,
+		fixtures.BackupJobStatus("job-1", backupsv1.BackupJobFailed, ""))
+	_, err = GetBackupSnapshotID(failed)
+	assert.Error(t, err)
+}
+
+func mustUpdateBackupStatus(t *testing.T, tc *targetcluster.TargetCluster, backup *backupsv1.Backup) {
+	current, err := tc.GetPDSBackup(context.Background(), backup.Namespace, backup.Name)
+	require.NoError(t, err)
+	current.Status = backup.Status
+	require.NoError(t, tc.CtrlRuntimeClient.Update(context.Background(), current))
+}
+
+func newFakeHelper(t *testing.T, objects ...runtime.Object) *CrossClusterHelper {
+	return NewHelper(nil, fixtures.NewFakeTargetCluster(t, objects...), time.Now())
+}
+
+// recordingT records test failures instead of failing the real test,
+// so the failure paths of the Must helpers can be tested.
+type recordingT struct {
+	sync.Mutex
+	name     string
+	failed   bool
+	messages string
+}
+
+func (t *recordingT) run(fn func()) {
+	defer func() {
+		if r := recover(); r != nil && r != errFailNow {
+			panic(r)
+		}
+	}()
+	fn()
+}
+
+var errFailNow = fmt.Errorf("FailNow called")
+
+func (t *recordingT) fail(msg string) {
+	t.Lock()
+	defer t.Unlock()
+	t.failed = true
+	t.messages += msg + "\n"
+}
+
+func (t *recordingT) Failed() bool {
+	t.Lock()
+	defer t.Unlock()
+	return t.failed
+}
+
+func (t *recordingT) Error(args ...interface{})                 { t.fail(fmt.Sprint(args...)) }
+func (t *recordingT) Errorf(format string, args ...interface{}) { t.fail(fmt.Sprintf(format, args...)) }
+func (t *recordingT) Fail()                                     { t.fail("") }
+func (t *recordingT) FailNow()                                  { t.fail(""); panic(errFailNow) }
+func (t *recordingT) Fatal(args ...interface{})                 { t.Error(args...); panic(errFailNow) }
+func (t *recordingT) Fatalf(format string, args ...interface{}) {
+	t.Errorf(format, args...)
+	panic(errFailNow)
+}
+func (t *recordingT) Log(_ ...interface{})            {}
+func (t *recordingT) Logf(_ string, _ ...interface{}) {}
+func (t *recordingT) Name() string                    { return t.name }
+func (t *recordingT) Helper()                         {}
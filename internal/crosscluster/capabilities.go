@@ -0,0 +1,72 @@
+package crosscluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+
+	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
+
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+// MustWaitForCapabilitiesReported waits until all capabilities exported by the PDS chart operators
+// are reported to the control plane with the same versions.
+func (c *CrossClusterHelper) MustWaitForCapabilitiesReported(ctx context.Context, t tests.T) {
+	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
+		exported, err := c.targetCluster.GetPDSCapabilities(ctx)
+		require.NoError(t, err, "Getting capabilities exported by PDS operators.")
+		require.NotEmpty(t, exported, "No capabilities exported by PDS operators.")
+
+		deploymentTarget := c.controlPlane.MustGetDeploymentTarget(ctx, t)
+		require.NotNil(t, deploymentTarget.Capabilities, "No capabilities reported to control plane.")
+		mismatches, err := unreportedCapabilities(exported, deploymentTarget.Capabilities)
+		require.NoError(t, err)
+		require.Emptyf(t, mismatches, "Capabilities are not reported to control plane: %v.", mismatches)
+	})
+}
+
+// unreportedCapabilities returns the exported capabilities which are not reported with the same version.
+// Capabilities unknown to the API model are ignored.
+func unreportedCapabilities(exported map[string]string, reported *pds.ModelsDeploymentTargetCapabilities) ([]string, error) {
+	data, err := json.Marshal(reported)
+	if err != nil {
+		return nil, err
+	}
+	var reportedVersions map[string]string
+	if err := json.Unmarshal(data, &reportedVersions); err != nil {
+		return nil, err
+	}
+
+	known := capabilityNames()
+	var mismatches []string
+	for name, version := range exported {
+		if !known[name] {
+			continue
+		}
+		if reportedVersion := reportedVersions[name]; reportedVersion != version {
+			mismatches = append(mismatches, fmt.Sprintf("%s: exported %q, reported %q", name, version, reportedVersion))
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches, nil
+}
+
+// capabilityNames returns the JSON names of the capabilities in the API model.
+func capabilityNames() map[string]bool {
+	names := make(map[string]bool)
+	modelType := reflect.TypeOf(pds.ModelsDeploymentTargetCapabilities{})
+	for i := 0; i < modelType.NumField(); i++ {
+		name, _, _ := strings.Cut(modelType.Field(i).Tag.Get("json"), ",")
+		if name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
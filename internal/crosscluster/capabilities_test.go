@@ -0,0 +1,41 @@
+package crosscluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+
+	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
+)
+
+func TestUnreportedCapabilities(t *testing.T) {
+	reported := &pds.ModelsDeploymentTargetCapabilities{
+		Backup:         pointer.String("v2"),
+		DataServiceTls: pointer.String(""),
+		Postgresql:     pointer.String("v1"),
+	}
+	exported := map[string]string{
+		"backup":           "v2",
+		"data_service_tls": "v1",
+		"postgresql":       "v2",
+		"kafka":            "v2",
+		"future_feature":   "v1",
+	}
+
+	mismatches, err := unreportedCapabilities(exported, reported)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`data_service_tls: exported "v1", reported ""`,
+		`kafka: exported "v2", reported ""`,
+		`postgresql: exported "v2", reported "v1"`,
+	}, mismatches)
+
+	reported.DataServiceTls = pointer.String("v1")
+	reported.Postgresql = pointer.String("v2")
+	reported.Kafka = pointer.String("v2")
+	mismatches, err = unreportedCapabilities(exported, reported)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches)
+}
@@ -0,0 +1,92 @@
+package crosscluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+// cassandraRingTimeout bounds waiting for the ring to converge, e.g. for new nodes to join after scaling.
+const cassandraRingTimeout = 15 * time.Minute
+
+// MustWaitForCassandraRingHealthy waits until the ring of the Cassandra deployment is healthy as seen by every node,
+// see datastore.VerifyCassandraRing: all nodes completed bootstrapping, see each other as peers which are up and
+// normal in `nodetool status`, agree on the schema, own a fair share of the tokens and all non-system keyspaces can
+// be replicated.
+func (c *CrossClusterHelper) MustWaitForCassandraRingHealthy(ctx context.Context, t *testing.T, deploymentID string) {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	require.Equalf(t, dataservices.Cassandra, dataServiceType, "Deployment %s is not a Cassandra deployment.", deploymentID)
+	nodeCount := int(deployment.GetNodeCount())
+	user := c.MustGetLoadTestUser(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	start := time.Now()
+	var ownership map[string]float64
+	wait.For(t, cassandraRingTimeout, wait.RetryInterval, func(t tests.T) {
+		client, closeClient, err := newDataStoreClient(ctx, t, targetCluster, namespace.GetName(), deployment.GetClusterResourceName(), deploymentID, dataServiceType, user)
+		require.NoErrorf(t, err, "Connecting Cassandra client to deployment %s.", deploymentID)
+		defer closeClient()
+		cassandraClient, ok := client.(datastore.CassandraRingClient)
+		require.Truef(t, ok, "Client of deployment %s is not a Cassandra client.", deploymentID)
+
+		ring, err := cassandraClient.Ring(ctx)
+		require.NoErrorf(t, err, "Getting ring of deployment %s.", deploymentID)
+		ring.Gossip, err = getCassandraGossip(ctx, targetCluster, namespace.GetName(), deploymentID)
+		require.NoErrorf(t, err, "Getting gossip status of deployment %s.", deploymentID)
+		err = datastore.VerifyCassandraRing(ring, nodeCount)
+		require.NoErrorf(t, err, "Verifying Cassandra ring of deployment %s.", deploymentID)
+
+		owned, err := datastore.CassandraOwnership(ring.Nodes)
+		require.NoError(t, err)
+		ownership = make(map[string]float64, len(ring.Nodes))
+		for _, node := range ring.Nodes {
+			ownership[node.Address] = owned[node.HostID]
+		}
+	})
+
+	var shares []string
+	for address, owned := range ownership {
+		shares = append(shares, fmt.Sprintf("%s=%.1f%%", address, owned*100))
+	}
+	sort.Strings(shares)
+	t.Logf("Cassandra ring of deployment %s is healthy after %s, token ownership: %s.",
+		deploymentID, time.Since(start).Round(time.Second), strings.Join(shares, ", "))
+}
+
+// getCassandraGossip runs `nodetool status` in every pod of the deployment and returns the gossip status of the nodes
+// as seen by each pod.
+func getCassandraGossip(ctx context.Context, targetCluster *targetcluster.TargetCluster, namespace, deploymentID string) (map[string]map[string]string, error) {
+	container, err := getDatabaseContainerName(dataservices.Cassandra)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := targetCluster.ListPods(ctx, namespace, map[string]string{pdsDeploymentIDLabel: deploymentID})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods of deployment %s: %w", deploymentID, err)
+	}
+
+	gossip := make(map[string]map[string]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		stdout, stderr, err := targetCluster.ExecInPod(ctx, namespace, pod.Name, container, []string{"nodetool", "status"})
+		if err != nil {
+			return nil, fmt.Errorf("running nodetool status in pod %s: %w: %s", pod.Name, err, stderr)
+		}
+		statuses, err := datastore.ParseCassandraNodetoolStatus(stdout)
+		if err != nil {
+			return nil, fmt.Errorf("pod %s: %w", pod.Name, err)
+		}
+		gossip[pod.Name] = statuses
+	}
+	return gossip, nil
+}
@@ -3,6 +3,8 @@ package crosscluster
 import (
 	"time"
 
+	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
+
 	"github.com/portworx/pds-integration-test/internal/controlplane"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
 )
@@ -13,10 +15,18 @@ import (
 //
 // It deliberately lacks access to a testing.T instance, forcing callers to supply their own (avoiding parallel subtest issues).
 type CrossClusterHelper struct {
-	controlPlane  *controlplane.ControlPlane
-	targetCluster *targetcluster.TargetCluster
+	controlPlane   *controlplane.ControlPlane
+	targetCluster  *targetcluster.TargetCluster
+	targetClusters TargetClusterResolver
 
 	startTime time.Time
+	// artifactsDir is the directory for diagnostics collected by the helpers, e.g. logs of failed backup targets.
+	artifactsDir string
+}
+
+// TargetClusterResolver resolves the target cluster registered in the control plane as the deployment target.
+type TargetClusterResolver interface {
+	TargetClusterForDeploymentTarget(deploymentTargetID string) (*targetcluster.TargetCluster, bool)
 }
 
 func NewHelper(controlPlane *controlplane.ControlPlane, targetCluster *targetcluster.TargetCluster, startTime time.Time) *CrossClusterHelper {
@@ -26,3 +36,41 @@ func NewHelper(controlPlane *controlplane.ControlPlane, targetCluster *targetclu
 		startTime:     startTime,
 	}
 }
+
+// WithTargetClusters returns a copy of the helper which checks each deployment in the target cluster
+// of its deployment target. Deployments on unknown deployment targets are checked in the default target cluster.
+func (c *CrossClusterHelper) WithTargetClusters(targetClusters TargetClusterResolver) *CrossClusterHelper {
+	helper := *c
+	helper.targetClusters = targetClusters
+	return &helper
+}
+
+// WithArtifactsDir returns a copy of the helper which writes diagnostics, e.g. operator logs of failed backup targets,
+// to the directory instead of only logging a summary.
+func (c *CrossClusterHelper) WithArtifactsDir(artifactsDir string) *CrossClusterHelper {
+	helper := *c
+	helper.artifactsDir = artifactsDir
+	return &helper
+}
+
+// ForDeploymentTarget returns a copy of the helper bound to the target cluster of the deployment target.
+// It is useful for helpers which work with target cluster resources directly, e.g. restores or volumes.
+func (c *CrossClusterHelper) ForDeploymentTarget(deploymentTargetID string) *CrossClusterHelper {
+	helper := *c
+	helper.targetCluster = c.targetClusterFor(deploymentTargetID)
+	return &helper
+}
+
+func (c *CrossClusterHelper) forDeployment(deployment *pds.ModelsDeployment) *CrossClusterHelper {
+	return c.ForDeploymentTarget(deployment.GetDeploymentTargetId())
+}
+
+func (c *CrossClusterHelper) targetClusterFor(deploymentTargetID string) *targetcluster.TargetCluster {
+	if c.targetClusters == nil || deploymentTargetID == "" {
+		return c.targetCluster
+	}
+	if tc, ok := c.targetClusters.TargetClusterForDeploymentTarget(deploymentTargetID); ok {
+		return tc
+	}
+	return c.targetCluster
+}
@@ -14,6 +14,7 @@ import (
 func (c *CrossClusterHelper) MustDeleteDeploymentCustomResource(ctx context.Context, t tests.T, deploymentId string, database string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentId).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
@@ -21,11 +22,11 @@ func (c *CrossClusterHelper) MustDeleteDeploymentCustomResource(ctx context.Cont
 
 	customResourceName := *deployment.ClusterResourceName
 
-	err = c.targetCluster.DeletePDSDeployment(ctx, namespace, database, customResourceName)
+	err = targetCluster.DeletePDSDeployment(ctx, namespace, database, customResourceName)
 	require.NoError(t, err)
 
 	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
-		_, err := c.targetCluster.GetPDSDeployment(ctx, namespace, database, customResourceName)
+		_, err := targetCluster.GetPDSDeployment(ctx, namespace, database, customResourceName)
 		expectedError := fmt.Sprintf("%s.deployments.pds.io %q not found", database, customResourceName)
 		require.EqualError(t, err, expectedError, "deployment CR is not deleted.")
 	})
@@ -0,0 +1,116 @@
+package crosscluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/portforward"
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+// dataStoreVerifyTimeout bounds retries of reading datasets, e.g. while a data service elects a new leader.
+const dataStoreVerifyTimeout = 3 * time.Minute
+
+// MustNewDataStoreClient connects a native client to the deployment through port-forwards to all its pods.
+// The returned function closes the client and the port-forwards.
+func (c *CrossClusterHelper) MustNewDataStoreClient(ctx context.Context, t *testing.T, deploymentID string) (datastore.Client, func()) {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	user := c.MustGetLoadTestUser(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	client, closeClient, err := newDataStoreClient(ctx, t, targetCluster, namespace.GetName(), deployment.GetClusterResourceName(), deploymentID, dataServiceType, user)
+	require.NoErrorf(t, err, "Connecting %s client to deployment %s.", dataServiceType, deploymentID)
+	return client, closeClient
+}
+
+// MustWriteDataset writes the records of the dataset to the deployment with a native client.
+func (c *CrossClusterHelper) MustWriteDataset(ctx context.Context, t *testing.T, deploymentID string, dataset datastore.Dataset) {
+	client, closeClient := c.MustNewDataStoreClient(ctx, t, deploymentID)
+	defer closeClient()
+
+	err := client.Write(ctx, dataset)
+	require.NoErrorf(t, err, "Writing dataset %s to deployment %s.", dataset, deploymentID)
+	t.Logf("Wrote dataset %s with digest %s to deployment %s.", dataset, dataset.Digest(), deploymentID)
+}
+
+// MustVerifyDataset reads the records of the dataset from the deployment with a native client and checks that
+// the digest of the stored records matches the digest of the dataset. Missing, corrupted and unexpected keys are
+// reported. The deployment is resolved by its ID, so restored deployments can be verified regardless of their name
+// and namespace. Reads are retried with new connections until dataStoreVerifyTimeout.
+func (c *CrossClusterHelper) MustVerifyDataset(ctx context.Context, t *testing.T, deploymentID string, dataset datastore.Dataset) {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	user := c.MustGetLoadTestUser(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	wait.For(t, dataStoreVerifyTimeout, wait.RetryInterval, func(t tests.T) {
+		client, closeClient, err := newDataStoreClient(ctx, t, targetCluster, namespace.GetName(), deployment.GetClusterResourceName(), deploymentID, dataServiceType, user)
+		require.NoErrorf(t, err, "Connecting %s client to deployment %s.", dataServiceType, deploymentID)
+		defer closeClient()
+
+		err = client.Verify(ctx, dataset)
+		require.NoErrorf(t, err, "Verifying dataset %s of deployment %s/%s (%s).", dataset, namespace.GetName(), deployment.GetClusterResourceName(), deploymentID)
+	})
+	t.Logf("Verified dataset %s with digest %s on deployment %s/%s.", dataset, dataset.Digest(), namespace.GetName(), deployment.GetClusterResourceName())
+}
+
+func newDataStoreClient(ctx context.Context, t tests.T, targetCluster *targetcluster.TargetCluster, namespace, clusterResourceName, deploymentID, dataServiceType, user string) (datastore.Client, func(), error) {
+	if _, ok := datastore.Ports(dataServiceType); !ok {
+		return nil, nil, fmt.Errorf("no native client for data service %s", dataServiceType)
+	}
+	creds := mustGetDeploymentCredentials(ctx, t, targetCluster, namespace, clusterResourceName)
+	return connectDataStore(ctx, targetCluster, namespace, deploymentID, dataServiceType, user, creds.Password)
+}
+
+// connectDataStore connects a native client through port-forwards to the running pods of the deployment.
+func connectDataStore(ctx context.Context, targetCluster *targetcluster.TargetCluster, namespace, deploymentID, dataServiceType, user, password string) (datastore.Client, func(), error) {
+	ports, ok := datastore.Ports(dataServiceType)
+	if !ok {
+		return nil, nil, fmt.Errorf("no native client for data service %s", dataServiceType)
+	}
+	pods, err := targetCluster.ListPods(ctx, namespace, map[string]string{pdsDeploymentIDLabel: deploymentID})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing pods of deployment %s: %w", deploymentID, err)
+	}
+
+	var tunnels []*portforward.Tunnel
+	closeTunnels := func() {
+		for _, tunnel := range tunnels {
+			tunnel.Close()
+		}
+	}
+	config := datastore.Config{Username: user, Password: password}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		endpoint := datastore.Endpoint{Pod: pod.Name, IP: pod.Status.PodIP, Ports: make(map[int]int, len(ports))}
+		for _, port := range ports {
+			tunnel, err := targetCluster.PortforwardPod(namespace, pod.Name, port)
+			if err != nil {
+				closeTunnels()
+				return nil, nil, fmt.Errorf("port-forwarding to pod %s port %d: %w", pod.Name, port, err)
+			}
+			tunnels = append(tunnels, tunnel)
+			endpoint.Ports[port] = tunnel.Local
+		}
+		config.Endpoints = append(config.Endpoints, endpoint)
+	}
+
+	client, err := datastore.New(ctx, dataServiceType, config)
+	if err != nil {
+		closeTunnels()
+		return nil, nil, err
+	}
+	return client, func() {
+		_ = client.Close()
+		closeTunnels()
+	}, nil
+}
@@ -15,6 +15,7 @@ import (
 func (c *CrossClusterHelper) MustWaitForDeploymentInitialized(ctx context.Context, t tests.T, deploymentID string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
@@ -24,19 +25,20 @@ func (c *CrossClusterHelper) MustWaitForDeploymentInitialized(ctx context.Contex
 	nodeInitJobName := fmt.Sprintf("%s-node-init", deployment.GetClusterResourceName())
 
 	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
-		clusterInitJob, err := c.targetCluster.GetJob(ctx, namespace, clusterInitJobName)
+		clusterInitJob, err := targetCluster.GetJob(ctx, namespace, clusterInitJobName)
 		require.NoErrorf(t, err, "Getting clusterInitJob %s/%s for deployment %s.", namespace, clusterInitJobName, deploymentID)
 		require.Truef(t, isJobSucceeded(clusterInitJob), "ClusterInitJob %s/%s for deployment %s not successful.", namespace, clusterInitJobName, deploymentID)
 
-		nodeInitJob, err := c.targetCluster.GetJob(ctx, namespace, nodeInitJobName)
+		nodeInitJob, err := targetCluster.GetJob(ctx, namespace, nodeInitJobName)
 		require.NoErrorf(t, err, "Getting nodeInitJob %s/%s for deployment %s.", namespace, nodeInitJobName, deploymentID)
-		require.Truef(t, isJobSucceeded(clusterInitJob), "NodeInitJob %s/%s for deployment %s not successful.", namespace, nodeInitJob, deploymentID)
+		require.Truef(t, isJobSucceeded(nodeInitJob), "NodeInitJob %s/%s for deployment %s not successful.", namespace, nodeInitJobName, deploymentID)
 	})
 }
 
 func (c *CrossClusterHelper) GetNodeInitJob(ctx context.Context, t tests.T, deploymentID string) (bool, error) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
@@ -44,7 +46,7 @@ func (c *CrossClusterHelper) GetNodeInitJob(ctx context.Context, t tests.T, depl
 	namespace := namespaceModel.GetName()
 	nodeInitJobName := fmt.Sprintf("%s-node-init", deployment.GetClusterResourceName())
 
-	nodeInitJob, err := c.targetCluster.GetJob(ctx, namespace, nodeInitJobName)
+	nodeInitJob, err := targetCluster.GetJob(ctx, namespace, nodeInitJobName)
 
 	return isJobSucceeded(nodeInitJob), err
 }
@@ -52,6 +54,7 @@ func (c *CrossClusterHelper) GetNodeInitJob(ctx context.Context, t tests.T, depl
 func (c *CrossClusterHelper) GetClusterInitJob(ctx context.Context, t tests.T, deploymentID string) (bool, error) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
@@ -59,11 +62,31 @@ func (c *CrossClusterHelper) GetClusterInitJob(ctx context.Context, t tests.T, d
 	namespace := namespaceModel.GetName()
 	clusterInitJobName := fmt.Sprintf("%s-cluster-init", deployment.GetClusterResourceName())
 
-	clusterInitJob, err := c.targetCluster.GetJob(ctx, namespace, clusterInitJobName)
+	clusterInitJob, err := targetCluster.GetJob(ctx, namespace, clusterInitJobName)
 
 	return isJobSucceeded(clusterInitJob), err
 }
 
 func isJobSucceeded(job *batchv1.Job) bool {
-	return *job.Spec.Completions == job.Status.Succeeded
+	if job == nil {
+		return false
+	}
+	// A job without completions set is complete as soon as one pod succeeds.
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	return job.Status.Succeeded >= completions
+}
+
+// MustPassDeploymentPodSecurityAudit checks that pods and pod templates of the deployment, including its init jobs,
+// comply with the pod security level. Backup and restore jobs are audited by MustPassBackupPodSecurityAudit and
+// MustPassRestorePodSecurityAudit.
+func (c *CrossClusterHelper) MustPassDeploymentPodSecurityAudit(ctx context.Context, t tests.T, deploymentID, level string) {
+	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
+	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	namespace := c.controlPlane.MustGetNamespaceForDeployment(ctx, t, deploymentID)
+	targetCluster.MustPassPodSecurityAudit(ctx, t, namespace, level, map[string]string{pdsDeploymentIDLabel: deploymentID})
 }
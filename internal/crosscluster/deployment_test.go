@@ -0,0 +1,33 @@
+package crosscluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/fixtures"
+)
+
+func TestIsJobSucceeded(t *testing.T) {
+	testCases := []struct {
+		name     string
+		job      *batchv1.Job
+		expected bool
+	}{
+		{name: "nil job", job: nil, expected: false},
+		{name: "not started", job: fixtures.Job("ns", "job", pointer.Int32(1), 0), expected: false},
+		{name: "succeeded", job: fixtures.Job("ns", "job", pointer.Int32(1), 1), expected: true},
+		{name: "partially succeeded", job: fixtures.Job("ns", "job", pointer.Int32(3), 2), expected: false},
+		{name: "all completions succeeded", job: fixtures.Job("ns", "job", pointer.Int32(3), 3), expected: true},
+		{name: "nil completions not started", job: fixtures.Job("ns", "job", nil, 0), expected: false},
+		{name: "nil completions succeeded", job: fixtures.Job("ns", "job", nil, 1), expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isJobSucceeded(tc.job))
+		})
+	}
+}
@@ -21,6 +21,7 @@ func (c *CrossClusterHelper) MustHaveDeploymentsMatching(ctx context.Context, t
 
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
@@ -28,7 +29,7 @@ func (c *CrossClusterHelper) MustHaveDeploymentsMatching(ctx context.Context, t
 
 	customResourceName := *deployment.ClusterResourceName
 
-	db, err := c.targetCluster.GetPDSDatabase(ctx, namespace, customResourceName)
+	db, err := targetCluster.GetPDSDatabase(ctx, namespace, customResourceName)
 	require.NoErrorf(t, err, "Getting database %s from target cluster failed", customResourceName)
 
 	for _, e := range db.Status.ResourceEvents {
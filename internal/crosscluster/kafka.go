@@ -0,0 +1,171 @@
+package crosscluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+// kafkaRecoveryTimeout bounds waiting for in-sync replicas and consumers, e.g. after broker restarts or scaling.
+const kafkaRecoveryTimeout = 10 * time.Minute
+
+// kafkaDeployment is a Kafka deployment resolved by its ID.
+type kafkaDeployment struct {
+	id                  string
+	namespace           string
+	clusterResourceName string
+	nodeCount           int32
+	user                string
+	targetCluster       *targetcluster.TargetCluster
+}
+
+func (c *CrossClusterHelper) mustGetKafkaDeployment(ctx context.Context, t *testing.T, deploymentID string) kafkaDeployment {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	require.Equalf(t, dataservices.Kafka, dataServiceType, "Deployment %s is not a Kafka deployment.", deploymentID)
+	return kafkaDeployment{
+		id:                  deploymentID,
+		namespace:           namespace.GetName(),
+		clusterResourceName: deployment.GetClusterResourceName(),
+		nodeCount:           deployment.GetNodeCount(),
+		user:                c.MustGetLoadTestUser(ctx, t, deploymentID),
+		targetCluster:       c.targetClusterFor(deployment.GetDeploymentTargetId()),
+	}
+}
+
+// withClient connects a Kafka client to the running brokers, calls fn and closes the client.
+func (d kafkaDeployment) withClient(ctx context.Context, t tests.T, fn func(client datastore.KafkaClient)) {
+	client, closeClient, err := newDataStoreClient(ctx, t, d.targetCluster, d.namespace, d.clusterResourceName, d.id, dataservices.Kafka, d.user)
+	require.NoErrorf(t, err, "Connecting Kafka client to deployment %s.", d.id)
+	defer closeClient()
+	kafkaClient, ok := client.(datastore.KafkaClient)
+	require.Truef(t, ok, "Client of deployment %s is not a Kafka client.", d.id)
+	fn(kafkaClient)
+}
+
+// MustCreateKafkaTopic creates the topic with a replica on every broker of the deployment.
+func (c *CrossClusterHelper) MustCreateKafkaTopic(ctx context.Context, t *testing.T, deploymentID, topic string, partitions int32) {
+	d := c.mustGetKafkaDeployment(ctx, t, deploymentID)
+	d.withClient(ctx, t, func(client datastore.KafkaClient) {
+		err := client.CreateTopic(ctx, topic, partitions, int16(d.nodeCount))
+		require.NoErrorf(t, err, "Creating topic %s with %d partitions and replication factor %d.", topic, partitions, d.nodeCount)
+	})
+	t.Logf("Created topic %s with %d partitions and replication factor %d on deployment %s.", topic, partitions, d.nodeCount, deploymentID)
+}
+
+// MustProduceKafkaMessages produces the messages to the topic and waits until all in-sync replicas acknowledged them.
+func (c *CrossClusterHelper) MustProduceKafkaMessages(ctx context.Context, t *testing.T, deploymentID, topic string, messages []datastore.KafkaMessage) {
+	d := c.mustGetKafkaDeployment(ctx, t, deploymentID)
+	d.withClient(ctx, t, func(client datastore.KafkaClient) {
+		err := client.Produce(ctx, topic, messages)
+		require.NoErrorf(t, err, "Producing %d messages to topic %s.", len(messages), topic)
+	})
+}
+
+// MustWaitForKafkaInSyncReplicas waits until every partition of the topic has a leader and all its replicas are
+// in sync.
+func (c *CrossClusterHelper) MustWaitForKafkaInSyncReplicas(ctx context.Context, t *testing.T, deploymentID, topic string) {
+	d := c.mustGetKafkaDeployment(ctx, t, deploymentID)
+	start := time.Now()
+	wait.For(t, kafkaRecoveryTimeout, wait.RetryInterval, func(t tests.T) {
+		d.withClient(ctx, t, func(client datastore.KafkaClient) {
+			partitions, err := client.Partitions(ctx, topic)
+			require.NoErrorf(t, err, "Getting partitions of topic %s.", topic)
+			require.NotEmptyf(t, partitions, "Topic %s has no partitions.", topic)
+			for _, partition := range partitions {
+				require.Falsef(t, partition.UnderReplicated(),
+					"Partition %s/%d is under-replicated (leader %d, replicas %v, in-sync %v).",
+					topic, partition.ID, partition.Leader, partition.Replicas, partition.InSyncReplicas)
+			}
+		})
+	})
+	t.Logf("All replicas of topic %s are in sync after %s.", topic, time.Since(start).Round(time.Second))
+}
+
+// MustVerifyKafkaMessages consumes all partitions of the topic from the first offset and checks that every message
+// was consumed exactly once, see datastore.VerifyKafkaMessages. Consumers are retried with new connections,
+// e.g. while partition leaders move.
+func (c *CrossClusterHelper) MustVerifyKafkaMessages(ctx context.Context, t *testing.T, deploymentID, topic string, messages []datastore.KafkaMessage) {
+	d := c.mustGetKafkaDeployment(ctx, t, deploymentID)
+	wait.For(t, kafkaRecoveryTimeout, wait.RetryInterval, func(t tests.T) {
+		d.withClient(ctx, t, func(client datastore.KafkaClient) {
+			partitions, err := client.Partitions(ctx, topic)
+			require.NoErrorf(t, err, "Getting partitions of topic %s.", topic)
+
+			var consumed []datastore.KafkaMessage
+			for _, partition := range partitions {
+				partitionMessages, err := client.Consume(ctx, topic, partition.ID)
+				require.NoErrorf(t, err, "Consuming partition %s/%d.", topic, partition.ID)
+				consumed = append(consumed, partitionMessages...)
+			}
+			err = datastore.VerifyKafkaMessages(messages, consumed)
+			require.NoErrorf(t, err, "Verifying messages of topic %s.", topic)
+		})
+	})
+	t.Logf("Consumed all %d messages of topic %s exactly once.", len(messages), topic)
+}
+
+// MustVerifyKafkaAdvertisedListeners checks that every broker of the deployment is announced and that the external
+// hosts of the advertised listeners are DNS endpoints of the deployment which resolve in the target cluster.
+// Listeners with pod IPs or cluster-internal hosts are resolved by the cluster DNS and are not checked.
+func (c *CrossClusterHelper) MustVerifyKafkaAdvertisedListeners(ctx context.Context, t *testing.T, deploymentID string) {
+	d := c.mustGetKafkaDeployment(ctx, t, deploymentID)
+
+	var externalHosts []string
+	d.withClient(ctx, t, func(client datastore.KafkaClient) {
+		brokers, err := client.Brokers(ctx)
+		require.NoError(t, err, "Getting Kafka brokers.")
+		require.Lenf(t, brokers, int(d.nodeCount), "Brokers announced by deployment %s: %v.", deploymentID, brokers)
+
+		for _, broker := range brokers {
+			listeners, err := client.AdvertisedListeners(ctx, broker.NodeID)
+			require.NoErrorf(t, err, "Getting advertised listeners of broker %d.", broker.NodeID)
+			require.NotEmptyf(t, listeners, "Broker %d has no advertised listeners.", broker.NodeID)
+			for _, listener := range listeners {
+				if !isClusterInternalHost(listener.Host) {
+					externalHosts = append(externalHosts, listener.Host)
+				}
+			}
+		}
+	})
+	if len(externalHosts) == 0 {
+		t.Logf("Brokers of deployment %s advertise cluster-internal listeners only.", deploymentID)
+		return
+	}
+
+	dnsEndpoints, err := d.targetCluster.GetDNSEndpoints(ctx, d.namespace, d.clusterResourceName, "CNAME")
+	require.NoErrorf(t, err, "Getting DNS endpoints of deployment %s.", deploymentID)
+	for _, host := range externalHosts {
+		require.Containsf(t, dnsEndpoints, host, "Advertised host %s is not a DNS endpoint of deployment %s.", host, deploymentID)
+	}
+
+	d.targetCluster.MustWaitForHostsResolvable(ctx, t, d.namespace, d.clusterResourceName, externalHosts)
+	t.Logf("Advertised hosts %s of deployment %s resolve through its DNS endpoints.", strings.Join(externalHosts, ", "), deploymentID)
+}
+
+// isClusterInternalHost returns true for IP addresses and host names resolved by the cluster DNS.
+func isClusterInternalHost(host string) bool {
+	if net.ParseIP(host) != nil {
+		return true
+	}
+	host = strings.TrimSuffix(host, ".")
+	return !strings.Contains(host, ".") ||
+		strings.HasSuffix(host, ".svc") ||
+		strings.Contains(host, ".svc.") ||
+		strings.HasSuffix(host, ".local")
+}
+
+// KafkaTopicName returns a topic name for the deployment, unique per purpose.
+func KafkaTopicName(purpose, deploymentID string) string {
+	return fmt.Sprintf("pds-integration-%s-%s", purpose, strings.ReplaceAll(deploymentID, "-", "")[:8])
+}
@@ -2,7 +2,6 @@ package crosscluster
 
 import (
 	"context"
-	"time"
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -16,13 +15,14 @@ import (
 func (c *CrossClusterHelper) MustWaitForLoadBalancerServicesReady(ctx context.Context, t tests.T, deploymentID string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
 
 	namespace := namespaceModel.GetName()
 	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
-		svcs, err := c.targetCluster.ListServices(ctx, namespace, map[string]string{
+		svcs, err := targetCluster.ListServices(ctx, namespace, map[string]string{
 			"name": deployment.GetClusterResourceName(),
 		})
 		require.NoErrorf(t, err, "Listing services for deployment %s.", deployment.GetClusterResourceName())
@@ -41,6 +41,7 @@ func (c *CrossClusterHelper) MustWaitForLoadBalancerServicesReady(ctx context.Co
 func (c *CrossClusterHelper) MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx context.Context, t tests.T, deploymentID string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	dataService, resp, err := c.controlPlane.PDS.DataServicesApi.ApiDataServicesIdGet(ctx, deployment.GetDataServiceId()).Execute()
 	api.RequireNoError(t, resp, err)
@@ -56,17 +57,12 @@ func (c *CrossClusterHelper) MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx
 	namespace := namespaceModel.GetName()
 
 	// Collect all CNAME hostnames from DNSEndpoints.
-	hostnames, err := c.targetCluster.GetDNSEndpoints(ctx, namespace, deployment.GetClusterResourceName(), "CNAME")
+	hostnames, err := targetCluster.GetDNSEndpoints(ctx, namespace, deployment.GetClusterResourceName(), "CNAME")
 	require.NoError(t, err)
 
 	// Wait until all hosts are accessible (DNS server returns an IP address for all hosts).
 	if len(hostnames) > 0 {
-		wait.For(t, wait.LongTimeout, wait.RetryInterval, func(t tests.T) {
-			dnsIPs := c.targetCluster.MustFlushDNSCache(ctx, t)
-			jobNameSuffix := time.Now().Format("0405") // mmss
-			jobName := c.targetCluster.MustRunHostCheckJob(ctx, t, namespace, deployment.GetClusterResourceName(), jobNameSuffix, hostnames, dnsIPs)
-			c.targetCluster.MustWaitForJobSuccess(ctx, t, namespace, jobName)
-		})
+		targetCluster.MustWaitForHostsResolvable(ctx, t, namespace, deployment.GetClusterResourceName(), hostnames)
 	}
 }
 
@@ -3,6 +3,7 @@ package crosscluster
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -15,6 +16,8 @@ import (
 
 	"github.com/portworx/pds-integration-test/internal/api"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/loadtest"
+	"github.com/portworx/pds-integration-test/internal/wait"
 )
 
 const (
@@ -23,6 +26,9 @@ const (
 	LoadTestCRUD       = "crud"
 	LoadTestDeleteUser = "delete_user"
 
+	// LoadTestMixed reads and writes records in the ratio of LoadTestConfig.ReadRatio.
+	LoadTestMixed = "mixed"
+
 	PDSUser        = "pds"
 	PDSReplaceUser = "pds_replace_user"
 
@@ -44,6 +50,95 @@ var loadTestImages = map[string]string{
 	dataservices.SqlServer:     DefaultLoadTestImage,
 }
 
+// LoadTestConfig configures the runs of the load-test container. Every run is a pod of the load-test job, the
+// results of a load test are the summaries of the operations logged by the runs, see loadtest.Summarize.
+type LoadTestConfig struct {
+	// Mode is one of the LoadTest* modes, the container default is used if empty.
+	Mode string
+	// Seed of the records, records written with a seed can be read with the same seed.
+	Seed string
+	// User to authenticate as, defaults to PDSUser.
+	User string
+	// Iterations of the workload in each run, defaults to 1. Ignored if Duration is set.
+	Iterations int
+	// Duration runs the workload for the duration instead of a number of iterations, rounded to seconds.
+	Duration time.Duration
+	// RecordSize is the size of the written records in bytes, the container default is used if zero.
+	RecordSize int
+	// ReadRatio is the fraction of reads of the operations of the LoadTestMixed mode, e.g. 0.9 for a read-heavy
+	// workload. The container default is used if zero.
+	ReadRatio float64
+	// Runs is the number of successful runs of the load test, defaults to 1.
+	Runs int
+	// Concurrency is the number of runs at a time, defaults to 1 and is at most the number of runs.
+	Concurrency int
+	// AllowErrors lets the runs continue after failed operations, so the error rate can be asserted on the results.
+	AllowErrors bool
+	// ExtraEnv is added to the env of the container and overrides the env set from the config.
+	ExtraEnv map[string]string
+}
+
+func (c LoadTestConfig) env() map[string]string {
+	env := map[string]string{
+		"FAIL_ON_ERROR": strconv.FormatBool(!c.AllowErrors),
+		"PDS_USER":      c.User,
+		// Log the summary of the operations, see loadtest.ParseSummary.
+		"SUMMARY": "json",
+	}
+	if c.Duration > 0 {
+		env["DURATION"] = strconv.Itoa(int(c.Duration.Round(time.Second).Seconds()))
+	} else {
+		env["ITERATIONS"] = strconv.Itoa(atLeastOne(c.Iterations))
+	}
+	if c.RecordSize > 0 {
+		env["RECORD_SIZE"] = strconv.Itoa(c.RecordSize)
+	}
+	if c.ReadRatio > 0 {
+		env["READ_RATIO"] = strconv.FormatFloat(c.ReadRatio, 'f', -1, 64)
+	}
+	if c.Mode != "" {
+		env["MODE"] = c.Mode
+	}
+	if c.Seed != "" {
+		env["SEED"] = strings.ReplaceAll(c.Seed, "-", "")
+	}
+	if c.User == "" {
+		env["PDS_USER"] = PDSUser
+	}
+	for name, value := range c.ExtraEnv {
+		env[name] = value
+	}
+	return env
+}
+
+// runs returns the completions and parallelism of the load-test job.
+func (c LoadTestConfig) runs() (completions, parallelism int32) {
+	completions = int32(atLeastOne(c.Runs))
+	parallelism = int32(atLeastOne(c.Concurrency))
+	if parallelism > completions {
+		parallelism = completions
+	}
+	return completions, parallelism
+}
+
+// timeout of the load test, the duration of a run plus the standard timeout, or the standard timeout for every
+// iteration, for the runs done one after another. Retries of failed runs are not accounted for.
+func (c LoadTestConfig) timeout() time.Duration {
+	completions, parallelism := c.runs()
+	waves := time.Duration((completions + parallelism - 1) / parallelism)
+	if c.Duration > 0 {
+		return waves * (c.Duration + wait.StandardTimeout)
+	}
+	return waves * time.Duration(atLeastOne(c.Iterations)) * wait.StandardTimeout
+}
+
+func atLeastOne(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
 func (c *CrossClusterHelper) MustGetDeploymentInfo(ctx context.Context, t *testing.T, deploymentID string) (*pds.ModelsDeployment, *pds.ModelsNamespace, string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
@@ -78,23 +173,23 @@ func (c *CrossClusterHelper) MustGetLoadTestUser(ctx context.Context, t *testing
 
 func (c *CrossClusterHelper) MustRunLoadTestJobWithUser(ctx context.Context, t *testing.T, deploymentID, user string) {
 	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
-	c.MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestCRUD, "", user, *deployment.NodeCount, nil)
+	c.forDeployment(deployment).MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestCRUD, "", user, *deployment.NodeCount, nil)
 }
 
 func (c *CrossClusterHelper) MustRunLoadTestJob(ctx context.Context, t *testing.T, deploymentID string) {
 	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
 	user := c.MustGetLoadTestUser(ctx, t, deploymentID)
-	c.MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestCRUD, "", user, *deployment.NodeCount, nil)
+	c.forDeployment(deployment).MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestCRUD, "", user, *deployment.NodeCount, nil)
 }
 
 func (c *CrossClusterHelper) MustRunReadLoadTestJob(ctx context.Context, t *testing.T, deploymentID, seed string) {
 	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
-	c.MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestRead, seed, PDSUser, *deployment.NodeCount, nil)
+	c.forDeployment(deployment).MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestRead, seed, PDSUser, *deployment.NodeCount, nil)
 }
 
 func (c *CrossClusterHelper) MustRunWriteLoadTestJob(ctx context.Context, t *testing.T, deploymentID, seed string) {
 	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
-	c.MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestWrite, seed, PDSUser, *deployment.NodeCount, nil)
+	c.forDeployment(deployment).MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestWrite, seed, PDSUser, *deployment.NodeCount, nil)
 }
 
 func (c *CrossClusterHelper) MustRunCRUDLoadTestJob(ctx context.Context, t *testing.T, deploymentID, user, replaceToken string) {
@@ -105,15 +200,16 @@ func (c *CrossClusterHelper) MustRunCRUDLoadTestJob(ctx context.Context, t *test
 			"PASSWORD": replaceToken,
 		}
 	}
-	c.MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestCRUD, "", user, *deployment.NodeCount, extraEnv)
+	c.forDeployment(deployment).MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestCRUD, "", user, *deployment.NodeCount, extraEnv)
 }
 
 func (c *CrossClusterHelper) MustRunCRUDLoadTestJobAndFail(ctx context.Context, t *testing.T, deploymentID, user string) {
 	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
 	ttlSecondsAfterFinished := pointer.Int32(30)
 	backOffLimit := pointer.Int32(0)
-	job := c.MustCreateLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestCRUD, "", user, *deployment.NodeCount, nil, ttlSecondsAfterFinished, backOffLimit)
-	c.targetCluster.MustWaitForJobFailure(ctx, t, job.Namespace, job.Name)
+	helper := c.forDeployment(deployment)
+	job := helper.MustCreateLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestCRUD, "", user, *deployment.NodeCount, nil, ttlSecondsAfterFinished, backOffLimit)
+	helper.targetCluster.MustWaitForJobFailure(ctx, t, job.Namespace, job.Name)
 }
 
 func (c *CrossClusterHelper) MustRunDeleteUserJob(ctx context.Context, t *testing.T, deploymentID, user, replacePassword string) {
@@ -124,30 +220,63 @@ func (c *CrossClusterHelper) MustRunDeleteUserJob(ctx context.Context, t *testin
 		extraEnv["REPLACE_PASSWORD"] = replacePassword
 	}
 	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
-	c.MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestDeleteUser, "", user, *deployment.NodeCount, extraEnv)
+	c.forDeployment(deployment).MustRunGenericLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), LoadTestDeleteUser, "", user, *deployment.NodeCount, extraEnv)
 }
 
 func (c *CrossClusterHelper) MustRunGenericLoadTestJob(ctx context.Context, t *testing.T, dataServiceType, namespace, deploymentName, mode, seed, user string, nodeCount int32, extraEnv map[string]string) {
+	config := LoadTestConfig{Mode: mode, Seed: seed, User: user, ExtraEnv: extraEnv}
 	ttlSecondsAfterFinished := pointer.Int32(30)
 	backOffLimit := pointer.Int32(6)
-	job := c.MustCreateLoadTestJob(ctx, t, dataServiceType, namespace, deploymentName, mode, seed, user, nodeCount, extraEnv, ttlSecondsAfterFinished, backOffLimit)
+	job := c.mustCreateLoadTestJob(ctx, t, dataServiceType, namespace, deploymentName, nodeCount, config, ttlSecondsAfterFinished, backOffLimit)
 	c.targetCluster.MustWaitForJobSuccess(ctx, t, job.Namespace, job.Name)
 }
 
 func (c *CrossClusterHelper) MustCreateLoadTestJob(ctx context.Context, t *testing.T, dataServiceType, namespace, deploymentName, mode, seed, user string, nodeCount int32, extraEnv map[string]string, ttlSecondsAfterFinished *int32, backOffLimit *int32) *batchv1.Job {
+	config := LoadTestConfig{Mode: mode, Seed: seed, User: user, ExtraEnv: extraEnv}
+	return c.mustCreateLoadTestJob(ctx, t, dataServiceType, namespace, deploymentName, nodeCount, config, ttlSecondsAfterFinished, backOffLimit)
+}
+
+// MustRunLoadTest runs the load-test container with the given config against the deployment and returns the results
+// of the operations of all runs. The user of the deployment is used if the config has none.
+func (c *CrossClusterHelper) MustRunLoadTest(ctx context.Context, t *testing.T, deploymentID string, config LoadTestConfig) loadtest.Results {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	if config.User == "" {
+		config.User = c.MustGetLoadTestUser(ctx, t, deploymentID)
+	}
+	if config.Mode == "" {
+		config.Mode = LoadTestCRUD
+	}
+
+	helper := c.forDeployment(deployment)
+	// Keep the pods after the job finished, so the summaries can be read from their logs.
+	ttlSecondsAfterFinished := pointer.Int32(300)
+	backOffLimit := pointer.Int32(6)
+	job := helper.mustCreateLoadTestJob(ctx, t, dataServiceType, namespace.GetName(), deployment.GetClusterResourceName(), *deployment.NodeCount, config, ttlSecondsAfterFinished, backOffLimit)
+	job = helper.targetCluster.MustWaitForJobFinishedWithin(ctx, t, job.Namespace, job.Name, config.timeout())
+	require.Truef(t, isJobSucceeded(job), "Load-test job %s/%s failed (Succeeded: %d, Failed: %d).", job.Namespace, job.Name, job.Status.Succeeded, job.Status.Failed)
+
+	summaries, err := helper.targetCluster.GetLoadTestSummaries(ctx, job.Namespace, job.Name)
+	require.NoErrorf(t, err, "Getting summaries of load-test job %s/%s.", job.Namespace, job.Name)
+	results, err := loadtest.Summarize(summaries)
+	require.NoErrorf(t, err, "Summarizing load-test job %s/%s.", job.Namespace, job.Name)
+	t.Logf("Load test %s of deployment %s: %s", config.Mode, deployment.GetClusterResourceName(), results)
+	return results
+}
 
+func (c *CrossClusterHelper) mustCreateLoadTestJob(ctx context.Context, t *testing.T, dataServiceType, namespace, deploymentName string, nodeCount int32, config LoadTestConfig, ttlSecondsAfterFinished *int32, backOffLimit *int32) *batchv1.Job {
 	jobName := fmt.Sprintf("%s-loadtest-%d", deploymentName, time.Now().Unix())
-	if mode != "" {
-		suffix := strings.ReplaceAll(mode, "_", "")
+	if config.Mode != "" {
+		suffix := strings.ReplaceAll(config.Mode, "_", "")
 		jobName = fmt.Sprintf("%s-loadtest-%s-%d", deploymentName, suffix, time.Now().Unix())
 	}
 
 	image, err := getLoadTestJobImage(dataServiceType)
 	require.NoError(t, err)
 
-	env := c.targetCluster.MustGetLoadTestJobEnv(ctx, t, dataServiceType, deploymentName, namespace, mode, seed, user, nodeCount, extraEnv)
+	env := c.targetCluster.MustGetLoadTestJobEnv(ctx, t, dataServiceType, deploymentName, namespace, nodeCount, config.env())
 
-	job, err := c.targetCluster.CreateJob(ctx, namespace, jobName, image, env, nil, ttlSecondsAfterFinished, backOffLimit)
+	completions, parallelism := config.runs()
+	job, err := c.targetCluster.CreateParallelJob(ctx, namespace, jobName, image, env, nil, &completions, &parallelism, ttlSecondsAfterFinished, backOffLimit)
 	require.NoError(t, err)
 
 	return job
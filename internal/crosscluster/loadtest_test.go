@@ -0,0 +1,142 @@
+package crosscluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+func TestLoadTestConfigEnv(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   LoadTestConfig
+		expected map[string]string
+	}{
+		{
+			name:   "defaults",
+			config: LoadTestConfig{},
+			expected: map[string]string{
+				"FAIL_ON_ERROR": "true",
+				"ITERATIONS":    "1",
+				"PDS_USER":      PDSUser,
+				"SUMMARY":       "json",
+			},
+		},
+		{
+			name: "all settings",
+			config: LoadTestConfig{
+				Mode:        LoadTestWrite,
+				Seed:        "2f5e-41c8",
+				User:        "admin",
+				Duration:    90 * time.Second,
+				RecordSize:  1024,
+				Runs:        10,
+				Concurrency: 2,
+				AllowErrors: true,
+			},
+			expected: map[string]string{
+				"FAIL_ON_ERROR": "false",
+				"DURATION":      "90",
+				"RECORD_SIZE":   "1024",
+				"MODE":          LoadTestWrite,
+				"SEED":          "2f5e41c8",
+				"PDS_USER":      "admin",
+				"SUMMARY":       "json",
+			},
+		},
+		{
+			name:   "mixed",
+			config: LoadTestConfig{Mode: LoadTestMixed, ReadRatio: 0.9, Duration: time.Minute},
+			expected: map[string]string{
+				"FAIL_ON_ERROR": "true",
+				"DURATION":      "60",
+				"MODE":          LoadTestMixed,
+				"READ_RATIO":    "0.9",
+				"PDS_USER":      PDSUser,
+				"SUMMARY":       "json",
+			},
+		},
+		{
+			name:   "iterations",
+			config: LoadTestConfig{Iterations: 3},
+			expected: map[string]string{
+				"FAIL_ON_ERROR": "true",
+				"ITERATIONS":    "3",
+				"PDS_USER":      PDSUser,
+				"SUMMARY":       "json",
+			},
+		},
+		{
+			name: "extra env overrides settings",
+			config: LoadTestConfig{
+				Mode:     LoadTestCRUD,
+				ExtraEnv: map[string]string{"PASSWORD": "token", "ITERATIONS": "5"},
+			},
+			expected: map[string]string{
+				"FAIL_ON_ERROR": "true",
+				"ITERATIONS":    "5",
+				"MODE":          LoadTestCRUD,
+				"PDS_USER":      PDSUser,
+				"PASSWORD":      "token",
+				"SUMMARY":       "json",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.config.env())
+		})
+	}
+}
+
+func TestLoadTestConfigRuns(t *testing.T) {
+	testCases := []struct {
+		name                string
+		config              LoadTestConfig
+		expectedCompletions int32
+		expectedParallelism int32
+		expectedTimeout     time.Duration
+	}{
+		{
+			name:                "defaults",
+			config:              LoadTestConfig{},
+			expectedCompletions: 1,
+			expectedParallelism: 1,
+			expectedTimeout:     wait.StandardTimeout,
+		},
+		{
+			name:                "parallel runs",
+			config:              LoadTestConfig{Runs: 10, Concurrency: 4, Iterations: 2},
+			expectedCompletions: 10,
+			expectedParallelism: 4,
+			expectedTimeout:     6 * wait.StandardTimeout,
+		},
+		{
+			name:                "concurrency above runs",
+			config:              LoadTestConfig{Runs: 2, Concurrency: 8},
+			expectedCompletions: 2,
+			expectedParallelism: 2,
+			expectedTimeout:     wait.StandardTimeout,
+		},
+		{
+			name:                "duration",
+			config:              LoadTestConfig{Runs: 16, Concurrency: 4, Duration: time.Minute, Iterations: 3},
+			expectedCompletions: 16,
+			expectedParallelism: 4,
+			expectedTimeout:     4 * (time.Minute + wait.StandardTimeout),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			completions, parallelism := tc.config.runs()
+			assert.Equal(t, tc.expectedCompletions, completions)
+			assert.Equal(t, tc.expectedParallelism, parallelism)
+			assert.Equal(t, tc.expectedTimeout, tc.config.timeout())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package crosscluster
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/chaos"
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+const (
+	// mongoDBMaxReplicationLag is the accepted lag of secondaries behind the primary of a healthy replica set.
+	mongoDBMaxReplicationLag = 10 * time.Second
+	// mongoDBReplicaSetTimeout bounds waiting for the replica set to become healthy, e.g. after a failover.
+	mongoDBReplicaSetTimeout = 10 * time.Minute
+)
+
+// mongoDBDeployment is a MongoDB deployment resolved by its ID.
+type mongoDBDeployment struct {
+	id                  string
+	namespace           string
+	clusterResourceName string
+	nodeCount           int
+	user                string
+	targetCluster       *targetcluster.TargetCluster
+}
+
+func (c *CrossClusterHelper) mustGetMongoDBDeployment(ctx context.Context, t *testing.T, deploymentID string) mongoDBDeployment {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	require.Equalf(t, dataservices.MongoDB, dataServiceType, "Deployment %s is not a MongoDB deployment.", deploymentID)
+	return mongoDBDeployment{
+		id:                  deploymentID,
+		namespace:           namespace.GetName(),
+		clusterResourceName: deployment.GetClusterResourceName(),
+		nodeCount:           int(deployment.GetNodeCount()),
+		user:                c.MustGetLoadTestUser(ctx, t, deploymentID),
+		targetCluster:       c.targetClusterFor(deployment.GetDeploymentTargetId()),
+	}
+}
+
+// replicaSetStatus connects to the primary and returns the status of the replica set. Connecting fails while there
+// is no primary.
+func (d mongoDBDeployment) replicaSetStatus(ctx context.Context, t tests.T) datastore.MongoDBReplicaSetStatus {
+	client, closeClient, err := newDataStoreClient(ctx, t, d.targetCluster, d.namespace, d.clusterResourceName, d.id, dataservices.MongoDB, d.user)
+	require.NoErrorf(t, err, "Connecting MongoDB client to deployment %s.", d.id)
+	defer closeClient()
+	mongoDBClient, ok := client.(datastore.MongoDBReplicaSetClient)
+	require.Truef(t, ok, "Client of deployment %s is not a MongoDB client.", d.id)
+
+	status, err := mongoDBClient.ReplicaSetStatus(ctx)
+	require.NoErrorf(t, err, "Getting replica set status of deployment %s.", d.id)
+	return status
+}
+
+// mustWaitForHealthy waits until the replica set is healthy and returns its status.
+func (d mongoDBDeployment) mustWaitForHealthy(ctx context.Context, t *testing.T) datastore.MongoDBReplicaSetStatus {
+	var status datastore.MongoDBReplicaSetStatus
+	wait.For(t, mongoDBReplicaSetTimeout, wait.RetryInterval, func(t tests.T) {
+		status = d.replicaSetStatus(ctx, t)
+		err := datastore.VerifyMongoDBReplicaSet(status, d.nodeCount, mongoDBMaxReplicationLag)
+		require.NoErrorf(t, err, "Verifying replica set of deployment %s.", d.id)
+	})
+	return status
+}
+
+// MustWaitForMongoDBReplicaSetHealthy waits until the replica set of the MongoDB deployment has a single primary,
+// all other members are secondaries and no secondary lags behind the primary, see datastore.VerifyMongoDBReplicaSet.
+func (c *CrossClusterHelper) MustWaitForMongoDBReplicaSetHealthy(ctx context.Context, t *testing.T, deploymentID string) {
+	d := c.mustGetMongoDBDeployment(ctx, t, deploymentID)
+	status := d.mustWaitForHealthy(ctx, t)
+	primary, _ := status.Primary()
+	t.Logf("Replica set %s of deployment %s is healthy with primary %s.", status.Set, deploymentID, primary.Name)
+}
+
+// MustVerifyMongoDBFailover writes the dataset with majority write concern, deletes the pod of the primary and
+// measures the time until a primary is elected. The election has to finish within maxElection, so the replica set
+// needs at least 3 voting members for the remaining ones to be a majority. After the pod is recreated the replica set
+// has to be healthy again and the acknowledged writes have to be intact.
+func (c *CrossClusterHelper) MustVerifyMongoDBFailover(ctx context.Context, t *testing.T, deploymentID string, dataset datastore.Dataset, maxElection time.Duration) {
+	d := c.mustGetMongoDBDeployment(ctx, t, deploymentID)
+	oldPrimary, _ := d.mustWaitForHealthy(ctx, t).Primary()
+	c.MustWriteDataset(ctx, t, deploymentID, dataset)
+
+	// Members are named by the host names of the pods, e.g. "<cluster resource name>-1.<service>:27017".
+	podName := strings.SplitN(oldPrimary.Host(), ".", 2)[0]
+	ordinal, err := strconv.Atoi(strings.TrimPrefix(podName, d.clusterResourceName+"-"))
+	require.NoErrorf(t, err, "Getting ordinal of primary %s.", oldPrimary.Name)
+
+	killedAt := time.Now()
+	revert := chaos.MustInject(ctx, t, c.MustNewPodKillFault(ctx, t, deploymentID, ordinal))
+	var newPrimary datastore.MongoDBMember
+	wait.For(t, maxElection, wait.ShortRetryInterval, func(t tests.T) {
+		primary, ok := d.replicaSetStatus(ctx, t).Primary()
+		require.True(t, ok, "Replica set has no primary.")
+		// The election date is compared instead of the clock of the test, the old primary may be elected again.
+		require.Truef(t, primary.Name != oldPrimary.Name || primary.ElectionDate.After(oldPrimary.ElectionDate),
+			"Primary %s was not elected yet.", primary.Name)
+		newPrimary = primary
+	})
+	election := time.Since(killedAt)
+	t.Logf("Primary %s elected %s after deleting primary pod %s.", newPrimary.Name, election.Round(time.Second), podName)
+	require.LessOrEqualf(t, election, maxElection, "Election of deployment %s took too long.", deploymentID)
+
+	revert()
+	d.mustWaitForHealthy(ctx, t)
+	c.MustVerifyDataset(ctx, t, deploymentID, dataset)
+}
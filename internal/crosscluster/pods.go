@@ -0,0 +1,68 @@
+package crosscluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// PodState identifies a pod instance and the number of its container restarts.
+type PodState struct {
+	UID      types.UID
+	Restarts int32
+}
+
+// MustGetDeploymentPodStates returns states of the data service pods of the deployment by their names.
+// Pods of jobs, e.g. backup jobs, are not included.
+func (c *CrossClusterHelper) MustGetDeploymentPodStates(ctx context.Context, t *testing.T, deploymentID string) map[string]PodState {
+	deployment, namespace, _ := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	pods, err := targetCluster.ListPods(ctx, namespace.GetName(), map[string]string{pdsDeploymentIDLabel: deploymentID})
+	require.NoErrorf(t, err, "Listing pods of deployment %s.", deploymentID)
+	states := make(map[string]PodState, len(pods.Items))
+	for _, pod := range pods.Items {
+		if !isOwnedByStatefulSet(&pod) {
+			continue
+		}
+		states[pod.Name] = podState(&pod)
+	}
+	require.NotEmptyf(t, states, "No data service pods found for deployment %s.", deploymentID)
+	return states
+}
+
+// MustVerifyDeploymentPodsNotRestarted checks that the deployment pods were neither recreated nor had containers restarted
+// since the previous states were taken.
+func (c *CrossClusterHelper) MustVerifyDeploymentPodsNotRestarted(ctx context.Context, t *testing.T, deploymentID string, previous map[string]PodState) {
+	current := c.MustGetDeploymentPodStates(ctx, t, deploymentID)
+	require.Lenf(t, current, len(previous), "Number of pods of deployment %s changed.", deploymentID)
+	for name, state := range previous {
+		currentState, ok := current[name]
+		require.Truef(t, ok, "Pod %s of deployment %s not found.", name, deploymentID)
+		require.Equalf(t, state.UID, currentState.UID, "Pod %s of deployment %s was recreated.", name, deploymentID)
+		require.Equalf(t, state.Restarts, currentState.Restarts, "Containers of pod %s of deployment %s were restarted.", name, deploymentID)
+	}
+}
+
+func podState(pod *corev1.Pod) PodState {
+	state := PodState{UID: pod.UID}
+	for _, status := range pod.Status.InitContainerStatuses {
+		state.Restarts += status.RestartCount
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		state.Restarts += status.RestartCount
+	}
+	return state
+}
+
+func isOwnedByStatefulSet(pod *corev1.Pod) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "StatefulSet" {
+			return true
+		}
+	}
+	return false
+}
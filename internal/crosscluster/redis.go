@@ -0,0 +1,58 @@
+package crosscluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+// redisClusterTimeout bounds waiting for the cluster to converge, e.g. rebalancing slots after scaling.
+const redisClusterTimeout = 15 * time.Minute
+
+// MustWaitForRedisClusterHealthy waits until the topology of the Redis Cluster matches the node count of the
+// deployment, see datastore.VerifyRedisCluster. Slots are expected to be balanced over all masters, so after
+// scaling this waits for the slots to be rebalanced onto the new masters. Deployments with a single node don't
+// run in cluster mode and are skipped.
+func (c *CrossClusterHelper) MustWaitForRedisClusterHealthy(ctx context.Context, t *testing.T, deploymentID string) {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	require.Equalf(t, dataservices.Redis, dataServiceType, "Deployment %s is not a Redis deployment.", deploymentID)
+	nodeCount := int(deployment.GetNodeCount())
+	if nodeCount <= 1 {
+		return
+	}
+	user := c.MustGetLoadTestUser(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	start := time.Now()
+	var nodes []datastore.RedisClusterNode
+	wait.For(t, redisClusterTimeout, wait.RetryInterval, func(t tests.T) {
+		client, closeClient, err := newDataStoreClient(ctx, t, targetCluster, namespace.GetName(), deployment.GetClusterResourceName(), deploymentID, dataServiceType, user)
+		require.NoErrorf(t, err, "Connecting Redis client to deployment %s.", deploymentID)
+		defer closeClient()
+		redisClient, ok := client.(datastore.RedisClusterClient)
+		require.Truef(t, ok, "Client of deployment %s is not a Redis client.", deploymentID)
+
+		nodes, err = redisClient.ClusterNodes(ctx)
+		require.NoErrorf(t, err, "Getting cluster nodes of deployment %s.", deploymentID)
+		err = datastore.VerifyRedisCluster(nodes, nodeCount)
+		require.NoErrorf(t, err, "Verifying Redis Cluster of deployment %s.", deploymentID)
+	})
+
+	var slots []string
+	for _, node := range nodes {
+		if node.IsMaster() {
+			slots = append(slots, fmt.Sprintf("%s=%d", node.Address, node.SlotCount()))
+		}
+	}
+	t.Logf("Redis Cluster of deployment %s is healthy after %s, slots per master: %s.",
+		deploymentID, time.Since(start).Round(time.Second), strings.Join(slots, ", "))
+}
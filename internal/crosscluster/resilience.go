@@ -0,0 +1,113 @@
+package crosscluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/portworx/pds-integration-test/internal/chaos"
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+// MustVerifyResilience checks that the deployment survives the fault without data loss and recovers in time.
+// The dataset is written before the fault is injected. The fault is active for the given duration, after it is
+// reverted the deployment has to be ready again within maxRecovery since the injection and the dataset has to be
+// intact. The fault duration counts towards maxRecovery.
+func (c *CrossClusterHelper) MustVerifyResilience(ctx context.Context, t *testing.T, deploymentID string, dataset datastore.Dataset, fault chaos.Fault, duration, maxRecovery time.Duration) {
+	require.Lessf(t, duration, maxRecovery, "Fault %q lasts longer than the maximal recovery time.", fault.Name())
+	c.MustWriteDataset(ctx, t, deploymentID, dataset)
+
+	injectedAt := time.Now()
+	deadline := injectedAt.Add(maxRecovery)
+	revert := chaos.MustInject(ctx, t, fault)
+	t.Logf("Fault %q injected for %s.", fault.Name(), duration)
+	time.Sleep(duration)
+	revert()
+
+	deployment, namespace, _ := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+	remaining := time.Until(deadline)
+	require.Positivef(t, remaining, "Deployment %s has no time left to recover from fault %q, %s passed since the injection.",
+		deploymentID, fault.Name(), time.Since(injectedAt).Round(time.Second))
+	wait.For(t, remaining, wait.ShortRetryInterval, func(t tests.T) {
+		set, err := targetCluster.GetStatefulSet(ctx, namespace.GetName(), deployment.GetClusterResourceName())
+		require.NoErrorf(t, err, "Getting statefulSet for deployment %s.", deployment.GetClusterResourceName())
+		require.Equalf(t, *deployment.NodeCount, set.Status.ReadyReplicas, "ReadyReplicas don't match desired NodeCount.")
+	})
+	c.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
+	recovery := time.Since(injectedAt)
+	t.Logf("Deployment %s recovered from fault %q in %s since the injection.", deploymentID, fault.Name(), recovery.Round(time.Second))
+	require.LessOrEqualf(t, recovery, maxRecovery, "Deployment %s did not recover from fault %q in time.", deploymentID, fault.Name())
+
+	c.MustVerifyDataset(ctx, t, deploymentID, dataset)
+}
+
+// MustNewPodKillFault creates a fault killing the deployment pods with the given ordinals.
+func (c *CrossClusterHelper) MustNewPodKillFault(ctx context.Context, t *testing.T, deploymentID string, ordinals ...int) chaos.Fault {
+	deployment, namespace, _ := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+	return chaos.NewPodKill(targetCluster, namespace.GetName(), deployment.GetClusterResourceName(), ordinals...)
+}
+
+// MustNewNodeDrainFault creates a fault draining the node which runs the deployment pod with the given ordinal.
+// Only the pods of the deployment are evicted.
+func (c *CrossClusterHelper) MustNewNodeDrainFault(ctx context.Context, t *testing.T, deploymentID string, ordinal int) chaos.Fault {
+	deployment, namespace, _ := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+	podName := fmt.Sprintf("%s-%d", deployment.GetClusterResourceName(), ordinal)
+	pod, err := targetCluster.Clientset.CoreV1().Pods(namespace.GetName()).Get(ctx, podName, metav1.GetOptions{})
+	require.NoErrorf(t, err, "Getting pod %s.", podName)
+	require.NotEmptyf(t, pod.Spec.NodeName, "Pod %s is not scheduled.", podName)
+	return chaos.NewNodeDrain(targetCluster, pod.Spec.NodeName, map[string]string{pdsDeploymentIDLabel: deploymentID})
+}
+
+// MustNewNetworkBlockFault creates a fault blocking all traffic of the deployment pods.
+func (c *CrossClusterHelper) MustNewNetworkBlockFault(ctx context.Context, t *testing.T, deploymentID string) chaos.Fault {
+	deployment, namespace, _ := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+	policyName := fmt.Sprintf("%s-chaos-deny-all", deployment.GetClusterResourceName())
+	return chaos.NewNetworkBlock(targetCluster, namespace.GetName(), policyName, map[string]string{pdsDeploymentIDLabel: deploymentID})
+}
+
+// MustNewVolumeFillFault creates a fault filling sizeMB megabytes of the persistent volume of the data service container
+// in the deployment pod with the given ordinal. If sizeMB is 0, the volume is filled up completely.
+func (c *CrossClusterHelper) MustNewVolumeFillFault(ctx context.Context, t *testing.T, deploymentID string, ordinal int, sizeMB int) chaos.Fault {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+	containerName, err := getDatabaseContainerName(dataServiceType)
+	require.NoError(t, err)
+	podName := fmt.Sprintf("%s-%d", deployment.GetClusterResourceName(), ordinal)
+	pod, err := targetCluster.Clientset.CoreV1().Pods(namespace.GetName()).Get(ctx, podName, metav1.GetOptions{})
+	require.NoErrorf(t, err, "Getting pod %s.", podName)
+	mountPath, ok := persistentVolumeMountPath(pod, containerName)
+	require.Truef(t, ok, "Container %s of pod %s has no persistent volume.", containerName, podName)
+	return chaos.NewVolumeFill(targetCluster, namespace.GetName(), podName, containerName, mountPath, sizeMB)
+}
+
+// persistentVolumeMountPath returns the mount path of the first persistent volume claim mounted in the container.
+func persistentVolumeMountPath(pod *corev1.Pod, containerName string) (string, bool) {
+	claims := make(map[string]bool)
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			claims[volume.Name] = true
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != containerName {
+			continue
+		}
+		for _, mount := range container.VolumeMounts {
+			if claims[mount.Name] {
+				return mount.MountPath, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,25 @@
+package crosscluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+const resourceUsageSampleInterval = 15 * time.Second
+
+// StartDeploymentResourceUsageSampler samples the resource usage of the data service container of all pods of the
+// deployment until the sampler is stopped.
+func (c *CrossClusterHelper) StartDeploymentResourceUsageSampler(ctx context.Context, t *testing.T, deploymentID string) *targetcluster.ResourceUsageSampler {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	containerName, err := getDatabaseContainerName(dataServiceType)
+	require.NoErrorf(t, err, "Getting data service container of deployment %s.", deploymentID)
+
+	tc := c.targetClusterFor(deployment.GetDeploymentTargetId())
+	podLabels := map[string]string{pdsDeploymentIDLabel: deploymentID}
+	return tc.StartResourceUsageSampler(ctx, namespace.GetName(), podLabels, containerName, resourceUsageSampleInterval)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/portworx/pds-integration-test/internal/wait"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	backupsv1 "github.com/portworx/pds-operator-backups/api/v1"
 )
@@ -41,6 +42,25 @@ func (c *CrossClusterHelper) MustEnsureRestoreSuccessful(ctx context.Context, t
 	require.True(t, isRestoreSucceeded(pdsRestore))
 }
 
+// MustPassRestorePodSecurityAudit checks that the jobs created for the restore and their pods comply with the pod
+// security level. The jobs are found by their owner reference to the restore.
+func (c *CrossClusterHelper) MustPassRestorePodSecurityAudit(ctx context.Context, t tests.T, namespace, restoreName, level string) {
+	pdsRestore, err := c.targetCluster.GetPDSRestore(ctx, namespace, restoreName)
+	require.NoErrorf(t, err, "Getting restore %s/%s from target cluster.", namespace, restoreName)
+	jobs, err := c.targetCluster.Clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	require.NoErrorf(t, err, "Listing jobs in namespace %s.", namespace)
+
+	var jobNames []string
+	for _, job := range jobs.Items {
+		for _, owner := range job.GetOwnerReferences() {
+			if owner.UID == pdsRestore.GetUID() {
+				jobNames = append(jobNames, job.GetName())
+			}
+		}
+	}
+	c.targetCluster.MustPassJobsPodSecurityAudit(ctx, t, namespace, level, jobNames)
+}
+
 func isRestoreFinished(restore *backupsv1.Restore) bool {
 	return isRestoreSucceeded(restore) || isRestoreFailed(restore)
 }
@@ -21,13 +21,14 @@ const (
 func (c *CrossClusterHelper) MustWaitForStatefulSetReady(ctx context.Context, t tests.T, deploymentID string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
 
 	namespace := namespaceModel.GetName()
 	wait.For(t, dataservices.GetLongTimeoutFor(*deployment.NodeCount), wait.RetryInterval, func(t tests.T) {
-		set, err := c.targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
+		set, err := targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
 		require.NoErrorf(t, err, "Getting statefulSet for deployment %s.", deployment.GetClusterResourceName())
 		require.Equalf(t, *deployment.NodeCount, set.Status.ReadyReplicas, "ReadyReplicas don't match desired NodeCount.")
 		// Also check the UpdatedReplicas count, so we are sure that all nodes are updated to the current version.
@@ -38,13 +39,14 @@ func (c *CrossClusterHelper) MustWaitForStatefulSetReady(ctx context.Context, t
 func (c *CrossClusterHelper) MustWaitForStatefulSetPDSModeNormalReady(ctx context.Context, t tests.T, deploymentID string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
 
 	namespace := namespaceModel.GetName()
 	wait.For(t, dataservices.GetLongTimeoutFor(*deployment.NodeCount), wait.RetryInterval, func(t tests.T) {
-		set, err := c.targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
+		set, err := targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
 		require.NoErrorf(t, err, "Getting statefulSet for deployment %s.", deployment.GetClusterResourceName())
 		pdsMode := getPDSMode(set)
 		require.Containsf(t, []string{"", pdsModeNormal}, pdsMode, "PDS mode should be set to Normal")
@@ -67,13 +69,14 @@ func (c *CrossClusterHelper) MustWaitForRestoredStatefulSetReady(ctx context.Con
 func (c *CrossClusterHelper) MustGetStatefulSetUpdateRevision(ctx context.Context, t tests.T, deploymentID string) string {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
 
 	namespace := namespaceModel.GetName()
 
-	set, err := c.targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
+	set, err := targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
 	require.NoErrorf(t, err, "Getting statefulSet for deployment %s.", deployment.GetClusterResourceName())
 	updateRevision := set.Status.UpdateRevision
 	require.NotEmpty(t, updateRevision, "UpdateRevision of the StatefulSet is empty.")
@@ -83,13 +86,14 @@ func (c *CrossClusterHelper) MustGetStatefulSetUpdateRevision(ctx context.Contex
 func (c *CrossClusterHelper) MustWaitForStatefulSetChanged(ctx context.Context, t tests.T, deploymentID, oldUpdateRevision string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
 
 	namespace := namespaceModel.GetName()
 	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
-		set, err := c.targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
+		set, err := targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
 		require.NoErrorf(t, err, "Getting statefulSet for deployment %s.", deployment.GetClusterResourceName())
 		updateRevision := set.Status.UpdateRevision
 		require.NotEmpty(t, updateRevision, "Update revision of the StatefulSet is empty.")
@@ -100,6 +104,7 @@ func (c *CrossClusterHelper) MustWaitForStatefulSetChanged(ctx context.Context,
 func (c *CrossClusterHelper) MustWaitForStatefulSetImage(ctx context.Context, t tests.T, deploymentID, imageTag string) {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
@@ -109,7 +114,7 @@ func (c *CrossClusterHelper) MustWaitForStatefulSetImage(ctx context.Context, t
 
 	namespace := namespaceModel.GetName()
 	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
-		set, err := c.targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
+		set, err := targetCluster.GetStatefulSet(ctx, namespace, deployment.GetClusterResourceName())
 		require.NoErrorf(t, err, "Getting statefulSet for deployment %s.", deployment.GetClusterResourceName())
 
 		image, err := getDatabaseImage(dataService.GetName(), set)
@@ -120,6 +125,24 @@ func (c *CrossClusterHelper) MustWaitForStatefulSetImage(ctx context.Context, t
 }
 
 func getDatabaseImage(deploymentType string, set *appsv1.StatefulSet) (string, error) {
+	containerName, err := getDatabaseContainerName(deploymentType)
+	if err != nil {
+		return "", err
+	}
+
+	for _, container := range set.Spec.Template.Spec.Containers {
+		if container.Name != containerName {
+			continue
+		}
+
+		return container.Image, nil
+	}
+
+	return "", fmt.Errorf("database type: %s: container %q is not found", deploymentType, containerName)
+}
+
+// getDatabaseContainerName returns the name of the container running the data service in the pods of the deployment.
+func getDatabaseContainerName(deploymentType string) (string, error) {
 	var containerName string
 	switch deploymentType {
 	case dataservices.Postgres:
@@ -149,16 +172,7 @@ func getDatabaseImage(deploymentType string, set *appsv1.StatefulSet) (string, e
 	default:
 		return "", fmt.Errorf("unknown database type: %s", deploymentType)
 	}
-
-	for _, container := range set.Spec.Template.Spec.Containers {
-		if container.Name != containerName {
-			continue
-		}
-
-		return container.Image, nil
-	}
-
-	return "", fmt.Errorf("database type: %s: container %q is not found", deploymentType, containerName)
+	return containerName, nil
 }
 
 func getPDSMode(set *appsv1.StatefulSet) string {
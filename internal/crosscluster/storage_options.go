@@ -0,0 +1,59 @@
+package crosscluster
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/tests"
+)
+
+const (
+	pvcStorageProvisionerAnnotation     = "volume.kubernetes.io/storage-provisioner"
+	pvcBetaStorageProvisionerAnnotation = "volume.beta.kubernetes.io/storage-provisioner"
+)
+
+// MustVerifyStorageOptions checks that the StorageClass, PersistentVolumeClaims and Portworx volumes of the deployment
+// match the requested storage options. The expectedProvisioner is the provisioner resolved by PDS,
+// e.g. the CSI driver name for the "auto" provisioner on a cluster with CSI enabled.
+func (c *CrossClusterHelper) MustVerifyStorageOptions(ctx context.Context, t tests.T, deploymentID string, options controlplane.StorageOptions, expectedProvisioner string) {
+	storageClasses := c.MustGetStorageClassesForDeployment(ctx, t, deploymentID)
+	for _, sc := range storageClasses {
+		assert.Equalf(t, expectedProvisioner, sc.Provisioner, "Provisioner of StorageClass %s.", sc.Name)
+		assert.Equalf(t, strconv.Itoa(int(options.Repl)), sc.Parameters["repl"], "Parameter repl of StorageClass %s.", sc.Name)
+		assert.Equalf(t, options.Fs, sc.Parameters["fs"], "Parameter fs of StorageClass %s.", sc.Name)
+		assert.Equalf(t, options.Secure, parseBoolParameter(sc.Parameters["secure"]), "Parameter secure of StorageClass %s.", sc.Name)
+		assert.Equalf(t, options.ForceSpread, parseBoolParameter(sc.Parameters["fg"]), "Parameter fg of StorageClass %s.", sc.Name)
+	}
+
+	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
+	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	expected := ExpectedVolumeProperties{
+		Repl:        options.Repl,
+		Fs:          options.Fs,
+		Secure:      options.Secure,
+		ForceSpread: options.ForceSpread,
+	}
+	report, pvcs := c.mustNewDeploymentVolumeReport(ctx, t, targetCluster, deploymentID, expected)
+	for _, pvc := range pvcs {
+		provisioner := pvc.Annotations[pvcStorageProvisionerAnnotation]
+		if provisioner == "" {
+			provisioner = pvc.Annotations[pvcBetaStorageProvisionerAnnotation]
+		}
+		assert.Equalf(t, expectedProvisioner, provisioner, "Provisioner of PVC %s.", pvc.Name)
+	}
+	assert.Falsef(t, report.HasProblems(), "Volumes of deployment %s don't match the storage options.\n%s", deploymentID, report)
+}
+
+func parseBoolParameter(value string) bool {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false
+	}
+	return parsed
+}
@@ -14,6 +14,7 @@ import (
 func (c *CrossClusterHelper) MustGetStorageClassesForDeployment(ctx context.Context, t tests.T, deploymentID string) []*storagev1.StorageClass {
 	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
 	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
 
 	namespaceModel, resp, err := c.controlPlane.PDS.NamespacesApi.ApiNamespacesIdGet(ctx, *deployment.NamespaceId).Execute()
 	api.RequireNoError(t, resp, err)
@@ -26,7 +27,7 @@ func (c *CrossClusterHelper) MustGetStorageClassesForDeployment(ctx context.Cont
 		fmt.Sprintf("%s-sharedbackups-%s", deployment.GetClusterResourceName(), namespace),
 	}
 	for _, name := range storageClassNames {
-		sc, err := c.targetCluster.GetStorageClass(ctx, name)
+		sc, err := targetCluster.GetStorageClass(ctx, name)
 		require.NoError(t, err)
 		storageClasses = append(storageClasses, sc)
 	}
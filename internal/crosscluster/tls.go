@@ -0,0 +1,209 @@
+package crosscluster
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"testing"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/tlscheck"
+	"github.com/portworx/pds-integration-test/internal/wait"
+)
+
+// MustVerifyDeploymentTLS port-forwards to the client port of every deployment pod and performs a TLS handshake
+// using the protocol of the data service. The served certificate chain is validated against the CA of the cluster issuer
+// and its SANs against the hostnames of the deployment services. If tlsRequired is set, plaintext connections
+// have to be refused.
+func (c *CrossClusterHelper) MustVerifyDeploymentTLS(ctx context.Context, t *testing.T, deploymentID, issuerName string, tlsRequired bool) {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+	protocol, port, ok := tlscheck.ForDataService(dataServiceType)
+	require.Truef(t, ok, "TLS verification is not supported for data service %s.", dataServiceType)
+
+	roots := mustGetTLSRoots(ctx, t, targetCluster, issuerName, namespace.GetName(), deployment.GetClusterResourceName())
+	services, err := targetCluster.ListServices(ctx, namespace.GetName(), map[string]string{"name": deployment.GetClusterResourceName()})
+	require.NoErrorf(t, err, "Listing services of deployment %s.", deployment.GetClusterResourceName())
+	require.NotEmptyf(t, services.Items, "No services found for deployment %s.", deployment.GetClusterResourceName())
+
+	var creds tlscheck.Credentials
+	if tlsRequired {
+		creds = mustGetDeploymentCredentials(ctx, t, targetCluster, namespace.GetName(), deployment.GetClusterResourceName())
+	}
+
+	pods, err := targetCluster.ListPods(ctx, namespace.GetName(), map[string]string{pdsDeploymentIDLabel: deploymentID})
+	require.NoErrorf(t, err, "Listing pods of deployment %s.", deploymentID)
+	require.NotEmptyf(t, pods.Items, "No pods found for deployment %s.", deploymentID)
+
+	for _, pod := range pods.Items {
+		tunnel, err := targetCluster.PortforwardPod(namespace.GetName(), pod.Name, port)
+		require.NoErrorf(t, err, "Port-forwarding to pod %s port %d.", pod.Name, port)
+		address := fmt.Sprintf("localhost:%d", tunnel.Local)
+
+		func() {
+			defer tunnel.Close()
+
+			state, err := tlscheck.Handshake(ctx, protocol, address)
+			require.NoErrorf(t, err, "TLS handshake with pod %s.", pod.Name)
+
+			err = tlscheck.VerifyChain(state.PeerCertificates, roots)
+			require.NoErrorf(t, err, "Verifying certificate chain of pod %s against issuer %s.", pod.Name, issuerName)
+
+			serverCert := state.PeerCertificates[0]
+			for _, svc := range services.Items {
+				err = tlscheck.VerifyHostnames(serverCert, serviceHostnames(svc.Name, namespace.GetName(), pod.Name)...)
+				require.NoErrorf(t, err, "Verifying SANs of pod %s certificate for service %s.", pod.Name, svc.Name)
+			}
+
+			if tlsRequired {
+				accepted, err := tlscheck.AcceptsPlaintext(ctx, protocol, address, creds)
+				require.NoErrorf(t, err, "Probing plaintext connection to pod %s.", pod.Name)
+				require.Falsef(t, accepted, "Pod %s accepted a plaintext %s connection.", pod.Name, protocol.Name())
+			}
+		}()
+	}
+}
+
+// mustGetTLSRoots returns the CA of a CA cluster issuer. Other issuers, e.g. self-signed, have no common CA,
+// the CA certificates of the deployment certificate secrets are used instead.
+func mustGetTLSRoots(ctx context.Context, t tests.T, targetCluster *targetcluster.TargetCluster, issuerName, namespace, clusterResourceName string) *x509.CertPool {
+	issuer, err := targetCluster.GetClusterIssuer(ctx, issuerName)
+	require.NoErrorf(t, err, "Getting cluster issuer %s.", issuerName)
+
+	roots := x509.NewCertPool()
+	if issuer.Spec.CA != nil {
+		ca, err := targetCluster.GetClusterIssuerCA(ctx, issuerName)
+		require.NoErrorf(t, err, "Getting CA of cluster issuer %s.", issuerName)
+		require.Truef(t, roots.AppendCertsFromPEM(ca), "Parsing CA of cluster issuer %s.", issuerName)
+		return roots
+	}
+
+	certificates, err := listDeploymentCertificates(ctx, targetCluster, namespace, clusterResourceName)
+	require.NoErrorf(t, err, "Listing certificates in namespace %s.", namespace)
+	found := false
+	for _, certificate := range certificates {
+		if certificate.Spec.IssuerRef.Name != issuerName {
+			continue
+		}
+		secret, err := targetCluster.GetSecret(ctx, namespace, certificate.Spec.SecretName)
+		require.NoErrorf(t, err, "Getting secret of certificate %s.", certificate.Name)
+		require.Truef(t, roots.AppendCertsFromPEM(secret.Data[cmmeta.TLSCAKey]), "Parsing CA of certificate %s.", certificate.Name)
+		found = true
+	}
+	require.Truef(t, found, "No certificates of deployment %s issued by %s.", clusterResourceName, issuerName)
+	return roots
+}
+
+func mustGetDeploymentCredentials(ctx context.Context, t tests.T, targetCluster *targetcluster.TargetCluster, namespace, clusterResourceName string) tlscheck.Credentials {
+	secretName := fmt.Sprintf("%s-creds", clusterResourceName)
+	secret, err := targetCluster.GetSecret(ctx, namespace, secretName)
+	require.NoErrorf(t, err, "Getting credentials secret %s.", secretName)
+	return tlscheck.Credentials{
+		Username: PDSUser,
+		Password: string(secret.Data["password"]),
+	}
+}
+
+// serviceHostnames returns the in-cluster hostnames of the service and of the pod behind the (headless) service.
+func serviceHostnames(service, namespace, pod string) []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc", service, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+		fmt.Sprintf("%s.%s.%s.svc", pod, service, namespace),
+		fmt.Sprintf("%s.%s.%s.svc.cluster.local", pod, service, namespace),
+	}
+}
+
+// MustRenewDeploymentCertificates forces renewal of all certificates of the deployment and waits until they are re-issued.
+func (c *CrossClusterHelper) MustRenewDeploymentCertificates(ctx context.Context, t *testing.T, deploymentID string) {
+	deployment, namespace, _ := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	certificates, err := listDeploymentCertificates(ctx, targetCluster, namespace.GetName(), deployment.GetClusterResourceName())
+	require.NoErrorf(t, err, "Listing certificates of deployment %s.", deploymentID)
+	require.NotEmptyf(t, certificates, "No certificates found for deployment %s.", deploymentID)
+
+	revisions := make(map[string]int, len(certificates))
+	for _, certificate := range certificates {
+		revisions[certificate.Name] = certificateRevision(&certificate)
+		err = targetCluster.RenewCertificate(ctx, namespace.GetName(), certificate.Name)
+		require.NoErrorf(t, err, "Renewing certificate %s.", certificate.Name)
+	}
+
+	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
+		for name, revision := range revisions {
+			certificate, err := targetCluster.GetCertificate(ctx, namespace.GetName(), name)
+			require.NoErrorf(t, err, "Getting certificate %s.", name)
+			require.Greaterf(t, certificateRevision(certificate), revision, "Certificate %s is not re-issued.", name)
+			require.Truef(t, isCertificateReady(certificate), "Certificate %s is not ready.", name)
+		}
+	})
+}
+
+// MustGetDeploymentPodUIDs returns UIDs of the deployment pods by their names.
+func (c *CrossClusterHelper) MustGetDeploymentPodUIDs(ctx context.Context, t *testing.T, deploymentID string) map[string]types.UID {
+	deployment, namespace, _ := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	pods, err := targetCluster.ListPods(ctx, namespace.GetName(), map[string]string{pdsDeploymentIDLabel: deploymentID})
+	require.NoErrorf(t, err, "Listing pods of deployment %s.", deploymentID)
+	uids := make(map[string]types.UID, len(pods.Items))
+	for _, pod := range pods.Items {
+		uids[pod.Name] = pod.UID
+	}
+	return uids
+}
+
+// MustWaitForDeploymentPodsRolled waits until all pods of the deployment were recreated and the deployment is ready again.
+func (c *CrossClusterHelper) MustWaitForDeploymentPodsRolled(ctx context.Context, t *testing.T, deploymentID string, previousUIDs map[string]types.UID) {
+	deployment, namespace, _ := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	wait.For(t, wait.LongTimeout, wait.RetryInterval, func(t tests.T) {
+		for name, uid := range previousUIDs {
+			pod, err := targetCluster.Clientset.CoreV1().Pods(namespace.GetName()).Get(ctx, name, metav1.GetOptions{})
+			require.NoErrorf(t, err, "Getting pod %s.", name)
+			require.NotEqualf(t, uid, pod.UID, "Pod %s was not recreated.", name)
+		}
+	})
+	c.MustWaitForStatefulSetReady(ctx, t, deploymentID)
+	c.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
+}
+
+func listDeploymentCertificates(ctx context.Context, targetCluster *targetcluster.TargetCluster, namespace, clusterResourceName string) ([]certmanagerv1.Certificate, error) {
+	certificates, err := targetCluster.ListCertificates(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var deploymentCertificates []certmanagerv1.Certificate
+	for _, certificate := range certificates.Items {
+		if strings.HasPrefix(certificate.Name, clusterResourceName) {
+			deploymentCertificates = append(deploymentCertificates, certificate)
+		}
+	}
+	return deploymentCertificates, nil
+}
+
+func certificateRevision(certificate *certmanagerv1.Certificate) int {
+	if certificate.Status.Revision == nil {
+		return 0
+	}
+	return *certificate.Status.Revision
+}
+
+func isCertificateReady(certificate *certmanagerv1.Certificate) bool {
+	for _, condition := range certificate.Status.Conditions {
+		if condition.Type == certmanagerv1.CertificateConditionReady {
+			return condition.Status == cmmeta.ConditionTrue
+		}
+	}
+	return false
+}
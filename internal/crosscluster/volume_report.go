@@ -0,0 +1,237 @@
+package crosscluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/portworx"
+	"github.com/portworx/pds-integration-test/internal/tests"
+)
+
+// ExpectedVolumeProperties are the Portworx volume properties requested by a deployment.
+type ExpectedVolumeProperties struct {
+	Repl        int32
+	Fs          string
+	Secure      bool
+	ForceSpread bool
+	// StorageRequest is the storage request of the resource settings template, e.g. "5G".
+	StorageRequest string
+}
+
+// VolumeReport describes a Portworx volume of a single PersistentVolumeClaim and its violations of the expected properties.
+type VolumeReport struct {
+	PVC              string
+	PersistentVolume string
+	VolumeID         string
+	HALevel          int64
+	Format           string
+	Encrypted        bool
+	GroupEnforced    bool
+	SizeBytes        int64
+	ReplicaNodes     []string
+	Problems         []string
+}
+
+// DeploymentVolumeReport describes all Portworx volumes of a deployment.
+type DeploymentVolumeReport struct {
+	DeploymentID string
+	Expected     ExpectedVolumeProperties
+	Volumes      []VolumeReport
+	// Problems holds violations which span multiple volumes, e.g. shared replica nodes with enforced spread.
+	Problems []string
+}
+
+// HasProblems returns true if any of the volumes doesn't match the expected properties.
+func (r DeploymentVolumeReport) HasProblems() bool {
+	if len(r.Problems) > 0 {
+		return true
+	}
+	for _, volume := range r.Volumes {
+		if len(volume.Problems) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (r DeploymentVolumeReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Volumes of deployment %s (expected repl=%d fs=%s secure=%t fg=%t size=%s):\n",
+		r.DeploymentID, r.Expected.Repl, r.Expected.Fs, r.Expected.Secure, r.Expected.ForceSpread, r.Expected.StorageRequest)
+	for _, volume := range r.Volumes {
+		fmt.Fprintf(&sb, "- PVC %s, PV %s, volume %s: repl=%d fs=%s encrypted=%t group_enforced=%t size=%d nodes=%s\n",
+			volume.PVC, volume.PersistentVolume, volume.VolumeID, volume.HALevel, volume.Format, volume.Encrypted,
+			volume.GroupEnforced, volume.SizeBytes, strings.Join(volume.ReplicaNodes, ","))
+		for _, problem := range volume.Problems {
+			fmt.Fprintf(&sb, "  * %s\n", problem)
+		}
+	}
+	for _, problem := range r.Problems {
+		fmt.Fprintf(&sb, "* %s\n", problem)
+	}
+	return sb.String()
+}
+
+// MustGetDeploymentVolumeReport maps PersistentVolumeClaims of the deployment to Portworx volumes
+// through the Portworx REST proxy and checks them against the storage options and resources of the deployment.
+func (c *CrossClusterHelper) MustGetDeploymentVolumeReport(ctx context.Context, t tests.T, deploymentID string) DeploymentVolumeReport {
+	deployment, resp, err := c.controlPlane.PDS.DeploymentsApi.ApiDeploymentsIdGet(ctx, deploymentID).Execute()
+	api.RequireNoError(t, resp, err)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+
+	storageOptions := deployment.GetStorageOptions()
+	resources := deployment.GetResources()
+	expected := ExpectedVolumeProperties{
+		Repl:           storageOptions.GetRepl(),
+		Fs:             storageOptions.GetFs(),
+		Secure:         storageOptions.GetSecure(),
+		ForceSpread:    storageOptions.GetFg(),
+		StorageRequest: resources.GetStorageRequest(),
+	}
+
+	report, _ := c.mustNewDeploymentVolumeReport(ctx, t, targetCluster, deploymentID, expected)
+	return report
+}
+
+// mustNewDeploymentVolumeReport checks the Portworx volumes of the deployment PersistentVolumeClaims against
+// the expected properties. It returns the report and the claims.
+func (c *CrossClusterHelper) mustNewDeploymentVolumeReport(ctx context.Context, t tests.T, targetCluster *targetcluster.TargetCluster, deploymentID string, expected ExpectedVolumeProperties) (DeploymentVolumeReport, []corev1.PersistentVolumeClaim) {
+	namespace := c.controlPlane.MustGetNamespaceForDeployment(ctx, t, deploymentID)
+	pvcs, err := targetCluster.ListPersistentVolumeClaims(ctx, namespace, map[string]string{pdsDeploymentIDLabel: deploymentID})
+	require.NoErrorf(t, err, "Listing PVCs of deployment %s.", deploymentID)
+	require.NotEmptyf(t, pvcs.Items, "No PVCs found for deployment %s.", deploymentID)
+
+	volumes, err := targetCluster.ListPXVolumes(ctx)
+	require.NoError(t, err, "Listing Portworx volumes.")
+
+	report, err := newDeploymentVolumeReport(deploymentID, expected, pvcs.Items, volumes)
+	require.NoErrorf(t, err, "Creating volume report of deployment %s.", deploymentID)
+	return report, pvcs.Items
+}
+
+// MustVerifyDeploymentVolumes checks replication factor, filesystem, encryption, size and replica node spread
+// of all Portworx volumes of the deployment. The volume report is logged on failure.
+func (c *CrossClusterHelper) MustVerifyDeploymentVolumes(ctx context.Context, t tests.T, deploymentID string) {
+	report := c.MustGetDeploymentVolumeReport(ctx, t, deploymentID)
+	assert.Falsef(t, report.HasProblems(), "Volumes of deployment %s don't match the expected properties.\n%s", deploymentID, report)
+}
+
+func newDeploymentVolumeReport(deploymentID string, expected ExpectedVolumeProperties, pvcs []corev1.PersistentVolumeClaim, volumes []portworx.PXVolume) (DeploymentVolumeReport, error) {
+	report := DeploymentVolumeReport{
+		DeploymentID: deploymentID,
+		Expected:     expected,
+	}
+
+	var expectedSize int64
+	if expected.StorageRequest != "" {
+		quantity, err := resource.ParseQuantity(expected.StorageRequest)
+		if err != nil {
+			return report, fmt.Errorf("parse storage request %q: %w", expected.StorageRequest, err)
+		}
+		expectedSize = quantity.Value()
+	}
+
+	volumesByName := make(map[string]portworx.PXVolume, len(volumes))
+	for _, volume := range volumes {
+		volumesByName[volume.Locator.Name] = volume
+	}
+
+	volumesByNode := make(map[string][]string)
+	for _, pvc := range pvcs {
+		volumeReport := VolumeReport{
+			PVC:              pvc.Name,
+			PersistentVolume: pvc.Spec.VolumeName,
+		}
+		if pvc.Spec.VolumeName == "" {
+			volumeReport.Problems = append(volumeReport.Problems, "PVC is not bound")
+			report.Volumes = append(report.Volumes, volumeReport)
+			continue
+		}
+		volume, ok := volumesByName[pvc.Spec.VolumeName]
+		if !ok {
+			volumeReport.Problems = append(volumeReport.Problems, "Portworx volume not found")
+			report.Volumes = append(report.Volumes, volumeReport)
+			continue
+		}
+
+		volumeReport.VolumeID = volume.ID
+		volumeReport.HALevel = int64(volume.Spec.HALevel)
+		volumeReport.Format = volume.Spec.Format
+		volumeReport.Encrypted = volume.Spec.Encrypted
+		volumeReport.GroupEnforced = volume.Spec.GroupEnforced
+		volumeReport.SizeBytes = int64(volume.Spec.Size)
+		for _, replicaSet := range volume.ReplicaSets {
+			volumeReport.ReplicaNodes = append(volumeReport.ReplicaNodes, replicaSet.Nodes...)
+		}
+		volumeReport.Problems = verifyVolume(expected, expectedSize, volumeReport)
+
+		for _, node := range uniqueStrings(volumeReport.ReplicaNodes) {
+			volumesByNode[node] = append(volumesByNode[node], volume.ID)
+		}
+		report.Volumes = append(report.Volumes, volumeReport)
+	}
+
+	if expected.ForceSpread {
+		nodes := make([]string, 0, len(volumesByNode))
+		for node := range volumesByNode {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		for _, node := range nodes {
+			if volumeIDs := volumesByNode[node]; len(volumeIDs) > 1 {
+				report.Problems = append(report.Problems, fmt.Sprintf("node %s holds replicas of multiple volumes %s", node, strings.Join(volumeIDs, ",")))
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func verifyVolume(expected ExpectedVolumeProperties, expectedSize int64, volume VolumeReport) []string {
+	var problems []string
+	if volume.HALevel != int64(expected.Repl) {
+		problems = append(problems, fmt.Sprintf("replication factor: expected %d, got %d", expected.Repl, volume.HALevel))
+	}
+	if !strings.EqualFold(volume.Format, "FS_TYPE_"+expected.Fs) && !strings.EqualFold(volume.Format, expected.Fs) {
+		problems = append(problems, fmt.Sprintf("filesystem: expected %s, got %s", expected.Fs, volume.Format))
+	}
+	if volume.Encrypted != expected.Secure {
+		problems = append(problems, fmt.Sprintf("encryption: expected %t, got %t", expected.Secure, volume.Encrypted))
+	}
+	if volume.GroupEnforced != expected.ForceSpread {
+		problems = append(problems, fmt.Sprintf("enforced replica group: expected %t, got %t", expected.ForceSpread, volume.GroupEnforced))
+	}
+	// Portworx may round the volume size up, it must never be smaller than requested.
+	if volume.SizeBytes < expectedSize {
+		problems = append(problems, fmt.Sprintf("size: expected at least %d bytes, got %d", expectedSize, volume.SizeBytes))
+	}
+	uniqueNodes := uniqueStrings(volume.ReplicaNodes)
+	if len(uniqueNodes) != len(volume.ReplicaNodes) {
+		problems = append(problems, fmt.Sprintf("replicas share nodes %s", strings.Join(volume.ReplicaNodes, ",")))
+	}
+	if int64(len(uniqueNodes)) != int64(expected.Repl) {
+		problems = append(problems, fmt.Sprintf("replica nodes: expected %d, got %d", expected.Repl, len(uniqueNodes)))
+	}
+	return problems
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,97 @@
+package crosscluster
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/portworx/pds-integration-test/internal/portworx"
+)
+
+func TestNewDeploymentVolumeReport(t *testing.T) {
+	expected := ExpectedVolumeProperties{
+		Repl:           2,
+		Fs:             "xfs",
+		Secure:         true,
+		ForceSpread:    true,
+		StorageRequest: "5G",
+	}
+	pvcs := []corev1.PersistentVolumeClaim{
+		boundPVC("data-ds-0", "pvc-0"),
+		boundPVC("data-ds-1", "pvc-1"),
+		boundPVC("data-ds-2", ""),
+	}
+
+	testCases := []struct {
+		name             string
+		volumesJSON      string
+		volumeProblems   [][]string
+		deploymentIssues []string
+	}{
+		{
+			name: "all volumes as expected",
+			volumesJSON: `[
+				{"id": "1", "locator": {"name": "pvc-0"}, "spec": {"ha_level": "2", "format": "FS_TYPE_XFS", "encrypted": true, "group_enforced": true, "size": "5368709120"}, "replica_sets": [{"nodes": ["n1", "n2"]}]},
+				{"id": "2", "locator": {"name": "pvc-1"}, "spec": {"ha_level": "2", "format": "FS_TYPE_XFS", "encrypted": true, "group_enforced": true, "size": "5000000000"}, "replica_sets": [{"nodes": ["n3", "n4"]}]}
+			]`,
+			volumeProblems: [][]string{nil, nil, {"PVC is not bound"}},
+		},
+		{
+			name: "wrong properties and shared nodes",
+			volumesJSON: `[
+				{"id": "1", "locator": {"name": "pvc-0"}, "spec": {"ha_level": "1", "format": "FS_TYPE_EXT4", "encrypted": false, "group_enforced": true, "size": "1073741824"}, "replica_sets": [{"nodes": ["n1"]}]},
+				{"id": "2", "locator": {"name": "pvc-1"}, "spec": {"ha_level": "2", "format": "FS_TYPE_XFS", "encrypted": true, "group_enforced": true, "size": "5368709120"}, "replica_sets": [{"nodes": ["n1", "n2"]}]}
+			]`,
+			volumeProblems: [][]string{
+				{
+					"replication factor: expected 2, got 1",
+					"filesystem: expected xfs, got FS_TYPE_EXT4",
+					"encryption: expected true, got false",
+					"size: expected at least 5000000000 bytes, got 1073741824",
+					"replica nodes: expected 2, got 1",
+				},
+				nil,
+				{"PVC is not bound"},
+			},
+			deploymentIssues: []string{"node n1 holds replicas of multiple volumes 1,2"},
+		},
+		{
+			name:           "missing volume",
+			volumesJSON:    `[]`,
+			volumeProblems: [][]string{{"Portworx volume not found"}, {"Portworx volume not found"}, {"PVC is not bound"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var volumes []portworx.PXVolume
+			require.NoError(t, json.Unmarshal([]byte(tc.volumesJSON), &volumes))
+
+			report, err := newDeploymentVolumeReport("dep-id", expected, pvcs, volumes)
+			require.NoError(t, err)
+
+			require.Len(t, report.Volumes, len(tc.volumeProblems))
+			for i, problems := range tc.volumeProblems {
+				assert.Equal(t, problems, report.Volumes[i].Problems, "Problems of volume %s.", report.Volumes[i].PVC)
+			}
+			assert.Equal(t, tc.deploymentIssues, report.Problems)
+			assert.True(t, report.HasProblems())
+		})
+	}
+}
+
+func TestNewDeploymentVolumeReport_InvalidStorageRequest(t *testing.T) {
+	_, err := newDeploymentVolumeReport("dep-id", ExpectedVolumeProperties{StorageRequest: "five"}, nil, nil)
+	assert.Error(t, err)
+}
+
+func boundPVC(name, volumeName string) corev1.PersistentVolumeClaim {
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: volumeName},
+	}
+}
@@ -0,0 +1,205 @@
+package crosscluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/internal/workload"
+)
+
+const (
+	backgroundWorkloadInterval    = time.Second
+	backgroundWorkloadOpTimeout   = 10 * time.Second
+	backgroundWorkloadDatasetSize = 10
+	// backgroundWorkloadRecoveryTimeout bounds how long a stopped workload keeps running until failing operations succeed again.
+	backgroundWorkloadRecoveryTimeout = 2 * time.Minute
+)
+
+// errLostWrite is returned by reads which found the records of an acknowledged write overwritten or missing.
+var errLostWrite = errors.New("acknowledged write lost")
+
+// BackgroundWorkload writes and reads a small dataset against a deployment in a loop, e.g. during rolling updates,
+// scaling, agent upgrades or restarts, and records the outcome of every operation. Every write stores the next
+// generation of the dataset, every read checks that the last acknowledged generation is stored, so lost writes are
+// detected.
+type BackgroundWorkload struct {
+	deploymentID string
+	tolerance    workload.Tolerance
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	timeline workload.Timeline
+}
+
+// StartBackgroundWorkload starts a workload against the deployment with a native client. The client is connected
+// through port-forwards and reconnected to the running pods after every failed operation. The initial write of the
+// dataset has to succeed.
+func (c *CrossClusterHelper) StartBackgroundWorkload(ctx context.Context, t *testing.T, deploymentID string) *BackgroundWorkload {
+	deployment, namespace, dataServiceType := c.MustGetDeploymentInfo(ctx, t, deploymentID)
+	user := c.MustGetLoadTestUser(ctx, t, deploymentID)
+	targetCluster := c.targetClusterFor(deployment.GetDeploymentTargetId())
+	creds := mustGetDeploymentCredentials(ctx, t, targetCluster, namespace.GetName(), deployment.GetClusterResourceName())
+	connect := func(ctx context.Context) (datastore.Client, func(), error) {
+		return connectDataStore(ctx, targetCluster, namespace.GetName(), deploymentID, dataServiceType, user, creds.Password)
+	}
+
+	dataset := datastore.NewDataset(deploymentID+"-workload", backgroundWorkloadDatasetSize)
+	client, closeClient, err := connect(ctx)
+	require.NoErrorf(t, err, "Connecting %s client to deployment %s.", dataServiceType, deploymentID)
+	if err := client.Write(ctx, dataset); err != nil {
+		closeClient()
+		require.NoErrorf(t, err, "Writing dataset %s to deployment %s.", dataset, deploymentID)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &BackgroundWorkload{
+		deploymentID: deploymentID,
+		tolerance:    workload.RolloutTolerance(dataServiceType, deployment.GetNodeCount()),
+		cancel:       cancel,
+		done:         make(chan struct{}),
+	}
+	go w.run(ctx, connect, client, closeClient, dataset)
+	// Do not leak the workload if the test fails before it is stopped.
+	t.Cleanup(func() {
+		w.cancel()
+		<-w.done
+	})
+	t.Logf("Started background workload against deployment %s.", deploymentID)
+	return w
+}
+
+func (w *BackgroundWorkload) run(ctx context.Context, connect func(ctx context.Context) (datastore.Client, func(), error), client datastore.Client, closeClient func(), dataset datastore.Dataset) {
+	defer close(w.done)
+	defer func() {
+		if closeClient != nil {
+			closeClient()
+		}
+	}()
+
+	// The generation of the last write and of the last acknowledged one. Failed writes may have been applied, so any
+	// generation between them is a valid read.
+	generation, acknowledged := dataset.Generation, dataset.Generation
+	ticker := time.NewTicker(backgroundWorkloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if client == nil {
+			var err error
+			start := time.Now()
+			client, closeClient, err = connect(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				// Both writes and reads are unavailable without a connection.
+				w.record(workload.KindWrite, start, err)
+				w.record(workload.KindRead, start, err)
+				continue
+			}
+		}
+
+		generation++
+		next := dataset
+		next.Generation = generation
+		writeErr := w.do(ctx, workload.KindWrite, func(ctx context.Context) error {
+			return client.Write(ctx, next)
+		})
+		if writeErr == nil {
+			acknowledged = generation
+		}
+		readErr := w.do(ctx, workload.KindRead, func(ctx context.Context) error {
+			return verifyAcknowledged(ctx, client, dataset, acknowledged, generation)
+		})
+		if writeErr != nil || readErr != nil {
+			// Pods may have been replaced, reconnect to the running ones.
+			closeClient()
+			client, closeClient = nil, nil
+		}
+	}
+}
+
+// verifyAcknowledged reads the dataset and checks that every record has the value of the acknowledged generation or
+// of a later one.
+func verifyAcknowledged(ctx context.Context, client datastore.Client, dataset datastore.Dataset, acknowledged, latest int) error {
+	dataset.Generation = acknowledged
+	records, err := client.Read(ctx, dataset)
+	if err != nil {
+		return fmt.Errorf("reading dataset %s: %w", dataset, err)
+	}
+	if diff := datastore.CompareGenerations(dataset, latest, records); !diff.Empty() {
+		return fmt.Errorf("%w: dataset %s: %s", errLostWrite, dataset, diff)
+	}
+	return nil
+}
+
+// do runs and records the operation. Operations interrupted by stopping the workload are not recorded.
+func (w *BackgroundWorkload) do(ctx context.Context, kind string, op func(ctx context.Context) error) error {
+	start := time.Now()
+	opCtx, cancel := context.WithTimeout(ctx, backgroundWorkloadOpTimeout)
+	defer cancel()
+	err := op(opCtx)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	w.record(kind, start, err)
+	return err
+}
+
+func (w *BackgroundWorkload) record(kind string, start time.Time, err error) {
+	op := workload.Operation{Time: start, Kind: kind, Latency: time.Since(start)}
+	if err != nil {
+		op.Error = err.Error()
+		op.Lost = errors.Is(err, errLostWrite)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timeline = append(w.timeline, op)
+}
+
+// Timeline returns the operations recorded so far.
+func (w *BackgroundWorkload) Timeline() workload.Timeline {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append(workload.Timeline(nil), w.timeline...)
+}
+
+// Stop stops the workload and returns its timeline. If the last operations failed, the workload keeps running
+// until they succeed again or backgroundWorkloadRecoveryTimeout passes.
+func (w *BackgroundWorkload) Stop() workload.Timeline {
+	deadline := time.Now().Add(backgroundWorkloadRecoveryTimeout)
+	for !w.Timeline().Recovered() && time.Now().Before(deadline) {
+		time.Sleep(backgroundWorkloadInterval)
+	}
+	w.cancel()
+	<-w.done
+	return w.Timeline()
+}
+
+// MustStop stops the workload and checks the unavailability windows against the rollout tolerance of the
+// data service, see workload.RolloutTolerance.
+func (w *BackgroundWorkload) MustStop(t *testing.T) workload.Timeline {
+	return w.MustStopWithTolerance(t, w.tolerance)
+}
+
+// MustStopWithTolerance stops the workload and checks the unavailability windows against the given tolerance.
+func (w *BackgroundWorkload) MustStopWithTolerance(t *testing.T, tolerance workload.Tolerance) workload.Timeline {
+	timeline := w.Stop()
+	t.Logf("Background workload against deployment %s:\n%s", w.deploymentID, timeline.Summary())
+	require.NotEmptyf(t, timeline, "Background workload against deployment %s did not run any operations.", w.deploymentID)
+	require.NoErrorf(t, timeline.Check(tolerance), "Background workload against deployment %s.", w.deploymentID)
+	return timeline
+}
@@ -0,0 +1,48 @@
+package crosscluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portworx/pds-integration-test/internal/datastore"
+)
+
+// recordsClient stores the records of the last written dataset.
+type recordsClient struct {
+	datastore.Client
+	records map[string]string
+}
+
+func (c *recordsClient) Write(_ context.Context, dataset datastore.Dataset) error {
+	c.records = dataset.Records()
+	return nil
+}
+
+func (c *recordsClient) Read(_ context.Context, _ datastore.Dataset) (map[string]string, error) {
+	return c.records, nil
+}
+
+func TestVerifyAcknowledged(t *testing.T) {
+	ctx := context.Background()
+	dataset := datastore.NewDataset("workload", 3)
+	client := &recordsClient{}
+	generation := func(g int) datastore.Dataset {
+		dataset := dataset
+		dataset.Generation = g
+		return dataset
+	}
+
+	assert.NoError(t, client.Write(ctx, generation(2)))
+	assert.NoError(t, verifyAcknowledged(ctx, client, dataset, 2, 2))
+	// The failed write of generation 3 may have been applied.
+	assert.NoError(t, client.Write(ctx, generation(3)))
+	assert.NoError(t, verifyAcknowledged(ctx, client, dataset, 2, 3))
+
+	// Generation 3 was acknowledged, but generation 2 is read.
+	assert.NoError(t, client.Write(ctx, generation(2)))
+	err := verifyAcknowledged(ctx, client, dataset, 3, 3)
+	assert.ErrorIs(t, err, errLostWrite)
+	assert.Contains(t, err.Error(), "generation 3")
+}
@@ -0,0 +1,123 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	cassandraPort     = 9042
+	cassandraKeyspace = "pds_integration"
+	// cassandraMaxReplicationFactor is the replication factor of the keyspace on clusters with enough nodes.
+	cassandraMaxReplicationFactor = 3
+)
+
+type cassandraClient struct {
+	session *gocql.Session
+	config  Config
+}
+
+// newCassandraClient connects to all endpoints. Peers announced by the nodes are translated to the tunnels.
+func newCassandraClient(ctx context.Context, config Config) (Client, error) {
+	cluster := newCassandraCluster(config, config.localAddresses(cassandraPort)...)
+	cluster.Consistency = gocql.Quorum
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return nil, fmt.Errorf("connecting to Cassandra: %w", err)
+	}
+
+	replicationFactor := len(config.Endpoints)
+	if replicationFactor > cassandraMaxReplicationFactor {
+		replicationFactor = cassandraMaxReplicationFactor
+	}
+	createKeyspace := fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s WITH replication = {'class': 'SimpleStrategy', 'replication_factor': %d}",
+		cassandraKeyspace, replicationFactor)
+	if err := session.Query(createKeyspace).WithContext(ctx).Exec(); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("creating keyspace %s: %w", cassandraKeyspace, err)
+	}
+	return &cassandraClient{session: session, config: config}, nil
+}
+
+// newCassandraCluster returns the configuration of a session connecting to the hosts, addresses of the nodes are
+// translated to the tunnels.
+func newCassandraCluster(config Config, hosts ...string) *gocql.ClusterConfig {
+	cluster := gocql.NewCluster(hosts...)
+	cluster.Authenticator = gocql.PasswordAuthenticator{Username: config.Username, Password: config.Password}
+	cluster.ConnectTimeout = dialTimeout
+	cluster.AddressTranslator = gocql.AddressTranslatorFunc(func(addr net.IP, port int) (net.IP, int) {
+		host, portString, err := net.SplitHostPort(config.resolve(net.JoinHostPort(addr.String(), strconv.Itoa(port))))
+		if err != nil {
+			return addr, port
+		}
+		translatedPort, err := strconv.Atoi(portString)
+		if err != nil {
+			return addr, port
+		}
+		return net.ParseIP(host), translatedPort
+	})
+	return cluster
+}
+
+func (c *cassandraClient) Write(ctx context.Context, dataset Dataset) error {
+	table, err := cassandraTable(dataset)
+	if err != nil {
+		return err
+	}
+	createTable := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (record_key text PRIMARY KEY, record_value text)", table)
+	if err := c.session.Query(createTable).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("creating table %s: %w", table, err)
+	}
+	if err := c.session.Query(fmt.Sprintf("TRUNCATE %s", table)).WithContext(ctx).Exec(); err != nil {
+		return fmt.Errorf("truncating table %s: %w", table, err)
+	}
+
+	insert := fmt.Sprintf("INSERT INTO %s (record_key, record_value) VALUES (?, ?)", table)
+	records := dataset.Records()
+	for _, key := range sortedKeys(records) {
+		if err := c.session.Query(insert, key, records[key]).WithContext(ctx).Exec(); err != nil {
+			return fmt.Errorf("inserting record %s into table %s: %w", key, table, err)
+		}
+	}
+	return nil
+}
+
+func (c *cassandraClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	table, err := cassandraTable(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]string)
+	iter := c.session.Query(fmt.Sprintf("SELECT record_key, record_value FROM %s", table)).WithContext(ctx).Iter()
+	var key, value string
+	for iter.Scan(&key, &value) {
+		records[key] = value
+	}
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("selecting records from table %s: %w", table, err)
+	}
+	return records, nil
+}
+
+func (c *cassandraClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *cassandraClient) Close() error {
+	c.session.Close()
+	return nil
+}
+
+func cassandraTable(dataset Dataset) (string, error) {
+	name, err := dataset.name()
+	if err != nil {
+		return "", err
+	}
+	return cassandraKeyspace + "." + name, nil
+}
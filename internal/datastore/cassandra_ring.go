@@ -0,0 +1,336 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gocql/gocql"
+)
+
+// cassandraBootstrapCompleted is the bootstrap state of nodes which joined the ring.
+const cassandraBootstrapCompleted = "COMPLETED"
+
+// cassandraUpNormal is the gossip status of nodes which are up and serve their token ranges.
+const cassandraUpNormal = "UN"
+
+var (
+	cassandraStatusPattern = regexp.MustCompile(`^[UD][NLJM]$`)
+	cassandraHostIDPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// CassandraRingClient is implemented by the client of Cassandra deployments, see New. Next to datasets it reports
+// the ring as seen by each node, e.g. for checks after scaling or pod restarts.
+type CassandraRingClient interface {
+	Client
+	// Ring queries system.local and system.peers on every node and the replication of all keyspaces.
+	Ring(ctx context.Context) (CassandraRing, error)
+}
+
+// CassandraRing is the state of the ring as reported by the nodes.
+type CassandraRing struct {
+	// Nodes are the nodes as reported by themselves in system.local.
+	Nodes []CassandraNode
+	// Peers are the peers of each node by its host ID, as reported in its system.peers.
+	Peers map[string][]CassandraNode
+	// Keyspaces maps the keyspaces to their replication settings.
+	Keyspaces map[string]map[string]string
+	// Gossip maps each node, e.g. by its pod, to the gossip status of all nodes by host ID as seen by that node, see
+	// ParseCassandraNodetoolStatus. It isn't filled by Ring.
+	Gossip map[string]map[string]string
+}
+
+// CassandraNode is a node of the ring. Bootstrapped is only known for nodes reporting themselves.
+type CassandraNode struct {
+	HostID        string
+	Address       string
+	DataCenter    string
+	Rack          string
+	Bootstrapped  string
+	SchemaVersion string
+	Tokens        []string
+}
+
+// CassandraOwnership returns the share of the token range owned by the nodes by their host IDs. Tokens are expected
+// to be Murmur3 tokens.
+func CassandraOwnership(nodes []CassandraNode) (map[string]float64, error) {
+	type token struct {
+		value  int64
+		hostID string
+	}
+	var tokens []token
+	for _, node := range nodes {
+		for _, value := range node.Tokens {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unsupported token %q of node %s", value, node.HostID)
+			}
+			tokens = append(tokens, token{value: parsed, hostID: node.HostID})
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens")
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].value < tokens[j].value })
+
+	ownership := make(map[string]float64, len(nodes))
+	if len(tokens) == 1 {
+		ownership[tokens[0].hostID] = 1
+		return ownership, nil
+	}
+	// Each token owns the range after the previous token, the first token wraps around. Differences of the unsigned
+	// values are the sizes of the ranges modulo 2^64.
+	previous := tokens[len(tokens)-1].value
+	for _, t := range tokens {
+		ownership[t.hostID] += float64(uint64(t.value)-uint64(previous)) / math.Pow(2, 64)
+		previous = t.value
+	}
+	return ownership, nil
+}
+
+// VerifyCassandraRing checks a ring of nodeCount nodes: every node completed bootstrapping and sees all other nodes
+// as peers with tokens, all nodes agree on the schema version, every node owns between half and twice an even share
+// of the token range and the replication factors of all non-system keyspaces can be satisfied by the nodes of their
+// data centers. Every node has to see all nodes as up and normal (UN) in its gossip status.
+func VerifyCassandraRing(ring CassandraRing, nodeCount int) error {
+	var problems []string
+	if len(ring.Nodes) != nodeCount {
+		problems = append(problems, fmt.Sprintf("%d nodes, expected %d", len(ring.Nodes), nodeCount))
+	}
+
+	hostIDs := make(map[string]bool, len(ring.Nodes))
+	dataCenters := make(map[string]int)
+	for _, node := range ring.Nodes {
+		hostIDs[node.HostID] = true
+		dataCenters[node.DataCenter]++
+	}
+
+	schemaVersions := make(map[string][]string)
+	for _, node := range ring.Nodes {
+		if node.Bootstrapped != cassandraBootstrapCompleted {
+			problems = append(problems, fmt.Sprintf("node %s (%s) did not complete bootstrapping, bootstrap state %q", node.HostID, node.Address, node.Bootstrapped))
+		}
+		if len(node.Tokens) == 0 {
+			problems = append(problems, fmt.Sprintf("node %s (%s) has no tokens", node.HostID, node.Address))
+		}
+		schemaVersions[node.SchemaVersion] = append(schemaVersions[node.SchemaVersion], node.Address)
+
+		seen := make(map[string]bool)
+		for _, peer := range ring.Peers[node.HostID] {
+			seen[peer.HostID] = true
+			if !hostIDs[peer.HostID] {
+				problems = append(problems, fmt.Sprintf("node %s sees unknown peer %s (%s)", node.Address, peer.HostID, peer.Address))
+			} else if len(peer.Tokens) == 0 || peer.SchemaVersion == "" {
+				problems = append(problems, fmt.Sprintf("node %s sees peer %s without tokens or schema", node.Address, peer.Address))
+			}
+		}
+		for _, other := range ring.Nodes {
+			if other.HostID != node.HostID && !seen[other.HostID] {
+				problems = append(problems, fmt.Sprintf("node %s doesn't see peer %s", node.Address, other.Address))
+			}
+		}
+	}
+	if len(schemaVersions) > 1 {
+		var versions []string
+		for _, version := range sortedKeys(schemaVersions) {
+			versions = append(versions, fmt.Sprintf("%s on %s", version, strings.Join(schemaVersions[version], ",")))
+		}
+		problems = append(problems, fmt.Sprintf("no schema agreement: %s", strings.Join(versions, ", ")))
+	}
+
+	if len(ring.Nodes) > 0 {
+		ownership, err := CassandraOwnership(ring.Nodes)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		share := 1 / float64(len(ring.Nodes))
+		for _, node := range ring.Nodes {
+			if owned := ownership[node.HostID]; err == nil && (owned < share/2 || owned > share*2) {
+				problems = append(problems, fmt.Sprintf("node %s (%s) owns %.1f%% of the tokens, expected about %.1f%%", node.HostID, node.Address, owned*100, share*100))
+			}
+		}
+	}
+
+	if len(ring.Gossip) == 0 {
+		problems = append(problems, "no gossip status")
+	}
+	for _, view := range sortedKeys(ring.Gossip) {
+		statuses := ring.Gossip[view]
+		for _, node := range ring.Nodes {
+			if status, ok := statuses[node.HostID]; !ok {
+				problems = append(problems, fmt.Sprintf("%s doesn't gossip with node %s (%s)", view, node.HostID, node.Address))
+			} else if status != cassandraUpNormal {
+				problems = append(problems, fmt.Sprintf("%s sees node %s (%s) as %s", view, node.HostID, node.Address, status))
+			}
+		}
+		for _, hostID := range sortedKeys(statuses) {
+			if !hostIDs[hostID] {
+				problems = append(problems, fmt.Sprintf("%s sees unknown node %s as %s", view, hostID, statuses[hostID]))
+			}
+		}
+	}
+
+	for _, keyspace := range sortedKeys(ring.Keyspaces) {
+		// Replication of the system keyspaces is managed by Cassandra, e.g. system_distributed has a replication
+		// factor of 3 on clusters of any size.
+		if strings.HasPrefix(keyspace, "system") {
+			continue
+		}
+		problems = append(problems, verifyCassandraReplication(keyspace, ring.Keyspaces[keyspace], dataCenters)...)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cassandra ring of %d nodes: %s", nodeCount, strings.Join(problems, "; "))
+}
+
+// ParseCassandraNodetoolStatus returns the gossip status of the nodes listed by `nodetool status` by their host IDs,
+// e.g. "UN" for a node which is up and normal or "DN" for a node which is down.
+func ParseCassandraNodetoolStatus(output string) (map[string]string, error) {
+	statuses := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !cassandraStatusPattern.MatchString(fields[0]) {
+			continue
+		}
+		// The load is printed with its unit, e.g. "1.2 MiB", or as "?", so the host ID is found by its format.
+		hostID := ""
+		for _, field := range fields[1:] {
+			if cassandraHostIDPattern.MatchString(field) {
+				hostID = field
+				break
+			}
+		}
+		if hostID == "" {
+			return nil, fmt.Errorf("no host ID in nodetool status line %q", line)
+		}
+		statuses[hostID] = fields[0]
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("no nodes in nodetool status")
+	}
+	return statuses, nil
+}
+
+// verifyCassandraReplication checks that the replication factors of the keyspace are between 1 and the number of
+// nodes of the data centers. Local and everywhere replication is not checked.
+func verifyCassandraReplication(keyspace string, replication map[string]string, dataCenters map[string]int) []string {
+	var problems []string
+	check := func(dataCenter string, value string, nodes int) {
+		// Transient replication is configured as "<replicas>/<transient replicas>".
+		replicas, err := strconv.Atoi(strings.SplitN(value, "/", 2)[0])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("keyspace %s has invalid replication factor %q", keyspace, value))
+			return
+		}
+		if replicas < 1 || replicas > nodes {
+			problems = append(problems, fmt.Sprintf("keyspace %s has replication factor %d in %s with %d nodes", keyspace, replicas, dataCenter, nodes))
+		}
+	}
+
+	class := replication["class"]
+	switch {
+	case strings.HasSuffix(class, "SimpleStrategy"):
+		nodes := 0
+		for _, n := range dataCenters {
+			nodes += n
+		}
+		check("the cluster", replication["replication_factor"], nodes)
+	case strings.HasSuffix(class, "NetworkTopologyStrategy"):
+		for _, dataCenter := range sortedKeys(replication) {
+			if dataCenter == "class" {
+				continue
+			}
+			check(dataCenter, replication[dataCenter], dataCenters[dataCenter])
+		}
+	}
+	return problems
+}
+
+// Ring connects a separate session to every node, so each node reports itself and its view of the peers.
+func (c *cassandraClient) Ring(ctx context.Context) (CassandraRing, error) {
+	ring := CassandraRing{
+		Peers:     make(map[string][]CassandraNode),
+		Keyspaces: make(map[string]map[string]string),
+	}
+	for _, endpoint := range c.config.Endpoints {
+		local, ok := endpoint.Ports[cassandraPort]
+		if !ok {
+			continue
+		}
+		node, peers, err := c.nodeView(ctx, localAddress(local))
+		if err != nil {
+			return CassandraRing{}, fmt.Errorf("querying node %s: %w", endpoint.Pod, err)
+		}
+		ring.Nodes = append(ring.Nodes, node)
+		ring.Peers[node.HostID] = peers
+	}
+
+	iter := c.session.Query("SELECT keyspace_name, replication FROM system_schema.keyspaces").WithContext(ctx).Iter()
+	var keyspace string
+	var replication map[string]string
+	for iter.Scan(&keyspace, &replication) {
+		ring.Keyspaces[keyspace] = replication
+		replication = nil
+	}
+	if err := iter.Close(); err != nil {
+		return CassandraRing{}, fmt.Errorf("selecting keyspaces: %w", err)
+	}
+	return ring, nil
+}
+
+// nodeView queries system.local and system.peers of the node. The session connects to the node only, so the
+// node itself answers the queries.
+func (c *cassandraClient) nodeView(ctx context.Context, host string) (CassandraNode, []CassandraNode, error) {
+	cluster := newCassandraCluster(c.config, host)
+	cluster.Consistency = gocql.One
+	cluster.DisableInitialHostLookup = true
+	cluster.Events.DisableTopologyEvents = true
+	cluster.Events.DisableNodeStatusEvents = true
+	cluster.Events.DisableSchemaEvents = true
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return CassandraNode{}, nil, err
+	}
+	defer session.Close()
+
+	var (
+		node                  CassandraNode
+		hostID, schemaVersion gocql.UUID
+		address               net.IP
+	)
+	err = session.Query("SELECT host_id, broadcast_address, data_center, rack, bootstrapped, schema_version, tokens FROM system.local").
+		WithContext(ctx).
+		Scan(&hostID, &address, &node.DataCenter, &node.Rack, &node.Bootstrapped, &schemaVersion, &node.Tokens)
+	if err != nil {
+		return CassandraNode{}, nil, fmt.Errorf("selecting system.local: %w", err)
+	}
+	node.HostID, node.Address, node.SchemaVersion = cassandraUUID(hostID), address.String(), cassandraUUID(schemaVersion)
+
+	var peers []CassandraNode
+	var peer CassandraNode
+	iter := session.Query("SELECT host_id, peer, data_center, rack, schema_version, tokens FROM system.peers").WithContext(ctx).Iter()
+	for iter.Scan(&hostID, &address, &peer.DataCenter, &peer.Rack, &schemaVersion, &peer.Tokens) {
+		peer.HostID, peer.Address, peer.SchemaVersion = cassandraUUID(hostID), address.String(), cassandraUUID(schemaVersion)
+		peers = append(peers, peer)
+		peer = CassandraNode{}
+	}
+	if err := iter.Close(); err != nil {
+		return CassandraNode{}, nil, fmt.Errorf("selecting system.peers: %w", err)
+	}
+	return node, peers, nil
+}
+
+// cassandraUUID returns the UUID as string, null UUIDs are empty.
+func cassandraUUID(uuid gocql.UUID) string {
+	if uuid == (gocql.UUID{}) {
+		return ""
+	}
+	return uuid.String()
+}
@@ -0,0 +1,178 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCassandraRing() CassandraRing {
+	nodes := []CassandraNode{
+		{HostID: "a", Address: "10.0.0.1", DataCenter: "dc1", Bootstrapped: "COMPLETED", SchemaVersion: "v1", Tokens: []string{"-9223372036854775808", "0"}},
+		{HostID: "b", Address: "10.0.0.2", DataCenter: "dc1", Bootstrapped: "COMPLETED", SchemaVersion: "v1", Tokens: []string{"-4611686018427387904", "4611686018427387904"}},
+	}
+	peer := func(node CassandraNode) CassandraNode {
+		node.Bootstrapped = ""
+		return node
+	}
+	return CassandraRing{
+		Nodes: nodes,
+		Peers: map[string][]CassandraNode{
+			"a": {peer(nodes[1])},
+			"b": {peer(nodes[0])},
+		},
+		Keyspaces: map[string]map[string]string{
+			"system_auth":     {"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "1"},
+			"pds_integration": {"class": "org.apache.cassandra.locator.NetworkTopologyStrategy", "dc1": "2"},
+			"system":          {"class": "org.apache.cassandra.locator.LocalStrategy"},
+		},
+		Gossip: map[string]map[string]string{
+			"cas-0": {"a": "UN", "b": "UN"},
+			"cas-1": {"a": "UN", "b": "UN"},
+		},
+	}
+}
+
+func TestCassandraOwnership(t *testing.T) {
+	ownership, err := CassandraOwnership(newCassandraRing().Nodes)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, ownership["a"], 1e-9)
+	assert.InDelta(t, 0.5, ownership["b"], 1e-9)
+
+	ownership, err = CassandraOwnership([]CassandraNode{
+		{HostID: "a", Tokens: []string{"0"}},
+		{HostID: "b", Tokens: []string{"4611686018427387904"}},
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.75, ownership["a"], 1e-9, "The first token owns the wrapped range.")
+	assert.InDelta(t, 0.25, ownership["b"], 1e-9)
+
+	_, err = CassandraOwnership([]CassandraNode{{HostID: "a", Tokens: []string{"85070591730234615865843651857942052864"}}})
+	assert.Error(t, err)
+}
+
+func TestVerifyCassandraRingSystemKeyspaces(t *testing.T) {
+	ring := newCassandraRing()
+	// Default replication of the system keyspaces of Cassandra 4.0.
+	for keyspace, replication := range map[string]map[string]string{
+		"system_auth":        {"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "1"},
+		"system_distributed": {"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "3"},
+		"system_traces":      {"class": "org.apache.cassandra.locator.SimpleStrategy", "replication_factor": "2"},
+		"system_schema":      {"class": "org.apache.cassandra.locator.LocalStrategy"},
+		"system_views":       {"class": "org.apache.cassandra.locator.LocalStrategy"},
+	} {
+		ring.Keyspaces[keyspace] = replication
+	}
+	assert.NoError(t, VerifyCassandraRing(ring, 2))
+
+	ring.Nodes = ring.Nodes[:1]
+	ring.Peers = map[string][]CassandraNode{}
+	ring.Gossip = map[string]map[string]string{"cas-0": {"a": "UN"}}
+	ring.Nodes[0].Tokens = []string{"0"}
+	ring.Keyspaces["pds_integration"]["dc1"] = "1"
+	assert.NoError(t, VerifyCassandraRing(ring, 1), "Replication factors of system keyspaces exceed a single node.")
+}
+
+func TestVerifyCassandraRing(t *testing.T) {
+	assert.NoError(t, VerifyCassandraRing(newCassandraRing(), 2))
+
+	for name, tc := range map[string]struct {
+		modify    func(ring *CassandraRing)
+		nodeCount int
+		problem   string
+	}{
+		"node count": {
+			nodeCount: 3,
+			problem:   "2 nodes, expected 3",
+		},
+		"joining": {
+			modify:  func(ring *CassandraRing) { ring.Nodes[1].Bootstrapped = "IN_PROGRESS" },
+			problem: `node b (10.0.0.2) did not complete bootstrapping, bootstrap state "IN_PROGRESS"`,
+		},
+		"missing peer": {
+			modify:  func(ring *CassandraRing) { ring.Peers["a"] = nil },
+			problem: "node 10.0.0.1 doesn't see peer 10.0.0.2",
+		},
+		"unknown peer": {
+			modify: func(ring *CassandraRing) {
+				ring.Peers["a"] = append(ring.Peers["a"], CassandraNode{HostID: "c", Address: "10.0.0.3"})
+			},
+			problem: "node 10.0.0.1 sees unknown peer c (10.0.0.3)",
+		},
+		"schema": {
+			modify:  func(ring *CassandraRing) { ring.Nodes[1].SchemaVersion = "v2" },
+			problem: "no schema agreement: v1 on 10.0.0.1, v2 on 10.0.0.2",
+		},
+		"ownership": {
+			modify:  func(ring *CassandraRing) { ring.Nodes[1].Tokens = []string{"-9223372036854775807"} },
+			problem: "node b (10.0.0.2) owns 0.0% of the tokens, expected about 50.0%",
+		},
+		"down": {
+			modify:  func(ring *CassandraRing) { ring.Gossip["cas-0"]["b"] = "DN" },
+			problem: "cas-0 sees node b (10.0.0.2) as DN",
+		},
+		"leaving": {
+			modify:  func(ring *CassandraRing) { ring.Gossip["cas-1"]["b"] = "UL" },
+			problem: "cas-1 sees node b (10.0.0.2) as UL",
+		},
+		"missing gossip": {
+			modify:  func(ring *CassandraRing) { delete(ring.Gossip["cas-1"], "a") },
+			problem: "cas-1 doesn't gossip with node a (10.0.0.1)",
+		},
+		"unknown gossip": {
+			modify:  func(ring *CassandraRing) { ring.Gossip["cas-0"]["c"] = "DN" },
+			problem: "cas-0 sees unknown node c as DN",
+		},
+		"no gossip": {
+			modify:  func(ring *CassandraRing) { ring.Gossip = nil },
+			problem: "no gossip status",
+		},
+		"replication": {
+			modify:  func(ring *CassandraRing) { ring.Keyspaces["pds_integration"]["dc1"] = "3" },
+			problem: "keyspace pds_integration has replication factor 3 in dc1 with 2 nodes",
+		},
+		"unknown data center": {
+			modify:  func(ring *CassandraRing) { ring.Keyspaces["pds_integration"]["dc2"] = "1" },
+			problem: "keyspace pds_integration has replication factor 1 in dc2 with 0 nodes",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ring := newCassandraRing()
+			if tc.modify != nil {
+				tc.modify(&ring)
+			}
+			nodeCount := tc.nodeCount
+			if nodeCount == 0 {
+				nodeCount = 2
+			}
+			err := VerifyCassandraRing(ring, nodeCount)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.problem)
+		})
+	}
+}
+
+func TestParseCassandraNodetoolStatus(t *testing.T) {
+	output := `Datacenter: dc1
+===============
+Status=Up/Down
+|/ State=Normal/Leaving/Joining/Moving
+--  Address     Load        Tokens  Owns (effective)  Host ID                               Rack
+UN  10.0.0.1    1.21 MiB    16      100.0%            5f2c7a3e-3b5d-4c8e-9a41-0d6c2f1e7b90  rack1
+DN  10.0.0.2    ?           16      100.0%            9b1e4d62-7c0a-4f3b-8e25-6a9d3c1f0e47  rack1
+UJ  10.0.0.3    96.3 KiB    16      ?                 0c8f2b15-e6d4-4a79-b3c1-2f5e8d7a9c60  rack1
+`
+	statuses, err := ParseCassandraNodetoolStatus(output)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"5f2c7a3e-3b5d-4c8e-9a41-0d6c2f1e7b90": "UN",
+		"9b1e4d62-7c0a-4f3b-8e25-6a9d3c1f0e47": "DN",
+		"0c8f2b15-e6d4-4a79-b3c1-2f5e8d7a9c60": "UJ",
+	}, statuses)
+
+	_, err = ParseCassandraNodetoolStatus("nodetool: Failed to connect to '127.0.0.1:7199'")
+	assert.Error(t, err)
+	_, err = ParseCassandraNodetoolStatus("UN  10.0.0.1  1.21 MiB  16  100.0%  rack1")
+	assert.Error(t, err, "Status line without host ID.")
+}
@@ -0,0 +1,89 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	consulPort      = 8500
+	consulKeyPrefix = "pds-integration/"
+)
+
+type consulClient struct {
+	http *httpClient
+}
+
+// newConsulClient connects to the KV store of Consul. The password is used as ACL token.
+func newConsulClient(ctx context.Context, config Config) (Client, error) {
+	client := newHTTPClient(config, consulPort)
+	if config.Password != "" {
+		client.header = http.Header{"X-Consul-Token": []string{config.Password}}
+	}
+	if err := client.do(ctx, http.MethodGet, "/v1/status/leader", "", nil, nil); err != nil {
+		return nil, fmt.Errorf("connecting to Consul: %w", err)
+	}
+	return &consulClient{http: client}, nil
+}
+
+func (c *consulClient) Write(ctx context.Context, dataset Dataset) error {
+	prefix, err := consulPrefix(dataset)
+	if err != nil {
+		return err
+	}
+	records := dataset.Records()
+	for _, key := range sortedKeys(records) {
+		var ok bool
+		if err := c.http.do(ctx, http.MethodPut, "/v1/kv/"+prefix+key, "", []byte(records[key]), &ok); err != nil {
+			return fmt.Errorf("putting key %s: %w", prefix+key, err)
+		}
+		if !ok {
+			return fmt.Errorf("putting key %s: not stored", prefix+key)
+		}
+	}
+	return nil
+}
+
+func (c *consulClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	prefix, err := consulPrefix(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	var pairs []struct {
+		Key   string
+		Value []byte
+	}
+	err = c.http.do(ctx, http.MethodGet, "/v1/kv/"+prefix+"?recurse=true", "", nil, &pairs)
+	if isHTTPStatus(err, http.StatusNotFound) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing keys %s: %w", prefix, err)
+	}
+
+	records := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		records[strings.TrimPrefix(pair.Key, prefix)] = string(pair.Value)
+	}
+	return records, nil
+}
+
+func (c *consulClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *consulClient) Close() error {
+	c.http.client.CloseIdleConnections()
+	return nil
+}
+
+func consulPrefix(dataset Dataset) (string, error) {
+	name, err := dataset.name()
+	if err != nil {
+		return "", err
+	}
+	return consulKeyPrefix + name + "/", nil
+}
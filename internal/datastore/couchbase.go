@@ -0,0 +1,147 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	couchbaseAdminPort = 8091
+	couchbaseQueryPort = 8093
+	couchbaseBucket    = "pds"
+	// couchbaseBucketRAMQuota is the RAM quota of the bucket in MiB, the minimum accepted by Couchbase.
+	couchbaseBucketRAMQuota = 100
+	couchbaseBucketTimeout  = 2 * time.Minute
+)
+
+type couchbaseClient struct {
+	admin *httpClient
+	query *httpClient
+}
+
+// newCouchbaseClient creates the bucket used for all datasets, if missing, and waits until it can be queried.
+func newCouchbaseClient(ctx context.Context, config Config) (Client, error) {
+	c := &couchbaseClient{
+		admin: newHTTPClient(config, couchbaseAdminPort),
+		query: newHTTPClient(config, couchbaseQueryPort),
+	}
+	for _, client := range []*httpClient{c.admin, c.query} {
+		client.username = config.Username
+		client.password = config.Password
+	}
+
+	form := url.Values{
+		"name":       {couchbaseBucket},
+		"bucketType": {"couchbase"},
+		"ramQuotaMB": {fmt.Sprint(couchbaseBucketRAMQuota)},
+	}
+	err := c.admin.do(ctx, http.MethodPost, "/pools/default/buckets", "application/x-www-form-urlencoded", []byte(form.Encode()), nil)
+	if err != nil && !(isHTTPStatus(err, http.StatusBadRequest) && strings.Contains(err.Error(), "already exists")) {
+		return nil, fmt.Errorf("creating Couchbase bucket %s: %w", couchbaseBucket, err)
+	}
+
+	// The bucket cannot be queried until it is warmed up on all nodes.
+	deadline := time.Now().Add(couchbaseBucketTimeout)
+	for {
+		_, err := c.statement(ctx, "SELECT RAW META(d).id FROM `"+couchbaseBucket+"` d USE KEYS $1", []string{})
+		if err == nil {
+			return c, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("waiting for Couchbase bucket %s: %w", couchbaseBucket, err)
+		}
+		select {
+		case <-time.After(5 * time.Second):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Write upserts a document per record, the document keys are prefixed with the dataset name.
+func (c *couchbaseClient) Write(ctx context.Context, dataset Dataset) error {
+	prefix, err := couchbasePrefix(dataset)
+	if err != nil {
+		return err
+	}
+	records := dataset.Records()
+	for _, key := range sortedKeys(records) {
+		statement := "UPSERT INTO `" + couchbaseBucket + "` (KEY, VALUE) VALUES ($1, {\"value\": $2})"
+		if _, err := c.statement(ctx, statement, prefix+key, records[key]); err != nil {
+			return fmt.Errorf("upserting document %s: %w", prefix+key, err)
+		}
+	}
+	return nil
+}
+
+func (c *couchbaseClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	prefix, err := couchbasePrefix(dataset)
+	if err != nil {
+		return nil, err
+	}
+	records := make(map[string]string, dataset.Size)
+	statement := "SELECT META(d).id AS id, d.`value` AS `value` FROM `" + couchbaseBucket + "` d USE KEYS $1"
+	for _, keys := range batches(sortedKeys(dataset.Records())) {
+		documentKeys := make([]string, 0, len(keys))
+		for _, key := range keys {
+			documentKeys = append(documentKeys, prefix+key)
+		}
+		rows, err := c.statement(ctx, statement, documentKeys)
+		if err != nil {
+			return nil, fmt.Errorf("selecting documents of dataset %s: %w", dataset.Seed, err)
+		}
+		for _, row := range rows {
+			id, _ := row["id"].(string)
+			value, _ := row["value"].(string)
+			records[strings.TrimPrefix(id, prefix)] = value
+		}
+	}
+	return records, nil
+}
+
+func (c *couchbaseClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *couchbaseClient) Close() error {
+	c.admin.client.CloseIdleConnections()
+	c.query.client.CloseIdleConnections()
+	return nil
+}
+
+// statement runs a N1QL statement with positional arguments and returns the result rows.
+func (c *couchbaseClient) statement(ctx context.Context, statement string, args ...interface{}) ([]map[string]interface{}, error) {
+	request := map[string]interface{}{
+		"statement":        statement,
+		"args":             args,
+		"scan_consistency": "request_plus",
+	}
+	var resp struct {
+		Status  string                   `json:"status"`
+		Results []map[string]interface{} `json:"results"`
+		Errors  []struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		} `json:"errors"`
+	}
+	err := c.query.doJSON(ctx, http.MethodPost, "/query/service", request, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("query status %s: %v", resp.Status, resp.Errors)
+	}
+	return resp.Results, nil
+}
+
+func couchbasePrefix(dataset Dataset) (string, error) {
+	name, err := dataset.name()
+	if err != nil {
+		return "", err
+	}
+	return name + ":", nil
+}
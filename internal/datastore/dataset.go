@@ -0,0 +1,204 @@
+package datastore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultDatasetSize is the number of records of a dataset if no size is given.
+const DefaultDatasetSize = 100
+
+// Dataset is a deterministic set of records derived from a seed. Keys are numbered, values are derived from
+// the seed and the key, so the records and their digest can be recomputed from the seed and the size alone.
+type Dataset struct {
+	Seed string
+	// Size is the number of records.
+	Size int
+	// Generation of the values. Generations of a dataset are stored under the same name, writing a generation
+	// overwrites the values of the previous one.
+	Generation int
+}
+
+// NewDataset returns the dataset of the seed with the given number of records, or DefaultDatasetSize records
+// if size is not positive.
+func NewDataset(seed string, size int) Dataset {
+	if size <= 0 {
+		size = DefaultDatasetSize
+	}
+	return Dataset{Seed: seed, Size: size}
+}
+
+func (d Dataset) String() string {
+	if d.Generation > 0 {
+		return fmt.Sprintf("%s (%d records, generation %d)", d.Seed, d.Size, d.Generation)
+	}
+	return fmt.Sprintf("%s (%d records)", d.Seed, d.Size)
+}
+
+// Records returns the records of the dataset by their keys.
+func (d Dataset) Records() map[string]string {
+	seed := d.Seed
+	if d.Generation > 0 {
+		seed = fmt.Sprintf("%s/%d", d.Seed, d.Generation)
+	}
+	records := make(map[string]string, d.Size)
+	for i := 0; i < d.Size; i++ {
+		key := fmt.Sprintf("record-%06d", i)
+		sum := sha256.Sum256([]byte(seed + "/" + key))
+		records[key] = hex.EncodeToString(sum[:])
+	}
+	return records
+}
+
+// Digest returns the digest of all records of the dataset, see Digest.
+func (d Dataset) Digest() string {
+	return Digest(d.Records())
+}
+
+// name returns the seed usable as a table, topic, index or queue name.
+func (d Dataset) name() (string, error) {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(d.Seed) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("seed %q has no alphanumeric characters", d.Seed)
+	}
+	return "pds" + sb.String(), nil
+}
+
+// Digest returns a SHA-256 digest of the records in key order. It identifies the content of a dataset independent
+// of the data service it is stored in.
+func Digest(records map[string]string) string {
+	h := sha256.New()
+	for _, key := range sortedKeys(records) {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(key), key, len(records[key]), records[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Diff lists the keys of the records which differ from the dataset.
+type Diff struct {
+	// Missing keys are not stored.
+	Missing []string
+	// Corrupted keys are stored with a different value.
+	Corrupted []string
+	// Unexpected keys are stored but are not part of the dataset.
+	Unexpected []string
+}
+
+// Compare compares the records, e.g. read from a restored deployment, with the records of the dataset.
+func Compare(dataset Dataset, records map[string]string) Diff {
+	return CompareGenerations(dataset, dataset.Generation, records)
+}
+
+// CompareGenerations compares the records with the generations of the dataset from its generation up to the latest
+// one. A record matches if it has the value of any of these generations, e.g. if writes of later generations failed
+// after some of the records were written.
+func CompareGenerations(dataset Dataset, latest int, records map[string]string) Diff {
+	expected := make(map[string]map[string]bool, dataset.Size)
+	for generation := dataset.Generation; generation <= latest; generation++ {
+		dataset := dataset
+		dataset.Generation = generation
+		for key, value := range dataset.Records() {
+			if expected[key] == nil {
+				expected[key] = make(map[string]bool)
+			}
+			expected[key][value] = true
+		}
+	}
+
+	var diff Diff
+	for _, key := range sortedKeys(expected) {
+		value, ok := records[key]
+		switch {
+		case !ok:
+			diff.Missing = append(diff.Missing, key)
+		case !expected[key][value]:
+			diff.Corrupted = append(diff.Corrupted, key)
+		}
+	}
+	for _, key := range sortedKeys(records) {
+		if _, ok := expected[key]; !ok {
+			diff.Unexpected = append(diff.Unexpected, key)
+		}
+	}
+	return diff
+}
+
+// Empty returns true if the records match the dataset.
+func (d Diff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Corrupted) == 0 && len(d.Unexpected) == 0
+}
+
+func (d Diff) String() string {
+	return fmt.Sprintf("%d missing %s, %d corrupted %s, %d unexpected %s",
+		len(d.Missing), formatKeys(d.Missing), len(d.Corrupted), formatKeys(d.Corrupted), len(d.Unexpected), formatKeys(d.Unexpected))
+}
+
+// maxReportedKeys limits the keys listed in reports of large datasets.
+const maxReportedKeys = 20
+
+func formatKeys(keys []string) string {
+	if len(keys) > maxReportedKeys {
+		return fmt.Sprintf("[%s ... and %d more]", strings.Join(keys[:maxReportedKeys], " "), len(keys)-maxReportedKeys)
+	}
+	return fmt.Sprintf("%v", keys)
+}
+
+// VerifyError is returned by Client.Verify if the stored records differ from the dataset.
+type VerifyError struct {
+	Dataset Dataset
+	// Digest is the digest of the stored records, the dataset has the digest Dataset.Digest().
+	Digest string
+	Diff   Diff
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("dataset %s: digest %s, expected %s: %s", e.Dataset, e.Digest, e.Dataset.Digest(), e.Diff)
+}
+
+// verify compares the records read by the client with the records of the dataset.
+func verify(ctx context.Context, client Client, dataset Dataset) error {
+	records, err := client.Read(ctx, dataset)
+	if err != nil {
+		return fmt.Errorf("reading records of dataset %s: %w", dataset, err)
+	}
+	if diff := Compare(dataset, records); !diff.Empty() {
+		return &VerifyError{Dataset: dataset, Digest: Digest(records), Diff: diff}
+	}
+	return nil
+}
+
+// batchSize is the number of records written or read by a single request, so that requests for large datasets stay
+// below the request size limits of the data services.
+const batchSize = 1000
+
+// batches splits the keys into batches of batchSize keys.
+func batches(keys []string) [][]string {
+	var result [][]string
+	for len(keys) > batchSize {
+		result = append(result, keys[:batchSize])
+		keys = keys[batchSize:]
+	}
+	if len(keys) > 0 {
+		result = append(result, keys)
+	}
+	return result
+}
+
+// sortedKeys returns the keys of the map in order, e.g. so that records are written in a stable order.
+func sortedKeys[V any](records map[string]V) []string {
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,136 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	records map[string]string
+}
+
+func (c *fakeClient) Write(_ context.Context, dataset Dataset) error {
+	c.records = dataset.Records()
+	return nil
+}
+
+func (c *fakeClient) Read(_ context.Context, _ Dataset) (map[string]string, error) {
+	return c.records, nil
+}
+
+func (c *fakeClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *fakeClient) Close() error {
+	return nil
+}
+
+func TestDataset(t *testing.T) {
+	dataset := NewDataset("seed-1", 0)
+	assert.Equal(t, Dataset{Seed: "seed-1", Size: DefaultDatasetSize}, dataset)
+
+	records := dataset.Records()
+	assert.Len(t, records, DefaultDatasetSize)
+	assert.Equal(t, records, NewDataset("seed-1", 0).Records())
+	assert.NotEqual(t, records["record-000000"], NewDataset("seed-2", 0).Records()["record-000000"])
+	assert.Len(t, records["record-000099"], 64)
+	assert.Len(t, NewDataset("seed-1", 2500).Records(), 2500)
+
+	assert.Equal(t, dataset.Digest(), Digest(records))
+	assert.NotEqual(t, dataset.Digest(), NewDataset("seed-1", 101).Digest())
+	assert.NotEqual(t, dataset.Digest(), NewDataset("seed-2", 0).Digest())
+}
+
+func TestDigest(t *testing.T) {
+	// Key and value boundaries are part of the digest.
+	assert.NotEqual(t, Digest(map[string]string{"a": "bc"}), Digest(map[string]string{"ab": "c"}))
+	assert.Equal(t, Digest(map[string]string{"a": "1", "b": "2"}), Digest(map[string]string{"b": "2", "a": "1"}))
+}
+
+func TestCompare(t *testing.T) {
+	dataset := NewDataset("seed-1", 5)
+	records := dataset.Records()
+	assert.True(t, Compare(dataset, records).Empty())
+
+	delete(records, "record-000001")
+	records["record-000002"] = "changed"
+	records["other"] = "value"
+	diff := Compare(dataset, records)
+	assert.Equal(t, Diff{
+		Missing:    []string{"record-000001"},
+		Corrupted:  []string{"record-000002"},
+		Unexpected: []string{"other"},
+	}, diff)
+	assert.Equal(t, "1 missing [record-000001], 1 corrupted [record-000002], 1 unexpected [other]", diff.String())
+}
+
+func TestCompareGenerations(t *testing.T) {
+	dataset := NewDataset("seed-1", 3)
+	next := dataset
+	next.Generation = 1
+	assert.NotEqual(t, dataset.Records()["record-000000"], next.Records()["record-000000"])
+	assert.Equal(t, "seed-1 (3 records, generation 1)", next.String())
+
+	// A failed write of generation 2 stored only some of its records.
+	latest := dataset
+	latest.Generation = 2
+	records := next.Records()
+	records["record-000000"] = latest.Records()["record-000000"]
+	assert.True(t, CompareGenerations(next, 2, records).Empty())
+	assert.Equal(t, []string{"record-000000"}, Compare(next, records).Corrupted)
+
+	// Records of generations before the acknowledged one are lost writes.
+	records["record-000001"] = dataset.Records()["record-000001"]
+	assert.Equal(t, []string{"record-000001"}, CompareGenerations(next, 2, records).Corrupted)
+}
+
+func TestDiff_StringLimitsKeys(t *testing.T) {
+	var diff Diff
+	for i := 0; i < maxReportedKeys+5; i++ {
+		diff.Missing = append(diff.Missing, fmt.Sprint(i))
+	}
+	assert.Contains(t, diff.String(), "25 missing [0 1 2")
+	assert.Contains(t, diff.String(), "... and 5 more]")
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	dataset := NewDataset("seed-1", 0)
+	client := &fakeClient{}
+	require.NoError(t, client.Write(ctx, dataset))
+	assert.NoError(t, client.Verify(ctx, dataset))
+
+	delete(client.records, "record-000001")
+	err := client.Verify(ctx, dataset)
+	var verifyErr *VerifyError
+	require.True(t, errors.As(err, &verifyErr))
+	assert.Equal(t, []string{"record-000001"}, verifyErr.Diff.Missing)
+	assert.Equal(t, Digest(client.records), verifyErr.Digest)
+	assert.Contains(t, err.Error(), "dataset seed-1 (100 records)")
+
+	assert.Error(t, client.Verify(ctx, NewDataset("seed-2", 0)))
+}
+
+func TestDatasetName(t *testing.T) {
+	name, err := Dataset{Seed: "Backup-Seed_1"}.name()
+	require.NoError(t, err)
+	assert.Equal(t, "pdsbackupseed1", name)
+
+	_, err = Dataset{Seed: "--"}.name()
+	assert.Error(t, err)
+}
+
+func TestBatches(t *testing.T) {
+	keys := make([]string, 2*batchSize+1)
+	result := batches(keys)
+	require.Len(t, result, 3)
+	assert.Len(t, result[0], batchSize)
+	assert.Len(t, result[2], 1)
+	assert.Empty(t, batches(nil))
+}
@@ -0,0 +1,126 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+)
+
+const dialTimeout = 30 * time.Second
+
+// Client writes and reads back a dataset derived from a seed. The dataset can be verified e.g. after a restore
+// or a failover without keeping the written records around.
+type Client interface {
+	// Write writes all records of the dataset. Writing the same dataset again overwrites the records.
+	Write(ctx context.Context, dataset Dataset) error
+	// Read returns the records of the dataset stored in the data service by their keys.
+	Read(ctx context.Context, dataset Dataset) (map[string]string, error)
+	// Verify reads the records of the dataset and checks that all of them are stored with the written values,
+	// see Compare.
+	Verify(ctx context.Context, dataset Dataset) error
+	// Close closes all connections to the data service.
+	Close() error
+}
+
+// Endpoint is a pod of the data service which is reachable through port-forwards.
+type Endpoint struct {
+	// Pod and IP are the name and the IP address of the pod. They map addresses announced by the data service,
+	// e.g. in cluster topologies, to the local addresses of the tunnels.
+	Pod string
+	IP  string
+	// Ports maps the ports of the pod to the local ports of the tunnels.
+	Ports map[int]int
+}
+
+// Config is the connection configuration of a client.
+type Config struct {
+	Endpoints []Endpoint
+	Username  string
+	Password  string
+}
+
+type dataService struct {
+	ports     []int
+	newClient func(ctx context.Context, config Config) (Client, error)
+}
+
+var dataServices = map[string]dataService{
+	dataservices.Cassandra:     {ports: []int{cassandraPort}, newClient: newCassandraClient},
+	dataservices.Consul:        {ports: []int{consulPort}, newClient: newConsulClient},
+	dataservices.Couchbase:     {ports: []int{couchbaseAdminPort, couchbaseQueryPort}, newClient: newCouchbaseClient},
+	dataservices.ElasticSearch: {ports: []int{elasticsearchPort}, newClient: newElasticsearchClient},
+	dataservices.Kafka:         {ports: []int{kafkaPort}, newClient: newKafkaClient},
+	dataservices.MongoDB:       {ports: []int{mongoDBPort}, newClient: newMongoDBClient},
+	dataservices.MySQL:         {ports: []int{mysqlPort}, newClient: newMySQLClient},
+	dataservices.Postgres:      {ports: []int{postgresPort}, newClient: newPostgresClient},
+	dataservices.RabbitMQ:      {ports: []int{rabbitMQPort}, newClient: newRabbitMQClient},
+	dataservices.Redis:         {ports: []int{redisPort}, newClient: newRedisClient},
+	dataservices.SqlServer:     {ports: []int{sqlServerPort}, newClient: newSQLServerClient},
+	dataservices.ZooKeeper:     {ports: []int{zooKeeperPort}, newClient: newZooKeeperClient},
+}
+
+// Ports returns the pod ports which have to be port-forwarded for a client of the data service.
+func Ports(dataServiceType string) ([]int, bool) {
+	ds, ok := dataServices[dataServiceType]
+	return ds.ports, ok
+}
+
+// New connects a client of the data service.
+func New(ctx context.Context, dataServiceType string, config Config) (Client, error) {
+	ds, ok := dataServices[dataServiceType]
+	if !ok {
+		return nil, fmt.Errorf("no client for data service %s", dataServiceType)
+	}
+	if len(config.Endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints of data service %s", dataServiceType)
+	}
+	return ds.newClient(ctx, config)
+}
+
+// localAddresses returns the local addresses of the port of all endpoints.
+func (c Config) localAddresses(port int) []string {
+	addresses := make([]string, 0, len(c.Endpoints))
+	for _, endpoint := range c.Endpoints {
+		if local, ok := endpoint.Ports[port]; ok {
+			addresses = append(addresses, localAddress(local))
+		}
+	}
+	return addresses
+}
+
+// resolve maps an address of a pod, by pod IP, pod name or pod hostname, to the local address of the tunnel.
+// Other addresses, e.g. local addresses, are returned unchanged.
+func (c Config) resolve(address string) string {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return address
+	}
+	for _, endpoint := range c.Endpoints {
+		if host != endpoint.IP && host != endpoint.Pod && !strings.HasPrefix(host, endpoint.Pod+".") {
+			continue
+		}
+		if local, ok := endpoint.Ports[port]; ok {
+			return localAddress(local)
+		}
+	}
+	return address
+}
+
+// dial connects to the address through the tunnel of the pod, see resolve.
+func (c Config) dial(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: dialTimeout}
+	return dialer.DialContext(ctx, network, c.resolve(address))
+}
+
+func localAddress(port int) string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+}
@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+)
+
+func TestConfigResolve(t *testing.T) {
+	config := Config{Endpoints: []Endpoint{
+		{Pod: "kf-0", IP: "10.0.0.1", Ports: map[int]int{9092: 40001}},
+		{Pod: "kf-1", IP: "10.0.0.2", Ports: map[int]int{9092: 40002}},
+	}}
+	assert.Equal(t, []string{"127.0.0.1:40001", "127.0.0.1:40002"}, config.localAddresses(9092))
+	assert.Equal(t, "127.0.0.1:40001", config.resolve("10.0.0.1:9092"))
+	assert.Equal(t, "127.0.0.1:40002", config.resolve("kf-1:9092"))
+	assert.Equal(t, "127.0.0.1:40002", config.resolve("kf-1.kf-headless.ns.svc.cluster.local:9092"))
+	assert.Equal(t, "kf-10:9092", config.resolve("kf-10:9092"))
+	assert.Equal(t, "10.0.0.1:9093", config.resolve("10.0.0.1:9093"))
+}
+
+func TestNew_UnknownDataService(t *testing.T) {
+	_, err := New(context.Background(), "Unknown", Config{Endpoints: []Endpoint{{Pod: "pod-0"}}})
+	assert.Error(t, err)
+
+	_, ok := Ports(dataservices.Postgres)
+	assert.True(t, ok)
+	_, ok = Ports("Unknown")
+	assert.False(t, ok)
+}
+
+func TestConsulClient(t *testing.T) {
+	kv := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Consul-Token"))
+		switch {
+		case r.URL.Path == "/v1/status/leader":
+			_, _ = w.Write([]byte(`"10.0.0.1:8300"`))
+		case r.Method == http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			kv[strings.TrimPrefix(r.URL.Path, "/v1/kv/")] = string(body)
+			_, _ = w.Write([]byte("true"))
+		case r.Method == http.MethodGet:
+			prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+			var pairs []string
+			for key, value := range kv {
+				if strings.HasPrefix(key, prefix) {
+					pairs = append(pairs, `{"Key":"`+key+`","Value":"`+base64.StdEncoding.EncodeToString([]byte(value))+`"}`)
+				}
+			}
+			if len(pairs) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte("[" + strings.Join(pairs, ",") + "]"))
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(t, err)
+	config := Config{
+		Endpoints: []Endpoint{{Pod: "consul-0", Ports: map[int]int{consulPort: port}}},
+		Password:  "secret",
+	}
+
+	ctx := context.Background()
+	client, err := New(ctx, dataservices.Consul, config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	records, err := client.Read(ctx, NewDataset("seed-1", 0))
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	dataset := NewDataset("seed-1", 0)
+	require.NoError(t, client.Write(ctx, dataset))
+	assert.NoError(t, client.Verify(ctx, dataset))
+	assert.Error(t, client.Verify(ctx, NewDataset("seed-2", 0)))
+	assert.Contains(t, kv, "pds-integration/pdsseed1/record-000000")
+}
@@ -0,0 +1,129 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const elasticsearchPort = 9200
+
+type elasticsearchClient struct {
+	http *httpClient
+}
+
+func newElasticsearchClient(ctx context.Context, config Config) (Client, error) {
+	client := newHTTPClient(config, elasticsearchPort)
+	client.username = config.Username
+	client.password = config.Password
+	if err := client.do(ctx, http.MethodGet, "/", "", nil, nil); err != nil {
+		return nil, fmt.Errorf("connecting to Elasticsearch: %w", err)
+	}
+	return &elasticsearchClient{http: client}, nil
+}
+
+// Write indexes a document per record in the dataset index, using the keys as document IDs.
+func (c *elasticsearchClient) Write(ctx context.Context, dataset Dataset) error {
+	index, err := dataset.name()
+	if err != nil {
+		return err
+	}
+	mappings := map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"key":   map[string]string{"type": "keyword"},
+				"value": map[string]interface{}{"type": "keyword", "index": false},
+			},
+		},
+	}
+	err = c.http.doJSON(ctx, http.MethodPut, "/"+index, mappings, nil)
+	if err != nil && !(isHTTPStatus(err, http.StatusBadRequest) && strings.Contains(err.Error(), "resource_already_exists_exception")) {
+		return fmt.Errorf("creating index %s: %w", index, err)
+	}
+
+	records := dataset.Records()
+	for _, keys := range batches(sortedKeys(records)) {
+		var body bytes.Buffer
+		encoder := json.NewEncoder(&body)
+		for _, key := range keys {
+			if err := encoder.Encode(map[string]interface{}{"index": map[string]string{"_id": key}}); err != nil {
+				return err
+			}
+			if err := encoder.Encode(map[string]string{"key": key, "value": records[key]}); err != nil {
+				return err
+			}
+		}
+
+		var resp struct {
+			Errors bool `json:"errors"`
+		}
+		err = c.http.do(ctx, http.MethodPost, "/"+index+"/_bulk?refresh=wait_for", "application/x-ndjson", body.Bytes(), &resp)
+		if err != nil {
+			return fmt.Errorf("indexing records into %s: %w", index, err)
+		}
+		if resp.Errors {
+			return fmt.Errorf("indexing records into %s: bulk request has failed items", index)
+		}
+	}
+	return nil
+}
+
+// Read pages through all documents of the dataset index sorted by key.
+func (c *elasticsearchClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	index, err := dataset.name()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]string, dataset.Size)
+	var searchAfter []interface{}
+	for {
+		var resp struct {
+			Hits struct {
+				Hits []struct {
+					ID     string `json:"_id"`
+					Source struct {
+						Value string `json:"value"`
+					} `json:"_source"`
+					Sort []interface{} `json:"sort"`
+				} `json:"hits"`
+			} `json:"hits"`
+		}
+		query := map[string]interface{}{
+			"size":  batchSize,
+			"query": map[string]interface{}{"match_all": map[string]interface{}{}},
+			"sort":  []interface{}{map[string]string{"key": "asc"}},
+		}
+		if searchAfter != nil {
+			query["search_after"] = searchAfter
+		}
+		err = c.http.doJSON(ctx, http.MethodPost, "/"+index+"/_search", query, &resp)
+		if isHTTPStatus(err, http.StatusNotFound) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("searching index %s: %w", index, err)
+		}
+
+		hits := resp.Hits.Hits
+		for _, hit := range hits {
+			records[hit.ID] = hit.Source.Value
+		}
+		if len(hits) < batchSize {
+			return records, nil
+		}
+		searchAfter = hits[len(hits)-1].Sort
+	}
+}
+
+func (c *elasticsearchClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *elasticsearchClient) Close() error {
+	c.http.client.CloseIdleConnections()
+	return nil
+}
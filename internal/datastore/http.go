@@ -0,0 +1,96 @@
+package datastore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// httpClient is a minimal JSON client of the HTTP APIs of Elasticsearch, Couchbase and Consul.
+type httpClient struct {
+	client   *http.Client
+	baseURL  string
+	username string
+	password string
+	header   http.Header
+}
+
+// httpError is returned for responses with a non-2xx status code.
+type httpError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("%s %s: status %d: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+func newHTTPClient(config Config, port int) *httpClient {
+	return &httpClient{
+		client: &http.Client{
+			Timeout:   dialTimeout,
+			Transport: &http.Transport{DialContext: config.dial},
+		},
+		baseURL: "http://" + config.localAddresses(port)[0],
+	}
+}
+
+// do sends the request and decodes the JSON response into out, if not nil.
+func (c *httpClient) do(ctx context.Context, method, path, contentType string, body []byte, out interface{}) error {
+	url := c.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response of %s %s: %w", method, url, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &httpError{Method: method, URL: url, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(respBody))}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("decoding response of %s %s: %w", method, url, err)
+	}
+	return nil
+}
+
+// doJSON sends the value encoded as JSON, see do.
+func (c *httpClient) doJSON(ctx context.Context, method, path string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, method, path, "application/json", body, out)
+}
+
+// isHTTPStatus returns true if err is an httpError with the status code.
+func isHTTPStatus(err error, statusCode int) bool {
+	httpErr, ok := err.(*httpError)
+	return ok && httpErr.StatusCode == statusCode
+}
@@ -0,0 +1,120 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+const (
+	kafkaPort     = 9092
+	kafkaClientID = "pds-integration-test"
+)
+
+// kafkaClient produces and consumes records with franz-go. Records of a dataset are produced to a topic with a single
+// partition. Topics cannot be truncated, so writing a dataset again appends the records and the latest value of each
+// key is read.
+type kafkaClient struct {
+	// opts are the options of the client, consumers of partitions are separate clients with the same options.
+	opts   []kgo.Opt
+	client *kgo.Client
+	admin  *kadm.Client
+}
+
+func newKafkaClient(ctx context.Context, config Config) (Client, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(config.localAddresses(kafkaPort)...),
+		kgo.ClientID(kafkaClientID),
+		// Brokers announce the addresses of the pods, which are reached through the tunnels.
+		kgo.Dialer(config.dial),
+		kgo.DialTimeout(dialTimeout),
+		// Records are assigned to partitions by the caller and acknowledged by all in-sync replicas.
+		kgo.RecordPartitioner(kgo.ManualPartitioner()),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.DisableIdempotentWrite(),
+	}
+	if config.Username != "" {
+		// The first mechanism enabled on the brokers is used.
+		scramAuth := scram.Auth{User: config.Username, Pass: config.Password}
+		plainAuth := plain.Auth{User: config.Username, Pass: config.Password}
+		opts = append(opts, kgo.SASL(scramAuth.AsSha512Mechanism(), scramAuth.AsSha256Mechanism(), plainAuth.AsMechanism()))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kafka client: %w", err)
+	}
+	if err := client.Ping(ctx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("connecting to Kafka: %w", err)
+	}
+	return &kafkaClient{opts: opts, client: client, admin: kadm.NewClient(client)}, nil
+}
+
+func (c *kafkaClient) Write(ctx context.Context, dataset Dataset) error {
+	topic, err := dataset.name()
+	if err != nil {
+		return err
+	}
+	if err := c.CreateTopic(ctx, topic, 1, 0); err != nil {
+		return err
+	}
+
+	records := dataset.Records()
+	messages := make([]KafkaMessage, 0, len(records))
+	for _, key := range sortedKeys(records) {
+		messages = append(messages, KafkaMessage{Key: key, Value: records[key]})
+	}
+	return c.Produce(ctx, topic, messages)
+}
+
+func (c *kafkaClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	topic, err := dataset.name()
+	if err != nil {
+		return nil, err
+	}
+	exists, err := c.topicExists(ctx, topic)
+	if err != nil || !exists {
+		return map[string]string{}, err
+	}
+	messages, err := c.Consume(ctx, topic, 0)
+	if err != nil {
+		return nil, err
+	}
+	records := make(map[string]string, len(messages))
+	for _, message := range messages {
+		records[message.Key] = message.Value
+	}
+	return records, nil
+}
+
+func (c *kafkaClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *kafkaClient) Close() error {
+	c.client.Close()
+	return nil
+}
+
+// topicExists returns false if the brokers do not know the topic.
+func (c *kafkaClient) topicExists(ctx context.Context, topic string) (bool, error) {
+	metadata, err := c.admin.Metadata(ctx, topic)
+	if err != nil {
+		return false, fmt.Errorf("getting metadata of topic %s: %w", topic, err)
+	}
+	err = metadata.Topics[topic].Err
+	switch {
+	case errors.Is(err, kerr.UnknownTopicOrPartition):
+		return false, nil
+	case err != nil && !errors.Is(err, kerr.LeaderNotAvailable):
+		return false, fmt.Errorf("getting metadata of topic %s: %w", topic, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,301 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const kafkaAdvertisedListeners = "advertised.listeners"
+
+// KafkaClient is implemented by the client of Kafka deployments, see New. Next to datasets it produces and consumes
+// messages of topics with multiple partitions and reports the cluster state, e.g. for checks after scaling or
+// broker restarts.
+type KafkaClient interface {
+	Client
+	// Brokers returns the brokers as announced by the cluster metadata.
+	Brokers(ctx context.Context) ([]KafkaBroker, error)
+	// AdvertisedListeners returns the advertised listeners configured on the broker.
+	AdvertisedListeners(ctx context.Context, nodeID int32) ([]KafkaListener, error)
+	// CreateTopic creates the topic, existing topics are kept.
+	CreateTopic(ctx context.Context, topic string, partitions int32, replicationFactor int16) error
+	// Partitions returns the leaders and replicas of the partitions of the topic ordered by ID.
+	Partitions(ctx context.Context, topic string) ([]KafkaPartition, error)
+	// Produce produces the messages to their partitions and waits for all in-sync replicas.
+	Produce(ctx context.Context, topic string, messages []KafkaMessage) error
+	// Consume returns all messages of the partition up to the high watermark in offset order.
+	Consume(ctx context.Context, topic string, partition int32) ([]KafkaMessage, error)
+}
+
+// KafkaBroker is a broker as announced in the cluster metadata.
+type KafkaBroker struct {
+	NodeID int32
+	Host   string
+	Port   int
+}
+
+// KafkaListener is an advertised listener of a broker.
+type KafkaListener struct {
+	Name string
+	Host string
+	Port int
+}
+
+// KafkaPartition is the state of a partition. Replicas and InSyncReplicas are node IDs of brokers, the leader is -1
+// if the partition has no leader.
+type KafkaPartition struct {
+	ID             int32
+	Leader         int32
+	Replicas       []int32
+	InSyncReplicas []int32
+}
+
+// UnderReplicated returns true if not all replicas of the partition are in sync or the partition has no leader.
+func (p KafkaPartition) UnderReplicated() bool {
+	return p.Leader < 0 || len(p.InSyncReplicas) < len(p.Replicas)
+}
+
+// KafkaMessage is a keyed message of a partition. The offset is assigned by the broker.
+type KafkaMessage struct {
+	Partition int32
+	Offset    int64
+	Key       string
+	Value     string
+}
+
+// NewKafkaMessages returns count keyed messages derived from the seed. Messages are assigned to the partitions by
+// their keys.
+func NewKafkaMessages(seed string, count int, partitions int32) []KafkaMessage {
+	records := NewDataset(seed, count).Records()
+	messages := make([]KafkaMessage, 0, len(records))
+	for _, key := range sortedKeys(records) {
+		messages = append(messages, KafkaMessage{
+			Partition: int32(crc32.ChecksumIEEE([]byte(key)) % uint32(partitions)),
+			Key:       key,
+			Value:     records[key],
+		})
+	}
+	return messages
+}
+
+// VerifyKafkaMessages checks that every expected message was consumed exactly once from its partition with its value
+// and that the offsets of each partition are strictly increasing. Consumed messages which were not expected are
+// reported too.
+func VerifyKafkaMessages(expected, consumed []KafkaMessage) error {
+	want := make(map[string]KafkaMessage, len(expected))
+	values := make(map[string]string, len(expected))
+	for _, message := range expected {
+		want[message.Key] = message
+		values[message.Key] = message.Value
+	}
+
+	var problems []string
+	seen := make(map[string]int, len(consumed))
+	lastOffsets := make(map[int32]int64)
+	for _, message := range consumed {
+		if last, ok := lastOffsets[message.Partition]; ok && message.Offset <= last {
+			problems = append(problems, fmt.Sprintf("offset %d of partition %d after offset %d", message.Offset, message.Partition, last))
+		}
+		lastOffsets[message.Partition] = message.Offset
+
+		seen[message.Key]++
+		expectedMessage, ok := want[message.Key]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("unexpected key %s at %d/%d", message.Key, message.Partition, message.Offset))
+		case expectedMessage.Partition != message.Partition:
+			problems = append(problems, fmt.Sprintf("key %s in partition %d, expected %d", message.Key, message.Partition, expectedMessage.Partition))
+		case expectedMessage.Value != message.Value:
+			problems = append(problems, fmt.Sprintf("corrupted key %s at %d/%d", message.Key, message.Partition, message.Offset))
+		}
+	}
+	for _, key := range sortedKeys(values) {
+		switch n := seen[key]; {
+		case n == 0:
+			problems = append(problems, fmt.Sprintf("missing key %s", key))
+		case n > 1:
+			problems = append(problems, fmt.Sprintf("key %s consumed %d times", key, n))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	if len(problems) > maxReportedKeys {
+		problems = append(problems[:maxReportedKeys], fmt.Sprintf("and %d more", len(problems)-maxReportedKeys))
+	}
+	return fmt.Errorf("%d of %d messages consumed: %s", len(consumed), len(expected), strings.Join(problems, "; "))
+}
+
+// ParseKafkaListeners parses a listener list like "INTERNAL://host:9092,EXTERNAL://other:9094".
+func ParseKafkaListeners(value string) ([]KafkaListener, error) {
+	var listeners []KafkaListener
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, address, ok := strings.Cut(entry, "://")
+		if !ok {
+			return nil, fmt.Errorf("invalid listener %q", entry)
+		}
+		host, portString, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid listener %q: %w", entry, err)
+		}
+		port, err := strconv.Atoi(portString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port of listener %q", entry)
+		}
+		listeners = append(listeners, KafkaListener{Name: name, Host: host, Port: port})
+	}
+	return listeners, nil
+}
+
+func (c *kafkaClient) Brokers(ctx context.Context) ([]KafkaBroker, error) {
+	metadata, err := c.admin.BrokerMetadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting Kafka metadata: %w", err)
+	}
+	brokers := make([]KafkaBroker, 0, len(metadata.Brokers))
+	for _, broker := range metadata.Brokers {
+		brokers = append(brokers, KafkaBroker{NodeID: broker.NodeID, Host: broker.Host, Port: int(broker.Port)})
+	}
+	sort.Slice(brokers, func(i, j int) bool { return brokers[i].NodeID < brokers[j].NodeID })
+	return brokers, nil
+}
+
+// AdvertisedListeners describes the configuration of the broker. Broker configs are described by the broker itself.
+func (c *kafkaClient) AdvertisedListeners(ctx context.Context, nodeID int32) ([]KafkaListener, error) {
+	configs, err := c.admin.DescribeBrokerConfigs(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("describing config of broker %d: %w", nodeID, err)
+	}
+
+	var value string
+	for _, resource := range configs {
+		if resource.Err != nil {
+			return nil, fmt.Errorf("describing config of broker %d: %w", nodeID, resource.Err)
+		}
+		for _, config := range resource.Configs {
+			if config.Key == kafkaAdvertisedListeners && config.Value != nil {
+				value = *config.Value
+			}
+		}
+	}
+	return ParseKafkaListeners(value)
+}
+
+// CreateTopic creates the topic, existing topics are kept. If replicationFactor is 0, the topic is replicated to all
+// brokers, up to 3.
+func (c *kafkaClient) CreateTopic(ctx context.Context, topic string, partitions int32, replicationFactor int16) error {
+	if replicationFactor == 0 {
+		brokers, err := c.admin.ListBrokers(ctx)
+		if err != nil {
+			return fmt.Errorf("creating topic %s: %w", topic, err)
+		}
+		replicationFactor = int16(len(brokers))
+		if replicationFactor > 3 {
+			replicationFactor = 3
+		}
+	}
+
+	resp, err := c.admin.CreateTopic(ctx, partitions, replicationFactor, nil, topic)
+	if err == nil {
+		err = resp.Err
+	}
+	if err != nil && !errors.Is(err, kerr.TopicAlreadyExists) {
+		return fmt.Errorf("creating topic %s: %w", topic, err)
+	}
+	return nil
+}
+
+func (c *kafkaClient) Partitions(ctx context.Context, topic string) ([]KafkaPartition, error) {
+	metadata, err := c.admin.Metadata(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("getting metadata of topic %s: %w", topic, err)
+	}
+	topicDetail := metadata.Topics[topic]
+	if topicDetail.Err != nil && !errors.Is(topicDetail.Err, kerr.LeaderNotAvailable) {
+		return nil, fmt.Errorf("getting metadata of topic %s: %w", topic, topicDetail.Err)
+	}
+	partitions := make([]KafkaPartition, 0, len(topicDetail.Partitions))
+	for _, partition := range topicDetail.Partitions.Sorted() {
+		partitions = append(partitions, KafkaPartition{
+			ID:             partition.Partition,
+			Leader:         partition.Leader,
+			Replicas:       partition.Replicas,
+			InSyncReplicas: partition.ISR,
+		})
+	}
+	return partitions, nil
+}
+
+func (c *kafkaClient) Produce(ctx context.Context, topic string, messages []KafkaMessage) error {
+	records := make([]*kgo.Record, 0, len(messages))
+	for _, message := range messages {
+		records = append(records, &kgo.Record{
+			Topic:     topic,
+			Partition: message.Partition,
+			Key:       []byte(message.Key),
+			Value:     []byte(message.Value),
+		})
+	}
+	if err := c.client.ProduceSync(ctx, records...).FirstErr(); err != nil {
+		return fmt.Errorf("producing records to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// Consume reads the partition from the start with a separate consumer, the partitions to consume are fixed when
+// a client is created.
+func (c *kafkaClient) Consume(ctx context.Context, topic string, partition int32) ([]KafkaMessage, error) {
+	endOffsets, err := c.admin.ListEndOffsets(ctx, topic)
+	if err != nil {
+		return nil, fmt.Errorf("listing end offsets of %s: %w", topic, err)
+	}
+	endOffset, ok := endOffsets.Lookup(topic, partition)
+	if !ok {
+		return nil, fmt.Errorf("partition %s/%d does not exist", topic, partition)
+	}
+	if endOffset.Err != nil {
+		return nil, fmt.Errorf("listing end offset of %s/%d: %w", topic, partition, endOffset.Err)
+	}
+	highWatermark := endOffset.Offset
+	if highWatermark <= 0 {
+		return nil, nil
+	}
+
+	consumer, err := kgo.NewClient(append(c.opts, kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{
+		topic: {partition: kgo.NewOffset().AtStart()},
+	}))...)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kafka consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	var messages []KafkaMessage
+	for next := int64(0); next < highWatermark; {
+		fetches := consumer.PollFetches(ctx)
+		if errs := fetches.Errors(); len(errs) > 0 {
+			return nil, fmt.Errorf("fetching records of %s/%d at offset %d below high watermark %d: %w", topic, partition, next, highWatermark, errs[0].Err)
+		}
+		fetches.EachRecord(func(record *kgo.Record) {
+			if record.Offset < highWatermark {
+				messages = append(messages, KafkaMessage{Partition: partition, Offset: record.Offset, Key: string(record.Key), Value: string(record.Value)})
+			}
+			next = record.Offset + 1
+		})
+	}
+	return messages, nil
+}
@@ -0,0 +1,84 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKafkaMessages(t *testing.T) {
+	messages := NewKafkaMessages("seed", 50, 3)
+	require.Len(t, messages, 50)
+	assert.Equal(t, messages, NewKafkaMessages("seed", 50, 3))
+
+	partitions := make(map[int32]int)
+	for _, message := range messages {
+		require.GreaterOrEqual(t, message.Partition, int32(0))
+		require.Less(t, message.Partition, int32(3))
+		partitions[message.Partition]++
+	}
+	assert.Len(t, partitions, 3, "Messages should be spread over all partitions.")
+}
+
+func TestVerifyKafkaMessages(t *testing.T) {
+	expected := []KafkaMessage{
+		{Partition: 0, Key: "a", Value: "1"},
+		{Partition: 0, Key: "b", Value: "2"},
+		{Partition: 1, Key: "c", Value: "3"},
+	}
+	consumed := []KafkaMessage{
+		{Partition: 0, Offset: 0, Key: "a", Value: "1"},
+		{Partition: 0, Offset: 1, Key: "b", Value: "2"},
+		{Partition: 1, Offset: 0, Key: "c", Value: "3"},
+	}
+	assert.NoError(t, VerifyKafkaMessages(expected, consumed))
+
+	for name, tc := range map[string]struct {
+		consumed []KafkaMessage
+		problem  string
+	}{
+		"missing": {
+			consumed: consumed[:2],
+			problem:  "missing key c",
+		},
+		"duplicate": {
+			consumed: append(consumed, KafkaMessage{Partition: 1, Offset: 1, Key: "c", Value: "3"}),
+			problem:  "key c consumed 2 times",
+		},
+		"offsets": {
+			consumed: []KafkaMessage{consumed[1], consumed[0], consumed[2]},
+			problem:  "offset 0 of partition 0 after offset 1",
+		},
+		"unexpected": {
+			consumed: append(consumed, KafkaMessage{Partition: 1, Offset: 1, Key: "d", Value: "4"}),
+			problem:  "unexpected key d at 1/1",
+		},
+		"corrupted": {
+			consumed: []KafkaMessage{consumed[0], {Partition: 0, Offset: 1, Key: "b", Value: "x"}, consumed[2]},
+			problem:  "corrupted key b at 0/1",
+		},
+		"partition": {
+			consumed: []KafkaMessage{consumed[0], consumed[1], {Partition: 0, Offset: 2, Key: "c", Value: "3"}},
+			problem:  "key c in partition 0, expected 1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyKafkaMessages(expected, tc.consumed)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.problem)
+		})
+	}
+}
+
+func TestParseKafkaListeners(t *testing.T) {
+	listeners, err := ParseKafkaListeners("INTERNAL://kafka-0.kafka-vip.ns.svc.cluster.local:9092, EXTERNAL://kafka-0.example.com:9094")
+	require.NoError(t, err)
+	assert.Equal(t, []KafkaListener{
+		{Name: "INTERNAL", Host: "kafka-0.kafka-vip.ns.svc.cluster.local", Port: 9092},
+		{Name: "EXTERNAL", Host: "kafka-0.example.com", Port: 9094},
+	}, listeners)
+
+	_, err = ParseKafkaListeners("kafka-0:9092")
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+const (
+	mongoDBPort     = 27017
+	mongoDBDatabase = "pds"
+	mongoDBAuthDB   = "admin"
+)
+
+// mongoDBClient is connected directly to the primary of the replica set.
+type mongoDBClient struct {
+	client *mongo.Client
+}
+
+// mongoDBRecord is a document of a dataset collection.
+type mongoDBRecord struct {
+	Key   string `bson:"_id"`
+	Value string `bson:"value"`
+}
+
+// mongoDBDialer dials through the tunnels of the pods, see Config.dial.
+type mongoDBDialer Config
+
+func (d mongoDBDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return Config(d).dial(ctx, network, address)
+}
+
+func newMongoDBClient(ctx context.Context, config Config) (Client, error) {
+	var lastErr error
+	for _, address := range config.localAddresses(mongoDBPort) {
+		client, err := connectMongoDB(ctx, config, address)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		var hello struct {
+			IsWritablePrimary bool `bson:"isWritablePrimary"`
+		}
+		err = client.Database(mongoDBAuthDB).RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+		if err == nil && !hello.IsWritablePrimary {
+			err = fmt.Errorf("%s is not the primary", address)
+		}
+		if err != nil {
+			_ = client.Disconnect(ctx)
+			lastErr = err
+			continue
+		}
+		return &mongoDBClient{client: client}, nil
+	}
+	return nil, fmt.Errorf("connecting to MongoDB primary: %w", lastErr)
+}
+
+// connectMongoDB connects directly to the member at the address without discovering the replica set, members
+// announce the addresses of the pods.
+func connectMongoDB(ctx context.Context, config Config, address string) (*mongo.Client, error) {
+	opts := options.Client().
+		SetHosts([]string{address}).
+		SetDirect(true).
+		SetDialer(mongoDBDialer(config)).
+		SetConnectTimeout(dialTimeout).
+		SetServerSelectionTimeout(dialTimeout)
+	if config.Username != "" {
+		opts.SetAuth(options.Credential{
+			AuthMechanism: "SCRAM-SHA-256",
+			AuthSource:    mongoDBAuthDB,
+			Username:      config.Username,
+			Password:      config.Password,
+		})
+	}
+	return mongo.Connect(ctx, opts)
+}
+
+// collection returns the dataset collection with majority write concern.
+func (c *mongoDBClient) collection(name string) *mongo.Collection {
+	opts := options.Collection().SetWriteConcern(writeconcern.New(writeconcern.WMajority()))
+	return c.client.Database(mongoDBDatabase).Collection(name, opts)
+}
+
+// Write replaces the documents of the dataset collection by a document per record.
+func (c *mongoDBClient) Write(ctx context.Context, dataset Dataset) error {
+	name, err := dataset.name()
+	if err != nil {
+		return err
+	}
+	collection := c.collection(name)
+	if _, err := collection.DeleteMany(ctx, bson.D{}); err != nil {
+		return fmt.Errorf("deleting documents of %s: %w", name, err)
+	}
+
+	records := dataset.Records()
+	for _, keys := range batches(sortedKeys(records)) {
+		documents := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			documents = append(documents, mongoDBRecord{Key: key, Value: records[key]})
+		}
+		if _, err := collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(true)); err != nil {
+			return fmt.Errorf("inserting documents into %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (c *mongoDBClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	name, err := dataset.name()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := c.collection(name).Find(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("finding documents of %s: %w", name, err)
+	}
+	defer cursor.Close(ctx)
+
+	records := make(map[string]string)
+	for cursor.Next(ctx) {
+		var record mongoDBRecord
+		if err := cursor.Decode(&record); err != nil {
+			return nil, fmt.Errorf("decoding document of %s: %w", name, err)
+		}
+		records[record.Key] = record.Value
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("finding documents of %s: %w", name, err)
+	}
+	return records, nil
+}
+
+func (c *mongoDBClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *mongoDBClient) Close() error {
+	return c.client.Disconnect(context.Background())
+}
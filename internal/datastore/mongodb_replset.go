@@ -0,0 +1,145 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MongoDB replica set member states as reported by replSetGetStatus.
+const (
+	MongoDBPrimary   = "PRIMARY"
+	MongoDBSecondary = "SECONDARY"
+	MongoDBArbiter   = "ARBITER"
+)
+
+// MongoDBReplicaSetClient is implemented by the client of MongoDB deployments, see New. Next to datasets it reports
+// the status of the replica set, e.g. for checks after failovers.
+type MongoDBReplicaSetClient interface {
+	Client
+	// ReplicaSetStatus returns the status of the replica set as seen by the primary.
+	ReplicaSetStatus(ctx context.Context) (MongoDBReplicaSetStatus, error)
+}
+
+// MongoDBReplicaSetStatus is the status of a replica set.
+type MongoDBReplicaSetStatus struct {
+	Set     string
+	Members []MongoDBMember
+}
+
+// MongoDBMember is a member of a replica set. Optime is the time of the last operation applied by the member,
+// ElectionDate is only set for the primary.
+type MongoDBMember struct {
+	// Name is the host and port of the member, e.g. "mongodb-0.mongodb.ns.svc.cluster.local:27017".
+	Name         string
+	State        string
+	Healthy      bool
+	Optime       time.Time
+	ElectionDate time.Time
+}
+
+// Host returns the host of the member without the port.
+func (m MongoDBMember) Host() string {
+	host := m.Name
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	return host
+}
+
+// Primary returns the primary of the replica set, if any.
+func (s MongoDBReplicaSetStatus) Primary() (MongoDBMember, bool) {
+	for _, member := range s.Members {
+		if member.State == MongoDBPrimary {
+			return member, true
+		}
+	}
+	return MongoDBMember{}, false
+}
+
+// VerifyMongoDBReplicaSet checks a replica set of nodeCount data bearing members: all members are healthy, exactly
+// one is the primary, all others are secondaries and the optime of no secondary lags more than maxLag behind the
+// primary. Arbiters are not counted.
+func VerifyMongoDBReplicaSet(status MongoDBReplicaSetStatus, nodeCount int, maxLag time.Duration) error {
+	var problems []string
+	var dataMembers, primaries []MongoDBMember
+	for _, member := range status.Members {
+		if !member.Healthy {
+			problems = append(problems, fmt.Sprintf("member %s is not healthy", member.Name))
+		}
+		switch member.State {
+		case MongoDBArbiter:
+			continue
+		case MongoDBPrimary:
+			primaries = append(primaries, member)
+		case MongoDBSecondary:
+		default:
+			problems = append(problems, fmt.Sprintf("member %s is %s", member.Name, member.State))
+		}
+		dataMembers = append(dataMembers, member)
+	}
+	if len(dataMembers) != nodeCount {
+		problems = append(problems, fmt.Sprintf("%d members, expected %d", len(dataMembers), nodeCount))
+	}
+
+	if len(primaries) != 1 {
+		var names []string
+		for _, primary := range primaries {
+			names = append(names, primary.Name)
+		}
+		problems = append(problems, fmt.Sprintf("%d primaries %v, expected 1", len(primaries), names))
+	} else {
+		primary := primaries[0]
+		for _, member := range dataMembers {
+			if lag := primary.Optime.Sub(member.Optime); member.State == MongoDBSecondary && lag > maxLag {
+				problems = append(problems, fmt.Sprintf("member %s lags %s behind the primary, expected at most %s", member.Name, lag, maxLag))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("replica set %s: %s", status.Set, strings.Join(problems, "; "))
+}
+
+func (c *mongoDBClient) ReplicaSetStatus(ctx context.Context) (MongoDBReplicaSetStatus, error) {
+	resp, err := c.client.Database(mongoDBAuthDB).RunCommand(ctx, bson.D{{Key: "replSetGetStatus", Value: 1}}).DecodeBytes()
+	if err != nil {
+		return MongoDBReplicaSetStatus{}, fmt.Errorf("getting replica set status: %w", err)
+	}
+	return parseMongoDBReplicaSetStatus(resp)
+}
+
+// mongoDBReplSetGetStatus is the subset of the replSetGetStatus reply used by the checks.
+type mongoDBReplSetGetStatus struct {
+	Set     string `bson:"set"`
+	Members []struct {
+		Name         string    `bson:"name"`
+		Health       float64   `bson:"health"`
+		StateStr     string    `bson:"stateStr"`
+		OptimeDate   time.Time `bson:"optimeDate"`
+		ElectionDate time.Time `bson:"electionDate"`
+	} `bson:"members"`
+}
+
+func parseMongoDBReplicaSetStatus(resp bson.Raw) (MongoDBReplicaSetStatus, error) {
+	var reply mongoDBReplSetGetStatus
+	if err := bson.Unmarshal(resp, &reply); err != nil {
+		return MongoDBReplicaSetStatus{}, fmt.Errorf("decoding replica set status: %w", err)
+	}
+	status := MongoDBReplicaSetStatus{Set: reply.Set}
+	for _, member := range reply.Members {
+		status.Members = append(status.Members, MongoDBMember{
+			Name:         member.Name,
+			State:        member.StateStr,
+			Healthy:      member.Health == 1,
+			Optime:       member.OptimeDate,
+			ElectionDate: member.ElectionDate,
+		})
+	}
+	return status, nil
+}
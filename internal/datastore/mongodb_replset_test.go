@@ -0,0 +1,98 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestParseMongoDBReplicaSetStatus(t *testing.T) {
+	optime := time.UnixMilli(1700000000000).UTC()
+	resp, err := bson.Marshal(bson.D{
+		{Key: "set", Value: "rs0"},
+		{Key: "members", Value: bson.A{
+			bson.D{{Key: "name", Value: "mongodb-0.mongodb:27017"}, {Key: "health", Value: 1.0}, {Key: "stateStr", Value: "PRIMARY"}, {Key: "optimeDate", Value: optime}, {Key: "electionDate", Value: optime}},
+			// Older versions report the health as integer.
+			bson.D{{Key: "name", Value: "mongodb-1.mongodb:27017"}, {Key: "health", Value: int32(0)}, {Key: "stateStr", Value: "(not reachable/healthy)"}},
+		}},
+		{Key: "ok", Value: 1.0},
+	})
+	require.NoError(t, err)
+
+	status, err := parseMongoDBReplicaSetStatus(resp)
+	require.NoError(t, err)
+
+	assert.Equal(t, MongoDBReplicaSetStatus{
+		Set: "rs0",
+		Members: []MongoDBMember{
+			{Name: "mongodb-0.mongodb:27017", State: "PRIMARY", Healthy: true, Optime: optime, ElectionDate: optime},
+			{Name: "mongodb-1.mongodb:27017", State: "(not reachable/healthy)"},
+		},
+	}, status)
+	primary, ok := status.Primary()
+	require.True(t, ok)
+	assert.Equal(t, "mongodb-0.mongodb", primary.Host())
+}
+
+func TestVerifyMongoDBReplicaSet(t *testing.T) {
+	now := time.Now()
+	newStatus := func() MongoDBReplicaSetStatus {
+		return MongoDBReplicaSetStatus{
+			Set: "rs0",
+			Members: []MongoDBMember{
+				{Name: "mongodb-0:27017", State: MongoDBPrimary, Healthy: true, Optime: now},
+				{Name: "mongodb-1:27017", State: MongoDBSecondary, Healthy: true, Optime: now.Add(-time.Second)},
+				{Name: "mongodb-2:27017", State: MongoDBArbiter, Healthy: true},
+			},
+		}
+	}
+	assert.NoError(t, VerifyMongoDBReplicaSet(newStatus(), 2, 10*time.Second))
+
+	for name, tc := range map[string]struct {
+		modify    func(status *MongoDBReplicaSetStatus)
+		nodeCount int
+		problem   string
+	}{
+		"node count": {
+			nodeCount: 3,
+			problem:   "2 members, expected 3",
+		},
+		"no primary": {
+			modify:  func(status *MongoDBReplicaSetStatus) { status.Members[0].State = MongoDBSecondary },
+			problem: "0 primaries [], expected 1",
+		},
+		"two primaries": {
+			modify:  func(status *MongoDBReplicaSetStatus) { status.Members[1].State = MongoDBPrimary },
+			problem: "2 primaries [mongodb-0:27017 mongodb-1:27017], expected 1",
+		},
+		"recovering": {
+			modify:  func(status *MongoDBReplicaSetStatus) { status.Members[1].State = "RECOVERING" },
+			problem: "member mongodb-1:27017 is RECOVERING",
+		},
+		"unhealthy": {
+			modify:  func(status *MongoDBReplicaSetStatus) { status.Members[1].Healthy = false },
+			problem: "member mongodb-1:27017 is not healthy",
+		},
+		"lag": {
+			modify:  func(status *MongoDBReplicaSetStatus) { status.Members[1].Optime = now.Add(-time.Minute) },
+			problem: "member mongodb-1:27017 lags 1m0s behind the primary, expected at most 10s",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			status := newStatus()
+			if tc.modify != nil {
+				tc.modify(&status)
+			}
+			nodeCount := tc.nodeCount
+			if nodeCount == 0 {
+				nodeCount = 2
+			}
+			err := VerifyMongoDBReplicaSet(status, nodeCount, 10*time.Second)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.problem)
+		})
+	}
+}
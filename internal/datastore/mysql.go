@@ -0,0 +1,45 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	mysqlPort     = 3306
+	mysqlDatabase = "pds"
+)
+
+var mysqlDialect = sqlDialect{
+	driverName:  "mysql",
+	setup:       []string{"CREATE DATABASE IF NOT EXISTS " + mysqlDatabase},
+	isPrimary:   "SELECT @@super_read_only = 0",
+	createTable: "CREATE TABLE IF NOT EXISTS %[1]s (record_key VARCHAR(64) PRIMARY KEY, record_value VARCHAR(64) NOT NULL)",
+	table: func(name string) string {
+		return mysqlDatabase + "." + name
+	},
+	placeholder: func(int) string {
+		return "?"
+	},
+}
+
+// newMySQLClient connects to the primary of the group replication.
+func newMySQLClient(ctx context.Context, config Config) (Client, error) {
+	var dataSourceNames []string
+	for _, address := range config.localAddresses(mysqlPort) {
+		mysqlConfig := mysql.NewConfig()
+		mysqlConfig.User = config.Username
+		mysqlConfig.Passwd = config.Password
+		mysqlConfig.Net = "tcp"
+		mysqlConfig.Addr = address
+		mysqlConfig.Timeout = dialTimeout
+		dataSourceNames = append(dataSourceNames, mysqlConfig.FormatDSN())
+	}
+	client, err := newSQLClient(ctx, mysqlDialect, dataSourceNames)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to MySQL: %w", err)
+	}
+	return client, nil
+}
@@ -1,8 +1,10 @@
 package datastore
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"net"
 
 	_ "github.com/lib/pq" // need to import because database/sql only contains specs not implementation
 )
@@ -55,3 +57,34 @@ func (driver postgresDriver) CheckConnection() error {
 
 	return db.Ping()
 }
+
+const (
+	postgresPort = 5432
+	// postgresDatabase is created by PDS for the pds user.
+	postgresDatabase = "pds"
+)
+
+var postgresDialect = sqlDialect{
+	driverName:  postgresDB,
+	isPrimary:   "SELECT NOT pg_is_in_recovery()",
+	createTable: "CREATE TABLE IF NOT EXISTS %[1]s (record_key VARCHAR(64) PRIMARY KEY, record_value VARCHAR(64) NOT NULL)",
+	table:       unqualifiedTable,
+	placeholder: dollarPlaceholder,
+}
+
+func newPostgresClient(ctx context.Context, config Config) (Client, error) {
+	var dataSourceNames []string
+	for _, address := range config.localAddresses(postgresPort) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		dataSourceNames = append(dataSourceNames, fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s connect_timeout=%d",
+			host, port, config.Username, config.Password, postgresDatabase, Disable, int(dialTimeout.Seconds())))
+	}
+	client, err := newSQLClient(ctx, postgresDialect, dataSourceNames)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to PostgreSQL: %w", err)
+	}
+	return client, nil
+}
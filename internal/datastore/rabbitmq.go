@@ -0,0 +1,129 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const rabbitMQPort = 5672
+
+type rabbitMQClient struct {
+	conn *amqp.Connection
+}
+
+func newRabbitMQClient(ctx context.Context, config Config) (Client, error) {
+	uri := url.URL{
+		Scheme: "amqp",
+		User:   url.UserPassword(config.Username, config.Password),
+		Host:   config.localAddresses(rabbitMQPort)[0],
+		Path:   "/",
+	}
+	conn, err := amqp.DialConfig(uri.String(), amqp.Config{
+		Dial: func(network, address string) (net.Conn, error) {
+			return config.dial(ctx, network, address)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connecting to RabbitMQ: %w", err)
+	}
+	return &rabbitMQClient{conn: conn}, nil
+}
+
+// Write replaces the messages of the dataset queue by a message per record. Publishing waits for the confirmations
+// of the broker.
+func (c *rabbitMQClient) Write(ctx context.Context, dataset Dataset) error {
+	queue, err := dataset.name()
+	if err != nil {
+		return err
+	}
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declaring queue %s: %w", queue, err)
+	}
+	if _, err := ch.QueuePurge(queue, false); err != nil {
+		return fmt.Errorf("purging queue %s: %w", queue, err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		return err
+	}
+	confirmations := ch.NotifyPublish(make(chan amqp.Confirmation, dataset.Size))
+
+	records := dataset.Records()
+	for _, key := range sortedKeys(records) {
+		err := ch.PublishWithContext(ctx, "", queue, false, false, amqp.Publishing{
+			DeliveryMode: amqp.Persistent,
+			MessageId:    key,
+			Body:         []byte(records[key]),
+		})
+		if err != nil {
+			return fmt.Errorf("publishing record %s to queue %s: %w", key, queue, err)
+		}
+	}
+	for range records {
+		select {
+		case confirmation, ok := <-confirmations:
+			if !ok {
+				return fmt.Errorf("channel closed before all records to queue %s were confirmed", queue)
+			}
+			if !confirmation.Ack {
+				return fmt.Errorf("record %d to queue %s was not confirmed", confirmation.DeliveryTag, queue)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Read gets all messages of the dataset queue and requeues them, the records stay in the queue.
+func (c *rabbitMQClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	queue, err := dataset.name()
+	if err != nil {
+		return nil, err
+	}
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+
+	records := make(map[string]string)
+	var lastTag uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		delivery, ok, err := ch.Get(queue, false)
+		if err != nil {
+			return nil, fmt.Errorf("getting message from queue %s: %w", queue, err)
+		}
+		if !ok {
+			break
+		}
+		records[delivery.MessageId] = string(delivery.Body)
+		lastTag = delivery.DeliveryTag
+	}
+	if lastTag > 0 {
+		if err := ch.Nack(lastTag, true, true); err != nil {
+			return nil, fmt.Errorf("requeueing messages of queue %s: %w", queue, err)
+		}
+	}
+	return records, nil
+}
+
+func (c *rabbitMQClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *rabbitMQClient) Close() error {
+	return c.conn.Close()
+}
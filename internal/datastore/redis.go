@@ -0,0 +1,110 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisPort = 6379
+
+type redisClient struct {
+	client redis.UniversalClient
+}
+
+// newRedisClient connects a cluster client if there are multiple endpoints, the same way the load tests
+// enable the cluster mode for deployments with more than one node.
+func newRedisClient(ctx context.Context, config Config) (Client, error) {
+	addresses := config.localAddresses(redisPort)
+
+	var client redis.UniversalClient
+	if len(addresses) > 1 {
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:       addresses,
+			Username:    config.Username,
+			Password:    config.Password,
+			Dialer:      config.dial,
+			DialTimeout: dialTimeout,
+		})
+	} else {
+		client = redis.NewClient(&redis.Options{
+			Addr:        addresses[0],
+			Username:    config.Username,
+			Password:    config.Password,
+			DialTimeout: dialTimeout,
+		})
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("connecting to Redis: %w", err)
+	}
+	return &redisClient{client: client}, nil
+}
+
+func (c *redisClient) Write(ctx context.Context, dataset Dataset) error {
+	prefix, err := dataset.name()
+	if err != nil {
+		return err
+	}
+
+	records := dataset.Records()
+	for _, keys := range batches(sortedKeys(records)) {
+		_, err = c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				pipe.Set(ctx, redisKey(prefix, key), records[key], 0)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *redisClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	prefix, err := dataset.name()
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]string, dataset.Size)
+	for _, keys := range batches(sortedKeys(dataset.Records())) {
+		commands := make([]*redis.StringCmd, len(keys))
+		_, err = c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range keys {
+				commands[i] = pipe.Get(ctx, redisKey(prefix, key))
+			}
+			return nil
+		})
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+
+		for i, key := range keys {
+			value, err := commands[i].Result()
+			if err == redis.Nil {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("getting record %s: %w", key, err)
+			}
+			records[key] = value
+		}
+	}
+	return records, nil
+}
+
+func (c *redisClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *redisClient) Close() error {
+	return c.client.Close()
+}
+
+func redisKey(prefix, key string) string {
+	return prefix + ":" + key
+}
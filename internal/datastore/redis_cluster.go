@@ -0,0 +1,202 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// RedisClusterSlots is the number of hash slots of a Redis Cluster.
+	RedisClusterSlots = 16384
+	// redisClusterReplicas is the number of replicas of each master in clusters deployed by PDS.
+	redisClusterReplicas = 1
+)
+
+// RedisClusterClient is implemented by the client of Redis deployments, see New. Next to datasets it reports the
+// topology of deployments running in cluster mode.
+type RedisClusterClient interface {
+	Client
+	// ClusterNodes returns the nodes of the cluster as seen by one of them.
+	ClusterNodes(ctx context.Context) ([]RedisClusterNode, error)
+}
+
+// RedisClusterNode is a node of a Redis Cluster as reported by CLUSTER NODES.
+type RedisClusterNode struct {
+	ID      string
+	Address string
+	Flags   []string
+	// MasterID is the ID of the master of a replica, empty for masters.
+	MasterID  string
+	Connected bool
+	// Slots are the hash slots served by a master. Slots being imported or migrated are not included.
+	Slots []RedisSlotRange
+}
+
+// RedisSlotRange is an inclusive range of hash slots.
+type RedisSlotRange struct {
+	Start int
+	End   int
+}
+
+// Len returns the number of slots of the range.
+func (r RedisSlotRange) Len() int {
+	return r.End - r.Start + 1
+}
+
+// IsMaster returns true if the node is a master.
+func (n RedisClusterNode) IsMaster() bool {
+	return n.hasFlag("master")
+}
+
+// Failing returns true if the node is marked as failing, possibly failing or has no known address.
+func (n RedisClusterNode) Failing() bool {
+	return n.hasFlag("fail") || n.hasFlag("fail?") || n.hasFlag("noaddr") || n.hasFlag("handshake")
+}
+
+// SlotCount returns the number of slots served by the node.
+func (n RedisClusterNode) SlotCount() int {
+	count := 0
+	for _, slots := range n.Slots {
+		count += slots.Len()
+	}
+	return count
+}
+
+func (n RedisClusterNode) hasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRedisClusterNodes parses the output of CLUSTER NODES.
+func ParseRedisClusterNodes(output string) ([]RedisClusterNode, error) {
+	var nodes []RedisClusterNode
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 8 {
+			return nil, fmt.Errorf("invalid cluster node %q", line)
+		}
+
+		node := RedisClusterNode{
+			ID:        fields[0],
+			Address:   strings.SplitN(strings.SplitN(fields[1], ",", 2)[0], "@", 2)[0],
+			Flags:     strings.Split(fields[2], ","),
+			Connected: fields[7] == "connected",
+		}
+		if fields[3] != "-" {
+			node.MasterID = fields[3]
+		}
+		for _, field := range fields[8:] {
+			if strings.HasPrefix(field, "[") {
+				// Slot being imported or migrated.
+				continue
+			}
+			slots, err := parseRedisSlotRange(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid slots of cluster node %s: %w", node.ID, err)
+			}
+			node.Slots = append(node.Slots, slots)
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Address < nodes[j].Address })
+	return nodes, nil
+}
+
+func parseRedisSlotRange(value string) (RedisSlotRange, error) {
+	startValue, endValue, isRange := strings.Cut(value, "-")
+	if !isRange {
+		endValue = startValue
+	}
+	start, err := strconv.Atoi(startValue)
+	if err != nil {
+		return RedisSlotRange{}, fmt.Errorf("invalid slot %q", value)
+	}
+	end, err := strconv.Atoi(endValue)
+	if err != nil || end < start || end >= RedisClusterSlots {
+		return RedisSlotRange{}, fmt.Errorf("invalid slot range %q", value)
+	}
+	return RedisSlotRange{Start: start, End: end}, nil
+}
+
+// VerifyRedisCluster checks the topology of a cluster of nodeCount nodes: every node is connected and not failing,
+// there are nodeCount/2 masters with one replica each, all slots are served by exactly one master and the slots are
+// balanced, i.e. every master serves at least half of an even share of the slots.
+func VerifyRedisCluster(nodes []RedisClusterNode, nodeCount int) error {
+	var problems []string
+	if len(nodes) != nodeCount {
+		problems = append(problems, fmt.Sprintf("%d nodes, expected %d", len(nodes), nodeCount))
+	}
+
+	var masters []RedisClusterNode
+	replicas := make(map[string]int)
+	for _, node := range nodes {
+		if node.Failing() || !node.Connected {
+			problems = append(problems, fmt.Sprintf("node %s (%s) is failing with flags %s", node.ID, node.Address, strings.Join(node.Flags, ",")))
+		}
+		if node.IsMaster() {
+			masters = append(masters, node)
+		} else if node.MasterID != "" {
+			replicas[node.MasterID]++
+		}
+	}
+
+	expectedMasters := nodeCount / (redisClusterReplicas + 1)
+	if len(masters) != expectedMasters {
+		problems = append(problems, fmt.Sprintf("%d masters, expected %d", len(masters), expectedMasters))
+	}
+
+	var owners [RedisClusterSlots]int
+	for _, master := range masters {
+		if n := replicas[master.ID]; n != redisClusterReplicas {
+			problems = append(problems, fmt.Sprintf("master %s (%s) has %d replicas, expected %d", master.ID, master.Address, n, redisClusterReplicas))
+		}
+		for _, slots := range master.Slots {
+			for slot := slots.Start; slot <= slots.End; slot++ {
+				owners[slot]++
+			}
+		}
+		if len(masters) > 0 {
+			if minSlots := RedisClusterSlots / len(masters) / 2; master.SlotCount() < minSlots {
+				problems = append(problems, fmt.Sprintf("master %s (%s) serves %d slots, expected at least %d", master.ID, master.Address, master.SlotCount(), minSlots))
+			}
+		}
+	}
+	var uncovered, shared int
+	for _, n := range owners {
+		switch {
+		case n == 0:
+			uncovered++
+		case n > 1:
+			shared++
+		}
+	}
+	if uncovered > 0 {
+		problems = append(problems, fmt.Sprintf("%d slots not covered", uncovered))
+	}
+	if shared > 0 {
+		problems = append(problems, fmt.Sprintf("%d slots served by multiple masters", shared))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("redis cluster of %d nodes: %s", nodeCount, strings.Join(problems, "; "))
+}
+
+func (c *redisClient) ClusterNodes(ctx context.Context) ([]RedisClusterNode, error) {
+	output, err := c.client.ClusterNodes(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster nodes: %w", err)
+	}
+	return ParseRedisClusterNodes(output)
+}
@@ -0,0 +1,97 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redisClusterNodes = `07c37dfeb235213a872192d90877d0cd55635b91 10.0.0.4:6379@16379 slave e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 0 1426238317239 4 connected
+67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 10.0.0.2:6379@16379,redis-1 master - 0 1426238316232 2 connected 5461-10922
+292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 10.0.0.3:6379@16379 master - 0 1426238318243 3 connected 10923-16383
+6ec23923021cf3ffec47632106199cb7f496ce01 10.0.0.5:6379@16379 slave 67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 0 1426238316232 5 connected
+824fe116063bc5fcf9f4ffd895bc17aee7731ac3 10.0.0.6:6379@16379 slave 292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 0 1426238317741 6 connected
+e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca 10.0.0.1:6379@16379 myself,master - 0 0 1 connected 0-5460 [5461-<-67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1]
+`
+
+func TestParseRedisClusterNodes(t *testing.T) {
+	nodes, err := ParseRedisClusterNodes(redisClusterNodes)
+	require.NoError(t, err)
+	require.Len(t, nodes, 6)
+
+	assert.Equal(t, RedisClusterNode{
+		ID:        "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca",
+		Address:   "10.0.0.1:6379",
+		Flags:     []string{"myself", "master"},
+		Connected: true,
+		Slots:     []RedisSlotRange{{Start: 0, End: 5460}},
+	}, nodes[0])
+	assert.Equal(t, "10.0.0.2:6379", nodes[1].Address)
+	assert.Equal(t, "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca", nodes[3].MasterID)
+	assert.False(t, nodes[3].IsMaster())
+
+	_, err = ParseRedisClusterNodes("e7d1 10.0.0.1:6379@16379 master - 0 0 1 connected 0-abc")
+	assert.Error(t, err)
+}
+
+func TestVerifyRedisCluster(t *testing.T) {
+	nodes, err := ParseRedisClusterNodes(redisClusterNodes)
+	require.NoError(t, err)
+	assert.NoError(t, VerifyRedisCluster(nodes, 6))
+
+	for name, tc := range map[string]struct {
+		modify    func(nodes []RedisClusterNode) []RedisClusterNode
+		nodeCount int
+		problem   string
+	}{
+		"node count": {
+			nodeCount: 8,
+			problem:   "6 nodes, expected 8",
+		},
+		"failing": {
+			modify: func(nodes []RedisClusterNode) []RedisClusterNode {
+				nodes[4].Flags = []string{"slave", "fail"}
+				return nodes
+			},
+			problem: "is failing with flags slave,fail",
+		},
+		"uncovered": {
+			modify: func(nodes []RedisClusterNode) []RedisClusterNode {
+				nodes[1].Slots = []RedisSlotRange{{Start: 5461, End: 10000}}
+				return nodes
+			},
+			problem: "922 slots not covered",
+		},
+		"unbalanced": {
+			modify: func(nodes []RedisClusterNode) []RedisClusterNode {
+				nodes[0].Slots = []RedisSlotRange{{Start: 0, End: 10000}}
+				nodes[1].Slots = []RedisSlotRange{{Start: 10001, End: 10922}}
+				return nodes
+			},
+			problem: "serves 922 slots, expected at least 2730",
+		},
+		"replicas": {
+			modify: func(nodes []RedisClusterNode) []RedisClusterNode {
+				nodes[4].MasterID = nodes[0].ID
+				return nodes
+			},
+			problem: "has 2 replicas, expected 1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			nodes, err := ParseRedisClusterNodes(redisClusterNodes)
+			require.NoError(t, err)
+			if tc.modify != nil {
+				nodes = tc.modify(nodes)
+			}
+			nodeCount := tc.nodeCount
+			if nodeCount == 0 {
+				nodeCount = 6
+			}
+			err = VerifyRedisCluster(nodes, nodeCount)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.problem)
+		})
+	}
+}
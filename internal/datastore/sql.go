@@ -0,0 +1,137 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// sqlDialect holds the statements which differ between the SQL data services.
+type sqlDialect struct {
+	driverName string
+	// setup statements run once after connecting, e.g. to create the database.
+	setup []string
+	// isPrimary returns true if the connection is to a writable server.
+	isPrimary string
+	// createTable creates the table given as %[1]s if it doesn't exist.
+	createTable string
+	// table qualifies the table name, e.g. with the database.
+	table func(name string) string
+	// placeholder returns the bind parameter with the 1-based index.
+	placeholder func(i int) string
+}
+
+type sqlClient struct {
+	db      *sql.DB
+	dialect sqlDialect
+}
+
+// newSQLClient connects to the data source names in order and uses the first writable server.
+func newSQLClient(ctx context.Context, dialect sqlDialect, dataSourceNames []string) (*sqlClient, error) {
+	var lastErr error
+	for _, dataSourceName := range dataSourceNames {
+		db, err := sql.Open(dialect.driverName, dataSourceName)
+		if err != nil {
+			return nil, err
+		}
+
+		var primary bool
+		err = db.QueryRowContext(ctx, dialect.isPrimary).Scan(&primary)
+		if err != nil || !primary {
+			_ = db.Close()
+			if err != nil {
+				lastErr = err
+			}
+			continue
+		}
+
+		for _, statement := range dialect.setup {
+			if _, err := db.ExecContext(ctx, statement); err != nil {
+				_ = db.Close()
+				return nil, fmt.Errorf("setting up database: %w", err)
+			}
+		}
+		return &sqlClient{db: db, dialect: dialect}, nil
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("no writable %s server found: %w", dialect.driverName, lastErr)
+	}
+	return nil, fmt.Errorf("no writable %s server found", dialect.driverName)
+}
+
+func (c *sqlClient) Write(ctx context.Context, dataset Dataset) error {
+	table, err := c.table(dataset)
+	if err != nil {
+		return err
+	}
+	_, err = c.db.ExecContext(ctx, fmt.Sprintf(c.dialect.createTable, table))
+	if err != nil {
+		return fmt.Errorf("creating table %s: %w", table, err)
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", table))
+	if err != nil {
+		return fmt.Errorf("deleting records from table %s: %w", table, err)
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (record_key, record_value) VALUES (%s, %s)", table, c.dialect.placeholder(1), c.dialect.placeholder(2))
+	records := dataset.Records()
+	for _, key := range sortedKeys(records) {
+		_, err = tx.ExecContext(ctx, insert, key, records[key])
+		if err != nil {
+			return fmt.Errorf("inserting record %s into table %s: %w", key, table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *sqlClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	table, err := c.table(dataset)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.db.QueryContext(ctx, fmt.Sprintf("SELECT record_key, record_value FROM %s", table))
+	if err != nil {
+		return nil, fmt.Errorf("selecting records from table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	records := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		records[key] = value
+	}
+	return records, rows.Err()
+}
+
+func (c *sqlClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *sqlClient) Close() error {
+	return c.db.Close()
+}
+
+func (c *sqlClient) table(dataset Dataset) (string, error) {
+	name, err := dataset.name()
+	if err != nil {
+		return "", err
+	}
+	return c.dialect.table(name), nil
+}
+
+func unqualifiedTable(name string) string {
+	return name
+}
+
+func dollarPlaceholder(i int) string {
+	return fmt.Sprintf("$%d", i)
+}
@@ -0,0 +1,45 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	_ "github.com/microsoft/go-mssqldb" // registers the sqlserver driver
+)
+
+const (
+	sqlServerPort     = 1433
+	sqlServerDatabase = "pds"
+)
+
+var sqlServerDialect = sqlDialect{
+	driverName:  "sqlserver",
+	setup:       []string{fmt.Sprintf("IF DB_ID('%[1]s') IS NULL CREATE DATABASE %[1]s", sqlServerDatabase)},
+	isPrimary:   "SELECT CAST(1 AS BIT)",
+	createTable: "IF OBJECT_ID(N'%[1]s', N'U') IS NULL CREATE TABLE %[1]s (record_key VARCHAR(64) PRIMARY KEY, record_value VARCHAR(64) NOT NULL)",
+	table: func(name string) string {
+		return sqlServerDatabase + ".dbo." + name
+	},
+	placeholder: func(i int) string {
+		return fmt.Sprintf("@p%d", i)
+	},
+}
+
+func newSQLServerClient(ctx context.Context, config Config) (Client, error) {
+	var dataSourceNames []string
+	for _, address := range config.localAddresses(sqlServerPort) {
+		dataSourceName := url.URL{
+			Scheme:   "sqlserver",
+			User:     url.UserPassword(config.Username, config.Password),
+			Host:     address,
+			RawQuery: url.Values{"dial timeout": {fmt.Sprint(int(dialTimeout.Seconds()))}}.Encode(),
+		}
+		dataSourceNames = append(dataSourceNames, dataSourceName.String())
+	}
+	client, err := newSQLClient(ctx, sqlServerDialect, dataSourceNames)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to SQL Server: %w", err)
+	}
+	return client, nil
+}
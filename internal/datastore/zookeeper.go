@@ -0,0 +1,126 @@
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+const (
+	zooKeeperPort           = 2181
+	zooKeeperRoot           = "/pds-integration"
+	zooKeeperSessionTimeout = 30 * time.Second
+)
+
+type zooKeeperClient struct {
+	conn *zk.Conn
+}
+
+func newZooKeeperClient(ctx context.Context, config Config) (Client, error) {
+	conn, events, err := zk.Connect(config.localAddresses(zooKeeperPort), zooKeeperSessionTimeout, zk.WithLogInfo(false))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to ZooKeeper: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	for connected := false; !connected; {
+		select {
+		case event := <-events:
+			connected = event.State == zk.StateHasSession
+		case <-ctx.Done():
+			conn.Close()
+			return nil, fmt.Errorf("waiting for ZooKeeper session: %w", ctx.Err())
+		}
+	}
+
+	if config.Username != "" {
+		if err := conn.AddAuth("digest", []byte(config.Username+":"+config.Password)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("authenticating to ZooKeeper: %w", err)
+		}
+	}
+	return &zooKeeperClient{conn: conn}, nil
+}
+
+func (c *zooKeeperClient) Write(ctx context.Context, dataset Dataset) error {
+	datasetPath, err := zooKeeperPath(dataset)
+	if err != nil {
+		return err
+	}
+	for _, p := range []string{zooKeeperRoot, datasetPath} {
+		if err := c.createOrSet(p, nil); err != nil {
+			return err
+		}
+	}
+
+	records := dataset.Records()
+	for _, key := range sortedKeys(records) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := c.createOrSet(path.Join(datasetPath, key), []byte(records[key])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *zooKeeperClient) Read(ctx context.Context, dataset Dataset) (map[string]string, error) {
+	datasetPath, err := zooKeeperPath(dataset)
+	if err != nil {
+		return nil, err
+	}
+	children, _, err := c.conn.Children(datasetPath)
+	if errors.Is(err, zk.ErrNoNode) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing children of %s: %w", datasetPath, err)
+	}
+
+	records := make(map[string]string, len(children))
+	for _, key := range children {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		value, _, err := c.conn.Get(path.Join(datasetPath, key))
+		if err != nil {
+			return nil, fmt.Errorf("getting %s: %w", path.Join(datasetPath, key), err)
+		}
+		records[key] = string(value)
+	}
+	return records, nil
+}
+
+func (c *zooKeeperClient) Verify(ctx context.Context, dataset Dataset) error {
+	return verify(ctx, c, dataset)
+}
+
+func (c *zooKeeperClient) Close() error {
+	c.conn.Close()
+	return nil
+}
+
+func (c *zooKeeperClient) createOrSet(p string, data []byte) error {
+	_, err := c.conn.Create(p, data, 0, zk.WorldACL(zk.PermAll))
+	if errors.Is(err, zk.ErrNodeExists) {
+		_, err = c.conn.Set(p, data, -1)
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", p, err)
+	}
+	return nil
+}
+
+func zooKeeperPath(dataset Dataset) (string, error) {
+	name, err := dataset.name()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(zooKeeperRoot, name), nil
+}
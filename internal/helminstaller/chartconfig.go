@@ -2,21 +2,42 @@ package helminstaller
 
 import (
 	"fmt"
-	"strings"
+	"sort"
+
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
 )
 
 type ChartConfig struct {
 	VersionConstraints string
 	ReleaseName        string
 	ChartValues        map[string]string
+	// ValuesFiles are merged in order, values of later files override values of earlier ones.
+	ValuesFiles []string
+	// SetValues are --set style overrides, e.g. image.registry=registry.example.com,
+	// applied in order on top of the values files and ChartValues.
+	SetValues []string
 }
 
-func (s *ChartConfig) CommaSeparatedChartVals() string {
-	var keyValues []string
+// MergeValues merges the values files, ChartValues sorted by key and SetValues, in this order,
+// the same way as `helm install -f ... --set ...` does.
+func (s *ChartConfig) MergeValues() (map[string]interface{}, error) {
+	keys := make([]string, 0, len(s.ChartValues))
+	for key := range s.ChartValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-	for key, value := range s.ChartValues {
-		keyValues = append(keyValues, fmt.Sprintf("%s=%s", key, value))
+	setValues := make([]string, 0, len(keys)+len(s.SetValues))
+	for _, key := range keys {
+		setValues = append(setValues, fmt.Sprintf("%s=%s", key, s.ChartValues[key]))
 	}
+	setValues = append(setValues, s.SetValues...)
 
-	return strings.Join(keyValues, ",")
+	valueOpts := &values.Options{
+		ValueFiles: s.ValuesFiles,
+		Values:     setValues,
+	}
+	// Only local values files are supported, no getters are needed.
+	return valueOpts.MergeValues(getter.Providers{})
 }
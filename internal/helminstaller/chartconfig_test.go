@@ -0,0 +1,47 @@
+package helminstaller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartConfig_MergeValues(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("image:\n  registry: docker.io\n  tag: v1\nfeatures:\n  backups: true\ntenantId: from-file\n"), 0o600))
+	overlay := filepath.Join(dir, "overlay.yaml")
+	require.NoError(t, os.WriteFile(overlay, []byte("image:\n  registry: registry.example.com\n"), 0o600))
+
+	chartConfig := ChartConfig{
+		ChartValues: map[string]string{
+			"tenantId":    "tenant",
+			"apiEndpoint": "https://example.com/api",
+		},
+		ValuesFiles: []string{base, overlay},
+		SetValues:   []string{"image.tag=v2", "features.backups=false"},
+	}
+
+	vals, err := chartConfig.MergeValues()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"image": map[string]interface{}{
+			"registry": "registry.example.com",
+			"tag":      "v2",
+		},
+		"features": map[string]interface{}{
+			"backups": false,
+		},
+		"tenantId":    "tenant",
+		"apiEndpoint": "https://example.com/api",
+	}, vals)
+}
+
+func TestChartConfig_MergeValues_MissingFile(t *testing.T) {
+	chartConfig := ChartConfig{ValuesFiles: []string{filepath.Join(t.TempDir(), "missing.yaml")}}
+	_, err := chartConfig.MergeValues()
+	assert.Error(t, err)
+}
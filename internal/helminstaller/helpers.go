@@ -23,3 +23,31 @@ func filterMatchingVersions(stringConstraints string, versions []string) ([]stri
 	}
 	return matchingVersions, nil
 }
+
+// previousReleaseVersion returns the newest version of the release line preceding the version,
+// e.g. the latest 1.19.x for 1.20.1. Pre-releases are skipped.
+func previousReleaseVersion(versions []string, version string) (string, error) {
+	current, err := semver.NewVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("parsing version %s: %w", version, err)
+	}
+
+	var previous *semver.Version
+	var previousStr string
+	for _, v := range versions {
+		ver, err := semver.NewVersion(v)
+		if err != nil || ver.Prerelease() != "" {
+			continue
+		}
+		if ver.Major() > current.Major() || (ver.Major() == current.Major() && ver.Minor() >= current.Minor()) {
+			continue
+		}
+		if previous == nil || ver.GreaterThan(previous) {
+			previous, previousStr = ver, v
+		}
+	}
+	if previous == nil {
+		return "", fmt.Errorf("no release preceding version %s found", version)
+	}
+	return previousStr, nil
+}
@@ -0,0 +1,40 @@
+package helminstaller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviousReleaseVersion(t *testing.T) {
+	versions := []string{"1.21.0-rc1", "1.20.1", "1.20.0", "1.19.3", "1.19.10", "1.19.2", "1.18.0", "v0.9.0"}
+
+	testCases := []struct {
+		version  string
+		expected string
+	}{
+		{version: "1.20.1", expected: "1.19.10"},
+		{version: "1.20.0", expected: "1.19.10"},
+		{version: "1.21.0", expected: "1.20.1"},
+		{version: "1.19.0", expected: "1.18.0"},
+		{version: "2.0.0", expected: "1.20.1"},
+		{version: "1.18.5", expected: "v0.9.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			previous, err := previousReleaseVersion(versions, tc.version)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, previous)
+		})
+	}
+}
+
+func TestPreviousReleaseVersion_NotFound(t *testing.T) {
+	_, err := previousReleaseVersion([]string{"1.20.0", "1.20.1"}, "1.20.1")
+	assert.Error(t, err)
+
+	_, err = previousReleaseVersion([]string{"1.20.0"}, "invalid")
+	assert.Error(t, err)
+}
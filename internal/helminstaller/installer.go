@@ -22,37 +22,43 @@ const (
 )
 
 type HelmArtifactProvider struct {
-	client    minihelm.Client
-	versions  []string
-	chartName string
-	repoName  string
+	client   minihelm.Client
+	versions []string
+	chartRef string
 }
 
 type InstallableHelm struct {
 	HelmArtifactProvider
 	restGetter   genericclioptions.RESTClientGetter
-	chartValues  string
+	chartValues  map[string]interface{}
 	chartVersion string
 	releaseName  string
 }
 
 func nullWriter(format string, v ...interface{}) {}
 
-func NewHelmProviderPDS(namespace string) (*HelmArtifactProvider, error) {
-	return newHelmProvider(pdsChartName, pdsRepoName, pdsRepoURL, namespace)
+func NewHelmProviderPDS(namespace string, source ChartSource) (*HelmArtifactProvider, error) {
+	return newHelmProvider(pdsChartName, pdsRepoName, pdsRepoURL, namespace, source)
 }
 
-func NewHelmProviderCertManager(namespace string) (*HelmArtifactProvider, error) {
-	return newHelmProvider(certManagerChartName, certManagerRepoName, certManagerRepoURL, namespace)
+func NewHelmProviderCertManager(namespace string, source ChartSource) (*HelmArtifactProvider, error) {
+	return newHelmProvider(certManagerChartName, certManagerRepoName, certManagerRepoURL, namespace, source)
 }
 
-func newHelmProvider(chartName, repoName, repoURL, namespace string) (*HelmArtifactProvider, error) {
+func newHelmProvider(chartName, repoName, repoURL, namespace string, source ChartSource) (*HelmArtifactProvider, error) {
 	var err error
 	var client minihelm.Client
 	if client, err = minihelm.New(&minihelm.ClientOptions{Namespace: namespace}); err != nil {
 		return nil, fmt.Errorf("creating minihelm client: %w", err)
 	}
 
+	switch {
+	case source.Path != "":
+		return newProviderFromSource(client, source.Path, client.GetLocalChartVersions)
+	case source.OCIRef != "":
+		return newProviderFromSource(client, source.OCIRef, client.GetOCIChartVersions)
+	}
+
 	if !client.HasRepoWithNameAndURL(repoName, repoURL) {
 		return nil, fmt.Errorf("repo %s not found", repoName)
 	}
@@ -64,26 +70,58 @@ func newHelmProvider(chartName, repoName, repoURL, namespace string) (*HelmArtif
 
 	versions, err := client.GetChartVersions(repoName, chartName)
 	if err != nil {
-		return nil, fmt.Errorf("getting versions of chart %s from repo %s: %w", pdsChartName, repoName, err)
+		return nil, fmt.Errorf("getting versions of chart %s from repo %s: %w", chartName, repoName, err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("repository %s does not have chart %s", repoName, chartName)
+	}
+
+	return &HelmArtifactProvider{
+		client:   client,
+		versions: versions,
+		chartRef: fmt.Sprintf("%s/%s", repoName, chartName),
+	}, nil
+}
+
+// newProviderFromSource creates a provider of a chart from a local path or an OCI registry.
+// Neither needs the repositories file nor a repository index update.
+func newProviderFromSource(client minihelm.Client, chartRef string, getVersions func(string) ([]string, error)) (*HelmArtifactProvider, error) {
+	versions, err := getVersions(chartRef)
+	if err != nil {
+		return nil, fmt.Errorf("getting versions of chart %s: %w", chartRef, err)
 	}
 	if len(versions) == 0 {
-		return nil, fmt.Errorf("repository %s does not have chart %s", repoName, pdsChartName)
+		return nil, fmt.Errorf("no versions of chart %s found", chartRef)
 	}
 
 	return &HelmArtifactProvider{
-		client:    client,
-		versions:  versions,
-		repoName:  repoName,
-		chartName: chartName,
+		client:   client,
+		versions: versions,
+		chartRef: chartRef,
 	}, nil
 }
 
+// Versions returns all available versions of the chart.
+func (p *HelmArtifactProvider) Versions() []string {
+	return p.versions
+}
+
+// PreviousReleaseVersion returns the newest available chart version of the release line preceding the version.
+func (p *HelmArtifactProvider) PreviousReleaseVersion(version string) (string, error) {
+	return previousReleaseVersion(p.versions, version)
+}
+
 func (p *HelmArtifactProvider) InstallerFromRestCfg(cfg *rest.Config, chartConfig ChartConfig, namespace string) (*InstallableHelm, error) {
 	matchingVersions, err := filterMatchingVersions(chartConfig.VersionConstraints, p.versions)
 	if err != nil {
 		return nil, err
 	}
 
+	chartValues, err := chartConfig.MergeValues()
+	if err != nil {
+		return nil, fmt.Errorf("merging chart values: %w", err)
+	}
+
 	return &InstallableHelm{
 		HelmArtifactProvider: *p,
 		chartVersion:         matchingVersions[0],
@@ -92,7 +130,7 @@ func (p *HelmArtifactProvider) InstallerFromRestCfg(cfg *rest.Config, chartConfi
 			WithPersistent(true),
 			WithNamespace(namespace),
 		),
-		chartValues: chartConfig.CommaSeparatedChartVals(),
+		chartValues: chartValues,
 		releaseName: chartConfig.ReleaseName,
 	}, nil
 }
@@ -106,21 +144,26 @@ func (p *HelmArtifactProvider) Installer(kubeconfig string, chartConfig ChartCon
 		return nil, err
 	}
 
+	chartValues, err := chartConfig.MergeValues()
+	if err != nil {
+		return nil, fmt.Errorf("merging chart values: %w", err)
+	}
+
 	return &InstallableHelm{
 		HelmArtifactProvider: *p,
 		chartVersion:         matchingVersions[0],
 		restGetter:           restClientGetter,
-		chartValues:          chartConfig.CommaSeparatedChartVals(),
+		chartValues:          chartValues,
 		releaseName:          chartConfig.ReleaseName,
 	}, nil
 }
 
 func (i *InstallableHelm) Install(ctx context.Context) error {
-	return i.client.InstallChart(ctx, i.restGetter, i.repoName, i.releaseName, i.chartName, i.chartVersion, i.chartValues, nullWriter)
+	return i.client.InstallChart(ctx, i.restGetter, i.chartRef, i.releaseName, i.chartVersion, i.chartValues, nullWriter)
 }
 
 func (i *InstallableHelm) Upgrade(ctx context.Context) error {
-	return i.client.UpgradeChart(ctx, i.restGetter, i.repoName, i.releaseName, i.chartName, i.chartVersion, i.chartValues, nullWriter)
+	return i.client.UpgradeChart(ctx, i.restGetter, i.chartRef, i.releaseName, i.chartVersion, i.chartValues, nullWriter)
 }
 
 func (i *InstallableHelm) Version() string {
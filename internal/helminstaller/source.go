@@ -0,0 +1,28 @@
+package helminstaller
+
+import (
+	"strings"
+)
+
+const ociPrefix = "oci://"
+
+// ChartSource selects where a chart is installed from.
+// The zero value installs the chart from its Helm repository, which has to be present in the repositories file.
+type ChartSource struct {
+	// Path is a local chart directory or a packaged .tgz chart.
+	Path string
+	// OCIRef is a chart reference in an OCI registry, e.g. oci://registry.example.com/charts/pds-target.
+	OCIRef string
+}
+
+// ParseChartSource parses an oci:// reference or a local chart path. An empty string selects the Helm repository.
+func ParseChartSource(source string) ChartSource {
+	switch {
+	case source == "":
+		return ChartSource{}
+	case strings.HasPrefix(source, ociPrefix):
+		return ChartSource{OCIRef: source}
+	default:
+		return ChartSource{Path: source}
+	}
+}
@@ -0,0 +1,42 @@
+package helminstaller
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChartSource(t *testing.T) {
+	assert.Equal(t, ChartSource{}, ParseChartSource(""))
+	assert.Equal(t, ChartSource{Path: "./charts/pds-target"}, ParseChartSource("./charts/pds-target"))
+	assert.Equal(t, ChartSource{Path: "/tmp/pds-target-1.0.0.tgz"}, ParseChartSource("/tmp/pds-target-1.0.0.tgz"))
+	assert.Equal(t, ChartSource{OCIRef: "oci://registry.example.com/charts/pds-target"}, ParseChartSource("oci://registry.example.com/charts/pds-target"))
+}
+
+func TestNewHelmProvider_LocalChart(t *testing.T) {
+	// No repositories file is needed for a local chart.
+	helmHome := t.TempDir()
+	t.Setenv("HELM_REPOSITORY_CONFIG", filepath.Join(helmHome, "repositories.yaml"))
+	t.Setenv("HELM_REPOSITORY_CACHE", filepath.Join(helmHome, "cache"))
+	t.Setenv("HELM_REGISTRY_CONFIG", filepath.Join(helmHome, "registry.json"))
+
+	chartDir := filepath.Join(t.TempDir(), pdsChartName)
+	require.NoError(t, os.MkdirAll(chartDir, 0o755))
+	chartYAML := "apiVersion: v2\nname: pds-target\nversion: 1.2.3\n"
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte(chartYAML), 0o600))
+
+	provider, err := NewHelmProviderPDS("pds-system", ChartSource{Path: chartDir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.2.3"}, provider.versions)
+	assert.Equal(t, chartDir, provider.chartRef)
+
+	installer, err := provider.InstallerFromRestCfg(nil, ChartConfig{VersionConstraints: "1.2.3"}, "pds-system")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", installer.Version())
+
+	_, err = provider.InstallerFromRestCfg(nil, ChartConfig{VersionConstraints: "1.3.0"}, "pds-system")
+	assert.Error(t, err)
+}
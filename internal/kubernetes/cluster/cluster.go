@@ -3,7 +3,6 @@ package cluster
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -23,8 +22,10 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/utils/pointer"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/external-dns/endpoint"
@@ -43,18 +44,18 @@ const (
 
 type Cluster struct {
 	config            *rest.Config
-	Clientset         *kubernetes.Clientset
+	Clientset         kubernetes.Interface
 	MetaClient        metadata.Interface
 	CtrlRuntimeClient ctrlclient.Client
-	OpenStorageClient *openstorage.Clientset
+	OpenStorageClient openstorage.Interface
 }
 
 func NewCluster(
 	config *rest.Config,
-	clientset *kubernetes.Clientset,
+	clientset kubernetes.Interface,
 	metaClient metadata.Interface,
 	ctrlRuntimeClient ctrlclient.Client,
-	openStorageClient *openstorage.Clientset,
+	openStorageClient openstorage.Interface,
 ) (*Cluster, error) {
 	return &Cluster{
 		config:            config,
@@ -69,6 +70,33 @@ func (c *Cluster) PortforwardPod(namespace, name string, port int) (*portforward
 	return portforward.New(c.Clientset, c.config, namespace, name, port)
 }
 
+// ExecInPod runs the command in the pod container and returns its standard output and error.
+func (c *Cluster) ExecInPod(ctx context.Context, namespace, pod, container string, command []string) (string, string, error) {
+	req := c.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.config, "POST", req.URL())
+	if err != nil {
+		return "", "", fmt.Errorf("creating executor for pod %s/%s: %w", namespace, pod, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	return stdout.String(), stderr.String(), err
+}
+
 func (c *Cluster) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
 	return c.Clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 }
@@ -99,6 +127,12 @@ func (c *Cluster) ListDeployments(ctx context.Context, namespace string, labelSe
 	})
 }
 
+func (c *Cluster) ListPersistentVolumeClaims(ctx context.Context, namespace string, labelSelector map[string]string) (*corev1.PersistentVolumeClaimList, error) {
+	return c.Clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.FormatLabels(labelSelector),
+	})
+}
+
 func (c *Cluster) DeletePodsBySelector(ctx context.Context, namespace string, labelSelector map[string]string) error {
 	listOptions := metav1.ListOptions{}
 	if len(labelSelector) > 0 {
@@ -109,22 +143,20 @@ func (c *Cluster) DeletePodsBySelector(ctx context.Context, namespace string, la
 
 func (c *Cluster) GetPDSBackup(ctx context.Context, namespace, name string) (*backupsv1.Backup, error) {
 	result := &backupsv1.Backup{}
-	path := fmt.Sprintf("apis/backups.pds.io/v1/namespaces/%s/backups/%s", namespace, name)
-	err := c.Clientset.RESTClient().Get().AbsPath(path).Do(ctx).Into(result)
+	err := c.CtrlRuntimeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: name}, result)
 	return result, err
 }
 
 func (c *Cluster) GetVolumeSnapshot(ctx context.Context, namespace, name string) (*volumesnapshotv1.VolumeSnapshot, error) {
 	snapshot := &volumesnapshotv1.VolumeSnapshot{}
 	path := fmt.Sprintf("apis/volumesnapshot.external-storage.k8s.io/v1/namespaces/%s/volumesnapshots/%s", namespace, name)
-	err := c.Clientset.RESTClient().Get().AbsPath(path).Do(ctx).Into(snapshot)
+	err := c.Clientset.Discovery().RESTClient().Get().AbsPath(path).Do(ctx).Into(snapshot)
 	return snapshot, err
 }
 
 func (c *Cluster) GetPDSBackupJob(ctx context.Context, namespace, name string) (*backupsv1.BackupJob, error) {
 	result := &backupsv1.BackupJob{}
-	path := fmt.Sprintf("apis/backups.pds.io/v1/namespaces/%s/backupjobs/%s", namespace, name)
-	err := c.Clientset.RESTClient().Get().AbsPath(path).Do(ctx).Into(result)
+	err := c.CtrlRuntimeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: name}, result)
 	return result, err
 }
 
@@ -144,58 +176,51 @@ func (c *Cluster) CreatePDSRestore(ctx context.Context, namespace, name, credent
 			PXCloudSnapID:       snapID,
 		},
 	}
-	raw, err := json.Marshal(body)
+	err := c.CtrlRuntimeClient.Create(ctx, body)
 	if err != nil {
 		return nil, err
 	}
-	result := &backupsv1.Restore{}
-	path := fmt.Sprintf("apis/backups.pds.io/v1/namespaces/%s/restores", namespace)
-	err = c.Clientset.RESTClient().Post().AbsPath(path).Body(raw).Do(ctx).Into(result)
-	return result, err
+	return body, nil
 }
 
 func (c *Cluster) GetPDSRestore(ctx context.Context, namespace, name string) (*backupsv1.Restore, error) {
 	result := &backupsv1.Restore{}
-	path := fmt.Sprintf("apis/backups.pds.io/v1/namespaces/%s/restores/%s", namespace, name)
-	err := c.Clientset.RESTClient().Get().AbsPath(path).Do(ctx).Into(result)
+	err := c.CtrlRuntimeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: name}, result)
 	return result, err
 }
 
 func (c *Cluster) DeletePDSRestore(ctx context.Context, namespace, name string) error {
-	path := fmt.Sprintf("apis/backups.pds.io/v1/namespaces/%s/restores/%s", namespace, name)
-	err := c.Clientset.RESTClient().Delete().AbsPath(path).Do(ctx).Error()
-	return err
+	restore := &backupsv1.Restore{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	return c.CtrlRuntimeClient.Delete(ctx, restore)
 }
 
 func (c *Cluster) DeletePDSBackup(ctx context.Context, namespace, name string) error {
-	path := fmt.Sprintf("apis/backups.pds.io/v1/namespaces/%s/backups/%s", namespace, name)
-	err := c.Clientset.RESTClient().Delete().AbsPath(path).Do(ctx).Error()
-	return err
+	backup := &backupsv1.Backup{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	return c.CtrlRuntimeClient.Delete(ctx, backup)
 }
 
 func (c *Cluster) GetPDSDeployment(ctx context.Context, namespace, database, name string) (runtime.Object, error) {
 	path := fmt.Sprintf("apis/deployments.pds.io/v1/namespaces/%s/%s/%s", namespace, database, name)
-	res, err := c.Clientset.RESTClient().Get().AbsPath(path).Do(ctx).Get()
+	res, err := c.Clientset.Discovery().RESTClient().Get().AbsPath(path).Do(ctx).Get()
 	return res, err
 }
 
 func (c *Cluster) DeletePDSDeployment(ctx context.Context, namespace, database, name string) error {
 	path := fmt.Sprintf("apis/deployments.pds.io/v1/namespaces/%s/%s/%s", namespace, database, name)
-	err := c.Clientset.RESTClient().Delete().AbsPath(path).Do(ctx).Error()
+	err := c.Clientset.Discovery().RESTClient().Delete().AbsPath(path).Do(ctx).Error()
 	return err
 }
 
 func (c *Cluster) GetPDSDatabase(ctx context.Context, namespace, name string) (*deploymentsv1.Database, error) {
 	result := &deploymentsv1.Database{}
-	path := fmt.Sprintf("apis/deployments.pds.io/v1/namespaces/%s/databases/%s", namespace, name)
-	err := c.Clientset.RESTClient().Get().AbsPath(path).Do(ctx).Into(result)
+	err := c.CtrlRuntimeClient.Get(ctx, ctrlclient.ObjectKey{Namespace: namespace, Name: name}, result)
 	return result, err
 }
 
 func (c *Cluster) GetDNSEndpoints(ctx context.Context, namespace, nameFilter string, recordTypeFilter string) ([]string, error) {
 	// Query DNSEndpoints.
 	endpointList := &endpoint.DNSEndpointList{}
-	err := c.Clientset.RESTClient().Get().
+	err := c.Clientset.Discovery().RESTClient().Get().
 		AbsPath(fmt.Sprintf("apis/externaldns.k8s.io/v1alpha1/namespaces/%s/dnsendpoints", namespace)).
 		Param("labelSelector", fmt.Sprintf("name=%s", nameFilter)).
 		Do(ctx).
@@ -367,6 +392,12 @@ func (c *Cluster) CreateLoadTestServiceAccount(ctx context.Context, namespace st
 }
 
 func (c *Cluster) CreateJob(ctx context.Context, namespace, jobName, image string, env []corev1.EnvVar, command []string, ttlSecondsAfterFinished *int32, backOffLimit *int32) (*batchv1.Job, error) {
+	return c.CreateParallelJob(ctx, namespace, jobName, image, env, command, nil, nil, ttlSecondsAfterFinished, backOffLimit)
+}
+
+// CreateParallelJob creates a job which runs pods until the given number of completions succeeded, at most parallelism
+// pods at a time. Nil completions and parallelism run a single pod.
+func (c *Cluster) CreateParallelJob(ctx context.Context, namespace, jobName, image string, env []corev1.EnvVar, command []string, completions, parallelism *int32, ttlSecondsAfterFinished *int32, backOffLimit *int32) (*batchv1.Job, error) {
 	jobs := c.Clientset.BatchV1().Jobs(namespace)
 	spec := corev1.PodSpec{
 		Containers: []corev1.Container{
@@ -412,6 +443,8 @@ func (c *Cluster) CreateJob(ctx context.Context, namespace, jobName, image strin
 			Template: corev1.PodTemplateSpec{
 				Spec: spec,
 			},
+			Completions:             completions,
+			Parallelism:             parallelism,
 			BackoffLimit:            backOffLimit,
 			TTLSecondsAfterFinished: ttlSecondsAfterFinished,
 		},
@@ -478,10 +511,10 @@ func (c *Cluster) GetPodLogs(ctx context.Context, pod *corev1.Pod, since time.Ti
 	}
 	req := c.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOpts)
 	podLogs, err := req.Stream(ctx)
-	defer func() { _ = podLogs.Close() }()
 	if err != nil {
 		return "", err
 	}
+	defer func() { _ = podLogs.Close() }()
 
 	buf := new(bytes.Buffer)
 	_, err = io.Copy(buf, podLogs)
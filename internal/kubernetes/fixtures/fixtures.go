@@ -0,0 +1,124 @@
+// Package fixtures provides builders of Kubernetes and PDS objects for unit tests using a fake target cluster.
+package fixtures
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	backupsv1 "github.com/portworx/pds-operator-backups/api/v1"
+	deploymentsv1 "github.com/portworx/pds-operator-deployments/api/v1"
+)
+
+const deploymentIDLabel = "pds/deployment-id"
+
+// StatefulSet returns a stateful set of a PDS deployment with the given number of desired and ready replicas.
+func StatefulSet(namespace, name, deploymentID string, replicas, readyReplicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: objectMeta(namespace, name, deploymentID),
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(replicas),
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        replicas,
+			ReadyReplicas:   readyReplicas,
+			UpdatedReplicas: readyReplicas,
+		},
+	}
+}
+
+// Job returns a job with the given number of desired and succeeded completions.
+// If completions is nil, the job completes after a single successful pod.
+func Job(namespace, name string, completions *int32, succeeded int32) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: batchv1.JobSpec{
+			Completions: completions,
+		},
+		Status: batchv1.JobStatus{
+			Succeeded: succeeded,
+		},
+	}
+}
+
+// JobPod returns a pod created by the job, e.g. to serve its logs by targetcluster.SetFakePodLogs.
+func JobPod(namespace, name, jobName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				"job-name": jobName,
+			},
+		},
+	}
+}
+
+// Database returns a Database custom resource of a PDS deployment with the given health and number of ready replicas.
+func Database(namespace, name, deploymentID string, replicas, readyReplicas int32, health deploymentsv1.DataServiceHealth) *deploymentsv1.Database {
+	return &deploymentsv1.Database{
+		ObjectMeta: objectMeta(namespace, name, deploymentID),
+		Spec: deploymentsv1.DatabaseSpec{
+			StatefulSet: appsv1.StatefulSetSpec{
+				Replicas: pointer.Int32(replicas),
+			},
+		},
+		Status: deploymentsv1.DatabaseStatus{
+			DataServiceStatus: deploymentsv1.DataServiceStatus{
+				Replicas:      replicas,
+				ReadyReplicas: readyReplicas,
+				Health:        health,
+				Initialized:   deploymentsv1.InitializedTypeStatusYes,
+			},
+		},
+	}
+}
+
+// Backup returns a Backup custom resource of a PDS deployment. The status counters are derived from the job statuses.
+func Backup(namespace, name, deploymentID string, jobs ...backupsv1.InlineBackupJobStatus) *backupsv1.Backup {
+	backup := &backupsv1.Backup{
+		ObjectMeta: objectMeta(namespace, name, deploymentID),
+		Spec: backupsv1.BackupSpec{
+			DeploymentID: deploymentID,
+			Type:         "adhoc",
+		},
+		Status: backupsv1.BackupStatus{
+			BackupJobs: jobs,
+		},
+	}
+	for _, job := range jobs {
+		switch job.CompletionStatus {
+		case backupsv1.BackupJobActive:
+			backup.Status.Active++
+		case backupsv1.BackupJobSucceeded:
+			backup.Status.Succeeded++
+		case backupsv1.BackupJobFailed:
+			backup.Status.Failed++
+		}
+	}
+	return backup
+}
+
+// BackupJobStatus returns a status of a backup job as reported in the Backup custom resource.
+func BackupJobStatus(name string, status backupsv1.BackupJobCompletionStatus, cloudSnapID string) backupsv1.InlineBackupJobStatus {
+	return backupsv1.InlineBackupJobStatus{
+		Name:             name,
+		CompletionStatus: status,
+		CloudSnapID:      cloudSnapID,
+	}
+}
+
+func objectMeta(namespace, name, deploymentID string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		Labels: map[string]string{
+			deploymentIDLabel: deploymentID,
+		},
+	}
+}
@@ -0,0 +1,18 @@
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+// NewFakeTargetCluster returns a target cluster with fake clients serving the objects, see
+// targetcluster.NewFakeTargetCluster.
+func NewFakeTargetCluster(t *testing.T, objects ...runtime.Object) *targetcluster.TargetCluster {
+	tc, err := targetcluster.NewFakeTargetCluster(objects...)
+	require.NoError(t, err)
+	return tc
+}
@@ -0,0 +1,54 @@
+package psa
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// evaluator runs the checks of the upstream pod-security-admission policy library, the same checks as enforced
+// by the PodSecurity admission controller: https://kubernetes.io/docs/concepts/security/pod-security-standards/
+var evaluator = mustNewEvaluator()
+
+func mustNewEvaluator() policy.Evaluator {
+	e, err := policy.NewEvaluator(policy.DefaultChecks())
+	if err != nil {
+		panic(fmt.Sprintf("creating pod security evaluator: %v", err))
+	}
+	return e
+}
+
+// Violation is a Pod Security Standards check forbidding the pod spec, e.g. reason "host namespaces"
+// with detail "hostNetwork=true".
+type Violation struct {
+	Reason string
+	Detail string
+}
+
+func (v Violation) String() string {
+	if v.Detail == "" {
+		return v.Reason
+	}
+	return fmt.Sprintf("%s (%s)", v.Reason, v.Detail)
+}
+
+// AuditPodSpec evaluates the pod metadata and spec against the latest version of the baseline or restricted level.
+// The privileged level allows everything.
+func AuditPodSpec(level string, podMeta metav1.ObjectMeta, spec corev1.PodSpec) ([]Violation, error) {
+	parsedLevel, err := api.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	levelVersion := api.LevelVersion{Level: parsedLevel, Version: api.LatestVersion()}
+
+	var violations []Violation
+	for _, result := range evaluator.EvaluatePod(levelVersion, &podMeta, &spec) {
+		if !result.Allowed {
+			violations = append(violations, Violation{Reason: result.ForbiddenReason, Detail: result.ForbiddenDetail})
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,116 @@
+package psa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func restrictedPodSpec() corev1.PodSpec {
+	return corev1.PodSpec{
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsNonRoot:   pointer.Bool(true),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+		Containers: []corev1.Container{{
+			Name: "db",
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: pointer.Bool(false),
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			},
+		}},
+		Volumes: []corev1.Volume{{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}},
+		}},
+	}
+}
+
+func TestAuditPodSpec(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    string
+		modify   func(spec *corev1.PodSpec)
+		expected []Violation
+	}{
+		{
+			name:   "restricted pod passes restricted",
+			level:  PSAPolicyRestricted,
+			modify: func(spec *corev1.PodSpec) {},
+		},
+		{
+			name:  "privileged container violates baseline",
+			level: PSAPolicyBaseline,
+			modify: func(spec *corev1.PodSpec) {
+				spec.HostNetwork = true
+				spec.Containers[0].SecurityContext.Privileged = pointer.Bool(true)
+				spec.Containers[0].SecurityContext.Capabilities.Add = []corev1.Capability{"SYS_ADMIN"}
+			},
+			expected: []Violation{
+				{Reason: "non-default capabilities", Detail: `container "db" must not include "SYS_ADMIN" in securityContext.capabilities.add`},
+				{Reason: "host namespaces", Detail: "hostNetwork=true"},
+				{Reason: "privileged", Detail: `container "db" must not set securityContext.privileged=true`},
+			},
+		},
+		{
+			name:  "baseline pod violates restricted",
+			level: PSAPolicyRestricted,
+			modify: func(spec *corev1.PodSpec) {
+				spec.SecurityContext = nil
+				spec.InitContainers = []corev1.Container{{Name: "init"}}
+				spec.Volumes = append(spec.Volumes, corev1.Volume{
+					Name:         "nfs",
+					VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/"}},
+				})
+			},
+			expected: []Violation{
+				{Reason: "allowPrivilegeEscalation != false", Detail: `container "init" must set securityContext.allowPrivilegeEscalation=false`},
+				{Reason: "unrestricted capabilities", Detail: `container "init" must set securityContext.capabilities.drop=["ALL"]`},
+				{Reason: "restricted volume types", Detail: `volume "nfs" uses restricted volume type "nfs"`},
+				{Reason: "runAsNonRoot != true", Detail: `pod or containers "init", "db" must set securityContext.runAsNonRoot=true`},
+				{Reason: "seccompProfile", Detail: `pod or containers "init", "db" must set securityContext.seccompProfile.type to "RuntimeDefault" or "Localhost"`},
+			},
+		},
+		{
+			name:  "privileged level allows host path",
+			level: PSAPolicyPrivileged,
+			modify: func(spec *corev1.PodSpec) {
+				spec.Volumes[0].VolumeSource = corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := restrictedPodSpec()
+			tc.modify(&spec)
+
+			violations, err := AuditPodSpec(tc.level, metav1.ObjectMeta{}, spec)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, violations)
+		})
+	}
+}
+
+func TestAuditPodSpec_UnknownLevel(t *testing.T) {
+	_, err := AuditPodSpec("unknown", metav1.ObjectMeta{}, corev1.PodSpec{})
+	assert.Error(t, err)
+}
+
+func TestReport(t *testing.T) {
+	report := NewReport(PSAPolicyRestricted)
+	spec := restrictedPodSpec()
+	require.NoError(t, report.Audit("Pod", metav1.ObjectMeta{Namespace: "ns", Name: "ok"}, metav1.ObjectMeta{}, spec))
+	assert.False(t, report.HasViolations())
+
+	spec.HostPID = true
+	require.NoError(t, report.Audit("Job", metav1.ObjectMeta{Namespace: "ns", Name: "bad"}, metav1.ObjectMeta{}, spec))
+	assert.True(t, report.HasViolations())
+	assert.Contains(t, report.String(), "Job ns/bad")
+	assert.Contains(t, report.String(), "host namespaces (hostPID=true)")
+	assert.NotContains(t, report.String(), "Pod ns/ok")
+}
@@ -0,0 +1,67 @@
+package psa
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkloadAudit is the audit result of the pod spec of a single pod or pod template.
+type WorkloadAudit struct {
+	Kind       string
+	Namespace  string
+	Name       string
+	Violations []Violation
+}
+
+// Report collects audit results of multiple workloads against a single level.
+type Report struct {
+	Level     string
+	Workloads []WorkloadAudit
+}
+
+func NewReport(level string) *Report {
+	return &Report{Level: level}
+}
+
+// Audit evaluates the pod spec of the workload and adds the result to the report.
+func (r *Report) Audit(kind string, workload metav1.ObjectMeta, podMeta metav1.ObjectMeta, spec corev1.PodSpec) error {
+	violations, err := AuditPodSpec(r.Level, podMeta, spec)
+	if err != nil {
+		return err
+	}
+	r.Workloads = append(r.Workloads, WorkloadAudit{
+		Kind:       kind,
+		Namespace:  workload.Namespace,
+		Name:       workload.Name,
+		Violations: violations,
+	})
+	return nil
+}
+
+// HasViolations returns true if any of the audited workloads violates the level.
+func (r *Report) HasViolations() bool {
+	for _, workload := range r.Workloads {
+		if len(workload.Violations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Pod security %s audit of %d workloads:\n", r.Level, len(r.Workloads))
+	for _, workload := range r.Workloads {
+		if len(workload.Violations) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "- %s %s/%s:\n", workload.Kind, workload.Namespace, workload.Name)
+		for _, violation := range workload.Violations {
+			fmt.Fprintf(&sb, "  * %s\n", violation)
+		}
+	}
+	return sb.String()
+}
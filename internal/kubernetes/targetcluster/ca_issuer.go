@@ -0,0 +1,171 @@
+package targetcluster
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// caValidity is long enough for any test run, but short enough to not leave long-lived CAs behind.
+const caValidity = 7 * 24 * time.Hour
+
+// GenerateCA generates a self-signed root CA and returns its PEM encoded certificate and private key.
+func GenerateCA(commonName string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"pds-integration-test"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// CAIssuerSecretName returns the name of the secret holding the CA of the CA cluster issuer.
+func CAIssuerSecretName(issuerName string) string {
+	return issuerName + "-ca"
+}
+
+// CreateCAClusterIssuer generates a root CA, stores it in the cert-manager namespace
+// and creates a CA cluster issuer signing certificates with it.
+func (tc *TargetCluster) CreateCAClusterIssuer(ctx context.Context, name string) error {
+	secret, err := newCASecret(name)
+	if err != nil {
+		return err
+	}
+	_, err = tc.Clientset.CoreV1().Secrets(CertManagerNamespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("creating CA secret of cluster issuer %s: %w", name, err)
+	}
+
+	return tc.CreateClusterIssuer(ctx, &certmanagerv1.ClusterIssuer{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterIssuer",
+			APIVersion: "cert-manager.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: certmanagerv1.IssuerSpec{
+			IssuerConfig: certmanagerv1.IssuerConfig{
+				CA: &certmanagerv1.CAIssuer{SecretName: secret.Name},
+			},
+		},
+	})
+}
+
+// RotateClusterIssuerCA replaces the CA of the CA cluster issuer with a newly generated one.
+// Already issued certificates are signed by the new CA only after they are renewed.
+func (tc *TargetCluster) RotateClusterIssuerCA(ctx context.Context, name string) error {
+	rotated, err := newCASecret(name)
+	if err != nil {
+		return err
+	}
+	secret, err := tc.GetSecret(ctx, CertManagerNamespace, rotated.Name)
+	if err != nil {
+		return err
+	}
+	secret.Data = rotated.Data
+	_, err = tc.Clientset.CoreV1().Secrets(CertManagerNamespace).Update(ctx, secret, metav1.UpdateOptions{})
+	return err
+}
+
+// DeleteCAClusterIssuer deletes the CA cluster issuer and its CA secret.
+func (tc *TargetCluster) DeleteCAClusterIssuer(ctx context.Context, name string) error {
+	err := tc.DeleteClusterIssuer(ctx, &certmanagerv1.ClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	if err != nil {
+		return err
+	}
+	return tc.Clientset.CoreV1().Secrets(CertManagerNamespace).Delete(ctx, CAIssuerSecretName(name), metav1.DeleteOptions{})
+}
+
+// GetCertificate gets the cert-manager certificate.
+func (tc *TargetCluster) GetCertificate(ctx context.Context, namespace, name string) (*certmanagerv1.Certificate, error) {
+	certificate := &certmanagerv1.Certificate{}
+	err := tc.CtrlRuntimeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, certificate)
+	return certificate, err
+}
+
+// RenewCertificate triggers a manual renewal of the certificate, the same way as `cmctl renew` does.
+func (tc *TargetCluster) RenewCertificate(ctx context.Context, namespace, name string) error {
+	certificate, err := tc.GetCertificate(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	issuing := certmanagerv1.CertificateCondition{
+		Type:               certmanagerv1.CertificateConditionIssuing,
+		Status:             cmmeta.ConditionTrue,
+		Reason:             "ManuallyTriggered",
+		Message:            "Certificate re-issuance manually triggered by pds-integration-test",
+		LastTransitionTime: &now,
+		ObservedGeneration: certificate.Generation,
+	}
+	replaced := false
+	for i, condition := range certificate.Status.Conditions {
+		if condition.Type == certmanagerv1.CertificateConditionIssuing {
+			certificate.Status.Conditions[i] = issuing
+			replaced = true
+		}
+	}
+	if !replaced {
+		certificate.Status.Conditions = append(certificate.Status.Conditions, issuing)
+	}
+	return tc.CtrlRuntimeClient.Status().Update(ctx, certificate)
+}
+
+func newCASecret(issuerName string) (*corev1.Secret, error) {
+	certPEM, keyPEM, err := GenerateCA(issuerName + " root CA")
+	if err != nil {
+		return nil, fmt.Errorf("generating CA of cluster issuer %s: %w", issuerName, err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CAIssuerSecretName(issuerName),
+			Namespace: CertManagerNamespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+			cmmeta.TLSCAKey:         certPEM,
+		},
+	}, nil
+}
@@ -0,0 +1,76 @@
+package targetcluster_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+func TestCAClusterIssuer(t *testing.T) {
+	ctx := context.Background()
+	tc, err := targetcluster.NewFakeTargetCluster()
+	require.NoError(t, err)
+
+	err = tc.CreateCAClusterIssuer(ctx, "issuer")
+	require.NoError(t, err)
+
+	issuer, err := tc.GetClusterIssuer(ctx, "issuer")
+	require.NoError(t, err)
+	require.NotNil(t, issuer.Spec.CA)
+	assert.Equal(t, targetcluster.CAIssuerSecretName("issuer"), issuer.Spec.CA.SecretName)
+
+	ca, err := tc.GetClusterIssuerCA(ctx, "issuer")
+	require.NoError(t, err)
+	cert := parseCertificate(t, ca)
+	assert.True(t, cert.IsCA)
+
+	err = tc.RotateClusterIssuerCA(ctx, "issuer")
+	require.NoError(t, err)
+	rotated, err := tc.GetClusterIssuerCA(ctx, "issuer")
+	require.NoError(t, err)
+	assert.NotEqual(t, cert.SerialNumber, parseCertificate(t, rotated).SerialNumber)
+
+	err = tc.DeleteCAClusterIssuer(ctx, "issuer")
+	require.NoError(t, err)
+	_, err = tc.GetClusterIssuer(ctx, "issuer")
+	assert.Error(t, err)
+}
+
+func TestRenewCertificate(t *testing.T) {
+	ctx := context.Background()
+	tc, err := targetcluster.NewFakeTargetCluster(&certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pg-tls"},
+		Status: certmanagerv1.CertificateStatus{
+			Conditions: []certmanagerv1.CertificateCondition{
+				{Type: certmanagerv1.CertificateConditionReady, Status: cmmeta.ConditionTrue},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	err = tc.RenewCertificate(ctx, "ns", "pg-tls")
+	require.NoError(t, err)
+
+	certificate, err := tc.GetCertificate(ctx, "ns", "pg-tls")
+	require.NoError(t, err)
+	require.Len(t, certificate.Status.Conditions, 2)
+	assert.Equal(t, certmanagerv1.CertificateConditionIssuing, certificate.Status.Conditions[1].Type)
+	assert.Equal(t, cmmeta.ConditionTrue, certificate.Status.Conditions[1].Status)
+}
+
+func parseCertificate(t *testing.T, certPEM []byte) *x509.Certificate {
+	block, _ := pem.Decode(certPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
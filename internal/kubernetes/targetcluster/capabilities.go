@@ -13,3 +13,18 @@ func (tc *TargetCluster) GetOperatorCapabilities(ctx context.Context, namespace
 	}
 	return configMap.Data, nil
 }
+
+// GetPDSCapabilities returns the capabilities exported by all PDS chart operators.
+func (tc *TargetCluster) GetPDSCapabilities(ctx context.Context) (map[string]string, error) {
+	capabilities := make(map[string]string)
+	for _, operator := range PDSOperators {
+		operatorCapabilities, err := tc.GetOperatorCapabilities(ctx, PDSChartNamespace, operator.Name)
+		if err != nil {
+			return nil, fmt.Errorf("getting capabilities of %s operator: %w", operator.Name, err)
+		}
+		for name, version := range operatorCapabilities {
+			capabilities[name] = version
+		}
+	}
+	return capabilities, nil
+}
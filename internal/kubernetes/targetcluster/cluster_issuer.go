@@ -2,9 +2,13 @@ package targetcluster
 
 import (
 	"context"
+	"fmt"
 
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (tc *TargetCluster) CreateClusterIssuer(ctx context.Context, clusterIssuer *certmanagerv1.ClusterIssuer) error {
@@ -24,3 +28,35 @@ func (tc *TargetCluster) UpdateClusterIssuer(ctx context.Context, clusterIssuer
 func (tc *TargetCluster) DeleteClusterIssuer(ctx context.Context, clusterIssuer *certmanagerv1.ClusterIssuer) error {
 	return tc.CtrlRuntimeClient.Delete(ctx, clusterIssuer)
 }
+
+// GetClusterIssuerCA returns the PEM encoded CA certificate of a CA cluster issuer.
+// The CA secret of a cluster issuer is stored in the cert-manager namespace.
+func (tc *TargetCluster) GetClusterIssuerCA(ctx context.Context, name string) ([]byte, error) {
+	clusterIssuer, err := tc.GetClusterIssuer(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if clusterIssuer.Spec.CA == nil {
+		return nil, fmt.Errorf("cluster issuer %s is not a CA issuer", name)
+	}
+
+	secret, err := tc.GetSecret(ctx, CertManagerNamespace, clusterIssuer.Spec.CA.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	ca := secret.Data[cmmeta.TLSCAKey]
+	if len(ca) == 0 {
+		ca = secret.Data[corev1.TLSCertKey]
+	}
+	if len(ca) == 0 {
+		return nil, fmt.Errorf("secret %s/%s of cluster issuer %s has no CA certificate", CertManagerNamespace, clusterIssuer.Spec.CA.SecretName, name)
+	}
+	return ca, nil
+}
+
+// ListCertificates lists cert-manager certificates in the namespace.
+func (tc *TargetCluster) ListCertificates(ctx context.Context, namespace string) (*certmanagerv1.CertificateList, error) {
+	certificates := &certmanagerv1.CertificateList{}
+	err := tc.CtrlRuntimeClient.List(ctx, certificates, ctrlclient.InNamespace(namespace))
+	return certificates, err
+}
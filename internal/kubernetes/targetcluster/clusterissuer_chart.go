@@ -13,6 +13,8 @@ const (
 
 type CertManagerChartConfig struct {
 	Version string
+	// Source selects a local or OCI chart instead of the Jetstack Helm repository.
+	Source helminstaller.ChartSource
 }
 
 func (c CertManagerChartConfig) ToChartConfig() helminstaller.ChartConfig {
@@ -2,6 +2,8 @@ package targetcluster
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,3 +29,31 @@ func (tc *TargetCluster) DeleteDeployment(ctx context.Context, namespace string,
 func (tc *TargetCluster) PatchDeployment(ctx context.Context, namespace string, name string, data []byte) (*appsv1.Deployment, error) {
 	return tc.Clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
 }
+
+// GetPDSOperatorLogs returns logs of all pods of the given PDS operator (see PDSOperators) since the given time.
+func (tc *TargetCluster) GetPDSOperatorLogs(ctx context.Context, operator string, since time.Time) (map[string]string, error) {
+	pdsOperator, ok := PDSOperators[operator]
+	if !ok {
+		return nil, fmt.Errorf("unknown PDS operator %q", operator)
+	}
+
+	deployment, err := tc.GetDeployment(ctx, PDSChartNamespace, pdsOperator.Deployment)
+	if err != nil {
+		return nil, fmt.Errorf("getting deployment %s: %w", pdsOperator.Deployment, err)
+	}
+
+	pods, err := tc.ListPods(ctx, PDSChartNamespace, deployment.Spec.Selector.MatchLabels)
+	if err != nil {
+		return nil, fmt.Errorf("listing pods of deployment %s: %w", pdsOperator.Deployment, err)
+	}
+
+	logs := make(map[string]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		podLogs, err := tc.GetPodLogs(ctx, &pod, since)
+		if err != nil {
+			return nil, fmt.Errorf("getting logs of pod %s: %w", pod.Name, err)
+		}
+		logs[pod.Name] = podLogs
+	}
+	return logs, nil
+}
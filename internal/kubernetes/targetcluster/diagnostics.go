@@ -0,0 +1,130 @@
+package targetcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	backupsv1 "github.com/portworx/pds-operator-backups/api/v1"
+	deploymentsv1 "github.com/portworx/pds-operator-deployments/api/v1"
+)
+
+// unsafeArtifactNameChars matches characters which are replaced in names of artifact directories, e.g. "/" of subtests.
+var unsafeArtifactNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// TestArtifactsDir returns the directory of the artifacts of the test in the artifacts directory.
+func TestArtifactsDir(artifactsDir, testName string) string {
+	return filepath.Join(artifactsDir, unsafeArtifactNameChars.ReplaceAllString(testName, "_"))
+}
+
+// CollectDiagnosticsOnFailure registers a cleanup function which collects the diagnostics bundle
+// of the namespace if the test fails. It should be called after the cleanup of the tested resources is registered,
+// so the diagnostics are collected before the resources are removed.
+func (tc *TargetCluster) CollectDiagnosticsOnFailure(ctx context.Context, t *testing.T, artifactsDir, namespace string, since time.Time) {
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+
+		dir := TestArtifactsDir(artifactsDir, t.Name())
+		paths, err := tc.CollectDiagnostics(ctx, dir, namespace, since)
+		if err != nil {
+			t.Logf("Collecting diagnostics of namespace %s failed: %v", namespace, err)
+		}
+		for _, path := range paths {
+			t.Logf("Diagnostics artifact: %s", path)
+		}
+	})
+}
+
+// CollectDiagnostics dumps the state of the namespace and the logs of the PDS components since the given time
+// to the directory. It returns the paths of all written files.
+// Collection continues on errors, the returned error contains all failures.
+func (tc *TargetCluster) CollectDiagnostics(ctx context.Context, dir, namespace string, since time.Time) ([]string, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("creating artifacts directory %s: %w", dir, err)
+	}
+
+	var paths []string
+	writeFile := func(name string, content []byte) {
+		path := filepath.Join(dir, name)
+		writeErr := os.WriteFile(path, content, 0o644)
+		if writeErr != nil {
+			err = multierror.Append(err, fmt.Errorf("writing %s: %w", path, writeErr))
+			return
+		}
+		paths = append(paths, path)
+	}
+	writeYAML := func(name string, obj interface{}, getErr error) {
+		if getErr != nil {
+			err = multierror.Append(err, fmt.Errorf("getting %s: %w", name, getErr))
+			return
+		}
+		content, marshalErr := yaml.Marshal(obj)
+		if marshalErr != nil {
+			err = multierror.Append(err, fmt.Errorf("marshalling %s: %w", name, marshalErr))
+			return
+		}
+		writeFile(name, content)
+	}
+
+	listOptions := metav1.ListOptions{}
+	pods, listErr := tc.Clientset.CoreV1().Pods(namespace).List(ctx, listOptions)
+	writeYAML("pods.yaml", pods, listErr)
+	events, listErr := tc.Clientset.CoreV1().Events(namespace).List(ctx, listOptions)
+	writeYAML("events.yaml", events, listErr)
+	statefulSets, listErr := tc.Clientset.AppsV1().StatefulSets(namespace).List(ctx, listOptions)
+	writeYAML("statefulsets.yaml", statefulSets, listErr)
+	pvcs, listErr := tc.Clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, listOptions)
+	writeYAML("pvcs.yaml", pvcs, listErr)
+
+	databases := &deploymentsv1.DatabaseList{}
+	listErr = tc.CtrlRuntimeClient.List(ctx, databases, ctrlclient.InNamespace(namespace))
+	writeYAML("databases.yaml", databases, listErr)
+	backups := &backupsv1.BackupList{}
+	listErr = tc.CtrlRuntimeClient.List(ctx, backups, ctrlclient.InNamespace(namespace))
+	writeYAML("backups.yaml", backups, listErr)
+	restores := &backupsv1.RestoreList{}
+	listErr = tc.CtrlRuntimeClient.List(ctx, restores, ctrlclient.InNamespace(namespace))
+	writeYAML("restores.yaml", restores, listErr)
+
+	// Logs of the jobs in the namespace, e.g. cluster and node init jobs.
+	jobs, listErr := tc.Clientset.BatchV1().Jobs(namespace).List(ctx, listOptions)
+	if listErr != nil {
+		err = multierror.Append(err, fmt.Errorf("listing jobs: %w", listErr))
+	} else {
+		for _, job := range jobs.Items {
+			logs, logsErr := tc.GetJobLogs(ctx, namespace, job.Name, since)
+			if logsErr != nil {
+				logs = fmt.Sprintf("failed to get logs: %v", logsErr)
+			}
+			writeFile(fmt.Sprintf("job-%s.log", job.Name), []byte(logs))
+		}
+	}
+
+	// Logs of the PDS operators and agent.
+	pdsPods, listErr := tc.ListPods(ctx, PDSChartNamespace, nil)
+	if listErr != nil {
+		err = multierror.Append(err, fmt.Errorf("listing pods in %s: %w", PDSChartNamespace, listErr))
+	} else {
+		for _, pod := range pdsPods.Items {
+			logs, logsErr := tc.GetPodLogs(ctx, &pod, since)
+			if logsErr != nil {
+				logs = fmt.Sprintf("failed to get logs: %v", logsErr)
+			}
+			writeFile(fmt.Sprintf("%s-%s.log", PDSChartNamespace, pod.Name), []byte(logs))
+		}
+	}
+
+	return paths, err
+}
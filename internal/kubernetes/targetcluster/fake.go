@@ -0,0 +1,135 @@
+package targetcluster
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	openstoragefake "github.com/libopenstorage/operator/pkg/client/clientset/versioned/fake"
+	openstoragescheme "github.com/libopenstorage/operator/pkg/client/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/rest"
+	fakerest "k8s.io/client-go/rest/fake"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/cluster"
+)
+
+// NewFakeTargetCluster creates a TargetCluster backed by in-memory fake clients, intended for unit tests.
+// Built-in objects are served by the client-go clientset, the controller-runtime client serves both built-in
+// and PDS custom resources. Portworx storage objects are served by the openstorage clientset.
+// The clients don't share state, an object created through one client isn't visible to the others.
+// Helm, Portworx REST and port-forward based functions are not supported. Pod logs are "fake logs" unless they are
+// set by SetFakePodLogs.
+func NewFakeTargetCluster(objects ...runtime.Object) (*TargetCluster, error) {
+	ctrlScheme := runtime.NewScheme()
+	err := scheme.AddToScheme(ctrlScheme)
+	if err != nil {
+		return nil, err
+	}
+	err = AddToScheme(ctrlScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	var kubernetesObjects, ctrlRuntimeObjects, openStorageObjects []runtime.Object
+	for _, obj := range objects {
+		switch {
+		case isRegistered(ctrlScheme, obj):
+			ctrlRuntimeObjects = append(ctrlRuntimeObjects, obj)
+			if isRegistered(scheme.Scheme, obj) {
+				kubernetesObjects = append(kubernetesObjects, obj)
+			}
+		case isRegistered(openstoragescheme.Scheme, obj):
+			openStorageObjects = append(openStorageObjects, obj)
+		default:
+			return nil, fmt.Errorf("object of type %T is not supported by the fake target cluster", obj)
+		}
+	}
+
+	ctrlRuntimeClient := ctrlfake.NewClientBuilder().
+		WithScheme(ctrlScheme).
+		WithRuntimeObjects(ctrlRuntimeObjects...).
+		WithStatusSubresource(&certmanagerv1.Certificate{}).
+		Build()
+
+	fakeCluster, err := cluster.NewCluster(
+		&rest.Config{},
+		&fakeClientset{Clientset: kubernetesfake.NewSimpleClientset(kubernetesObjects...), podLogs: &sync.Map{}},
+		metadatafake.NewSimpleMetadataClient(metadatafake.NewTestScheme()),
+		ctrlRuntimeClient,
+		openstoragefake.NewSimpleClientset(openStorageObjects...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TargetCluster{
+		Cluster:    fakeCluster,
+		RestConfig: &rest.Config{},
+	}, nil
+}
+
+// SetFakePodLogs sets the logs of the pod returned by a target cluster created by NewFakeTargetCluster.
+func SetFakePodLogs(tc *TargetCluster, namespace, podName, logs string) error {
+	clientset, ok := tc.Clientset.(*fakeClientset)
+	if !ok {
+		return fmt.Errorf("target cluster is not fake")
+	}
+	clientset.podLogs.Store(namespace+"/"+podName, logs)
+	return nil
+}
+
+// fakeClientset is the client-go fake clientset serving pod logs set by SetFakePodLogs.
+type fakeClientset struct {
+	*kubernetesfake.Clientset
+	podLogs *sync.Map
+}
+
+func (c *fakeClientset) CoreV1() corev1client.CoreV1Interface {
+	return &fakeCoreV1{CoreV1Interface: c.Clientset.CoreV1(), podLogs: c.podLogs}
+}
+
+type fakeCoreV1 struct {
+	corev1client.CoreV1Interface
+	podLogs *sync.Map
+}
+
+func (c *fakeCoreV1) Pods(namespace string) corev1client.PodInterface {
+	return &fakePods{PodInterface: c.CoreV1Interface.Pods(namespace), namespace: namespace, podLogs: c.podLogs}
+}
+
+type fakePods struct {
+	corev1client.PodInterface
+	namespace string
+	podLogs   *sync.Map
+}
+
+func (c *fakePods) GetLogs(name string, opts *corev1.PodLogOptions) *rest.Request {
+	logs, ok := c.podLogs.Load(c.namespace + "/" + name)
+	if !ok {
+		return c.PodInterface.GetLogs(name, opts)
+	}
+	client := &fakerest.RESTClient{
+		Client: fakerest.CreateHTTPClient(func(*http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(logs.(string)))}, nil
+		}),
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		GroupVersion:         corev1.SchemeGroupVersion,
+		VersionedAPIPath:     fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/log", c.namespace, name),
+	}
+	return client.Request()
+}
+
+func isRegistered(s *runtime.Scheme, obj runtime.Object) bool {
+	_, _, err := s.ObjectKinds(obj)
+	return err == nil
+}
@@ -0,0 +1,43 @@
+package targetcluster_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	backupsv1 "github.com/portworx/pds-operator-backups/api/v1"
+	deploymentsv1 "github.com/portworx/pds-operator-deployments/api/v1"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/fixtures"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+func TestNewFakeTargetCluster(t *testing.T) {
+	ctx := context.Background()
+	tc, err := targetcluster.NewFakeTargetCluster(
+		fixtures.StatefulSet("ns", "pg-1", "id-1", 3, 2),
+		fixtures.Database("ns", "pg-1", "id-1", 3, 2, deploymentsv1.DataServiceHealthDegraded),
+		fixtures.Backup("ns", "backup-1", "id-1", fixtures.BackupJobStatus("job-1", backupsv1.BackupJobSucceeded, "snap-1")),
+	)
+	require.NoError(t, err)
+
+	set, err := tc.GetStatefulSet(ctx, "ns", "pg-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), set.Status.ReadyReplicas)
+
+	database, err := tc.GetPDSDatabase(ctx, "ns", "pg-1")
+	require.NoError(t, err)
+	assert.Equal(t, deploymentsv1.DataServiceHealthDegraded, database.Status.Health)
+
+	backup, err := tc.GetPDSBackup(ctx, "ns", "backup-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), backup.Status.Succeeded)
+	assert.Equal(t, "snap-1", backup.Status.BackupJobs[0].CloudSnapID)
+
+	err = tc.DeletePDSBackup(ctx, "ns", "backup-1")
+	require.NoError(t, err)
+	_, err = tc.GetPDSBackup(ctx, "ns", "backup-1")
+	assert.Error(t, err)
+}
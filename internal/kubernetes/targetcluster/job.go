@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 
@@ -17,7 +18,12 @@ import (
 )
 
 func (tc *TargetCluster) MustWaitForJobSuccess(ctx context.Context, t tests.T, namespace, jobName string) {
-	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
+	tc.MustWaitForJobSuccessWithin(ctx, t, namespace, jobName, wait.StandardTimeout)
+}
+
+// MustWaitForJobSuccessWithin waits for the job to succeed, e.g. for long-running load tests which exceed the standard timeout.
+func (tc *TargetCluster) MustWaitForJobSuccessWithin(ctx context.Context, t tests.T, namespace, jobName string, timeout time.Duration) {
+	wait.For(t, timeout, wait.RetryInterval, func(t tests.T) {
 		job, err := tc.GetJob(ctx, namespace, jobName)
 		require.NoErrorf(t, err, "Getting %s/%s job from target cluster.", namespace, jobName)
 		require.Truef(t, job.Status.Succeeded > 0,
@@ -26,6 +32,30 @@ func (tc *TargetCluster) MustWaitForJobSuccess(ctx context.Context, t tests.T, n
 	})
 }
 
+// MustWaitForJobFinishedWithin waits for the job to complete or fail and returns it.
+func (tc *TargetCluster) MustWaitForJobFinishedWithin(ctx context.Context, t tests.T, namespace, jobName string, timeout time.Duration) *batchv1.Job {
+	var job *batchv1.Job
+	wait.For(t, timeout, wait.RetryInterval, func(t tests.T) {
+		var err error
+		job, err = tc.GetJob(ctx, namespace, jobName)
+		require.NoErrorf(t, err, "Getting %s/%s job from target cluster.", namespace, jobName)
+		require.Truef(t, isJobFinished(job),
+			"Job did not finish (Succeeded: %d, Failed: %d)", job.Status.Succeeded, job.Status.Failed,
+		)
+	})
+	return job
+}
+
+// isJobFinished returns true if the job has a true Complete or Failed condition.
+func isJobFinished(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 func (tc *TargetCluster) MustWaitForJobFailure(ctx context.Context, t tests.T, namespace, jobName string) {
 	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
 		job, err := tc.GetJob(ctx, namespace, jobName)
@@ -44,6 +74,17 @@ func (tc *TargetCluster) JobLogsMustNotContain(ctx context.Context, t *testing.T
 	require.Nil(t, re.FindStringIndex(logs), "Job log '%s' contains pattern '%s':\n%s", jobName, rePattern, logs)
 }
 
+// MustWaitForHostsResolvable waits until the DNS servers of the cluster return an IP address for all hosts. The DNS
+// cache is flushed before every host check job, so negative answers are not cached across retries.
+func (tc *TargetCluster) MustWaitForHostsResolvable(ctx context.Context, t tests.T, namespace, jobNamePrefix string, hosts []string) {
+	wait.For(t, wait.LongTimeout, wait.RetryInterval, func(t tests.T) {
+		dnsIPs := tc.MustFlushDNSCache(ctx, t)
+		jobNameSuffix := time.Now().Format("0405") // mmss
+		jobName := tc.MustRunHostCheckJob(ctx, t, namespace, jobNamePrefix, jobNameSuffix, hosts, dnsIPs)
+		tc.MustWaitForJobSuccess(ctx, t, namespace, jobName)
+	})
+}
+
 func (tc *TargetCluster) MustRunHostCheckJob(ctx context.Context, t tests.T, namespace string, jobNamePrefix, jobNameSuffix string, hosts, dnsIPs []string) string {
 	jobName := fmt.Sprintf("%s-hostcheck-%s", jobNamePrefix, jobNameSuffix)
 	image := "portworx/dnsutils"
@@ -3,16 +3,18 @@ package targetcluster
 import (
 	"context"
 	"fmt"
-	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/loadtest"
 )
 
-func (tc *TargetCluster) MustGetLoadTestJobEnv(ctx context.Context, t *testing.T, dataServiceType, deploymentName, namespace, mode, seed, user string, nodeCount int32, extraEnv map[string]string) []corev1.EnvVar {
+// MustGetLoadTestJobEnv returns the connection env of the load-test container merged with the settings of the run,
+// e.g. MODE or ITERATIONS. Settings override the connection env.
+func (tc *TargetCluster) MustGetLoadTestJobEnv(ctx context.Context, t *testing.T, dataServiceType, deploymentName, namespace string, nodeCount int32, settings map[string]string) []corev1.EnvVar {
 	host := fmt.Sprintf("%s-%s", deploymentName, namespace)
 	password, err := tc.getDBPassword(ctx, namespace, deploymentName)
 	require.NoErrorf(t, err, "Could not get password for database %s/%s.", namespace, deploymentName)
@@ -33,27 +35,6 @@ func (tc *TargetCluster) MustGetLoadTestJobEnv(ctx context.Context, t *testing.T
 			Name:  "PASSWORD",
 			Value: password,
 		},
-		{
-			Name:  "ITERATIONS",
-			Value: "1",
-		},
-		{
-			Name:  "FAIL_ON_ERROR",
-			Value: "true",
-		},
-	}
-	if mode != "" {
-		env = append(env, corev1.EnvVar{
-			Name:  "MODE",
-			Value: mode,
-		})
-	}
-	if seed != "" {
-		seed := strings.ReplaceAll(seed, "-", "")
-		env = append(env, corev1.EnvVar{
-			Name:  "SEED",
-			Value: seed,
-		})
 	}
 
 	switch dataServiceType {
@@ -72,23 +53,40 @@ func (tc *TargetCluster) MustGetLoadTestJobEnv(ctx context.Context, t *testing.T
 		)
 	}
 
-	// Set user.
-	if user == "" {
-		user = "pds"
-	}
-	env = append(env, corev1.EnvVar{
-		Name:  "PDS_USER",
-		Value: user,
-	})
-
-	// Set extra env or override existing ones.
-	if len(extraEnv) > 0 {
-		env = mergeEnvs(env, extraEnv)
+	// Set settings of the run or override existing ones.
+	if len(settings) > 0 {
+		env = mergeEnvs(env, settings)
 	}
 
 	return env
 }
 
+// GetLoadTestSummaries returns the summaries logged by the succeeded pods of the load-test job. Failed pods, e.g.
+// retries of crashed runs, are skipped.
+func (tc *TargetCluster) GetLoadTestSummaries(ctx context.Context, namespace, jobName string) ([]loadtest.Summary, error) {
+	pods, err := tc.ListPods(ctx, namespace, map[string]string{"job-name": jobName})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods of job %s/%s: %w", namespace, jobName, err)
+	}
+	var summaries []loadtest.Summary
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		logs, err := tc.GetPodLogs(ctx, pod, pod.CreationTimestamp.Time)
+		if err != nil {
+			return nil, fmt.Errorf("getting logs of pod %s/%s: %w", namespace, pod.Name, err)
+		}
+		summary, err := loadtest.ParseSummary(logs)
+		if err != nil {
+			return nil, fmt.Errorf("pod %s/%s: %w", namespace, pod.Name, err)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
 func mergeEnvs(envs []corev1.EnvVar, extra map[string]string) []corev1.EnvVar {
 	mergedEnv := make(map[string]corev1.EnvVar)
 	for _, value := range envs {
@@ -0,0 +1,38 @@
+package targetcluster_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/fixtures"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/loadtest"
+)
+
+func TestGetLoadTestSummaries(t *testing.T) {
+	ctx := context.Background()
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		pod := fixtures.JobPod("ns", name, "pg-loadtest-crud-1")
+		pod.Status.Phase = phase
+		return pod
+	}
+	tc := fixtures.NewFakeTargetCluster(t,
+		pod("pg-loadtest-crud-1-a", corev1.PodSucceeded),
+		pod("pg-loadtest-crud-1-b", corev1.PodFailed),
+		pod("pg-loadtest-crud-1-c", corev1.PodRunning),
+		fixtures.JobPod("ns", "other-job-a", "other-job"),
+	)
+	require.NoError(t, targetcluster.SetFakePodLogs(tc, "ns", "pg-loadtest-crud-1-a", "starting\n"+`{"summary":{"ops":100,"errors":1,"latencyMs":{"2":100}}}`+"\n"))
+
+	summaries, err := tc.GetLoadTestSummaries(ctx, "ns", "pg-loadtest-crud-1")
+	require.NoError(t, err)
+	assert.Equal(t, []loadtest.Summary{{Ops: 100, Errors: 1, LatencyMs: map[string]int64{"2": 100}}}, summaries)
+
+	require.NoError(t, targetcluster.SetFakePodLogs(tc, "ns", "pg-loadtest-crud-1-a", "starting\n"))
+	_, err = tc.GetLoadTestSummaries(ctx, "ns", "pg-loadtest-crud-1")
+	assert.Error(t, err, "Succeeded pod without a summary.")
+}
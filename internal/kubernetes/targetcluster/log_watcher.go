@@ -0,0 +1,154 @@
+package targetcluster
+
+import (
+	"bufio"
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/portworx/pds-integration-test/internal/logscan"
+)
+
+const (
+	logWatcherResyncInterval = 10 * time.Second
+	// logWatcherMaxLineSize bounds the size of a single log line, longer lines stop the stream of the container.
+	logWatcherMaxLineSize = 1024 * 1024
+)
+
+// LogWatcher follows logs of all containers in a namespace and feeds the lines to a log scanner.
+// Pods are re-listed periodically, so pods created or containers restarted during the watch are followed too.
+type LogWatcher struct {
+	tc        *TargetCluster
+	namespace string
+	scanner   *logscan.Scanner
+	since     time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// streams holds the last seen timestamp of each container, keyed by pod UID and container name.
+	streams map[string]time.Time
+	active  map[string]bool
+}
+
+// StartLogWatcher starts following logs of all containers in the namespace from now on until Stop is called.
+func (tc *TargetCluster) StartLogWatcher(ctx context.Context, namespace string, scanner *logscan.Scanner) *LogWatcher {
+	ctx, cancel := context.WithCancel(ctx)
+	w := &LogWatcher{
+		tc:        tc,
+		namespace: namespace,
+		scanner:   scanner,
+		since:     time.Now(),
+		cancel:    cancel,
+		streams:   make(map[string]time.Time),
+		active:    make(map[string]bool),
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(logWatcherResyncInterval)
+		defer ticker.Stop()
+		for {
+			w.resync(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return w
+}
+
+// Stop stops following the logs and waits until all lines read so far are scanned.
+func (w *LogWatcher) Stop() {
+	w.cancel()
+	w.wg.Wait()
+}
+
+func (w *LogWatcher) resync(ctx context.Context) {
+	pods, err := w.tc.ListPods(ctx, w.namespace, nil)
+	if err != nil {
+		// Listing is retried on the next resync.
+		return
+	}
+
+	for _, pod := range pods.Items {
+		var statuses []corev1.ContainerStatus
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if status.State.Running == nil {
+				continue
+			}
+			key := string(pod.UID) + "/" + status.Name
+
+			w.mu.Lock()
+			if w.active[key] {
+				w.mu.Unlock()
+				continue
+			}
+			w.active[key] = true
+			w.mu.Unlock()
+
+			w.wg.Add(1)
+			go func(pod corev1.Pod, container, key string) {
+				defer w.wg.Done()
+				w.follow(ctx, pod, container, key)
+			}(pod, status.Name, key)
+		}
+	}
+}
+
+// follow streams the logs of the container until the container stops or the watcher is stopped.
+func (w *LogWatcher) follow(ctx context.Context, pod corev1.Pod, container, key string) {
+	defer func() {
+		w.mu.Lock()
+		delete(w.active, key)
+		w.mu.Unlock()
+	}()
+
+	w.mu.Lock()
+	lastSeen, ok := w.streams[key]
+	w.mu.Unlock()
+	since := w.since
+	if ok {
+		// The container restarted, SinceTime has a precision of seconds, already scanned lines are skipped below.
+		since = lastSeen
+	}
+
+	metaSince := metav1.NewTime(since)
+	logOpts := &corev1.PodLogOptions{
+		Container:  container,
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  &metaSince,
+	}
+	stream, err := w.tc.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOpts).Stream(ctx)
+	if err != nil {
+		return
+	}
+	defer func() { _ = stream.Close() }()
+
+	lines := bufio.NewScanner(stream)
+	lines.Buffer(make([]byte, 0, 64*1024), logWatcherMaxLineSize)
+	for lines.Scan() {
+		at, line, ok := logscan.SplitTimestamp(lines.Text())
+		if !ok {
+			at = time.Now()
+		} else if !at.After(lastSeen) {
+			continue
+		}
+		lastSeen = at
+		w.scanner.Scan(pod.Name, container, line, at)
+	}
+
+	w.mu.Lock()
+	w.streams[key] = lastSeen
+	w.mu.Unlock()
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
 
 	"github.com/portworx/pds-integration-test/internal/helminstaller"
+	"github.com/portworx/pds-integration-test/internal/tests"
+	"github.com/portworx/pds-integration-test/internal/wait"
 )
 
 const (
@@ -29,6 +32,12 @@ type PDSChartConfig struct {
 	ControlPlaneAPI       string
 	DeploymentTargetName  string
 	DataServiceTLSEnabled bool
+	// Source selects a local or OCI chart instead of the PDS Helm repository.
+	Source helminstaller.ChartSource
+	// ValuesFiles are layered values files, e.g. with feature flags, image registries or resources.
+	ValuesFiles []string
+	// SetValues are --set style overrides applied last.
+	SetValues []string
 }
 
 func (c PDSChartConfig) ToChartConfig() helminstaller.ChartConfig {
@@ -41,6 +50,8 @@ func (c PDSChartConfig) ToChartConfig() helminstaller.ChartConfig {
 			"apiEndpoint": c.ControlPlaneAPI,
 			"clusterName": c.DeploymentTargetName,
 		},
+		ValuesFiles: c.ValuesFiles,
+		SetValues:   c.SetValues,
 	}
 	if c.DataServiceTLSEnabled {
 		chartConfig.ChartValues["dataServiceTLSEnabled"] = "true"
@@ -89,3 +100,23 @@ func (tc *TargetCluster) PDSChartInstaller() (*helminstaller.InstallableHelm, er
 		PDSChartNamespace,
 	)
 }
+
+// PreviousPDSChartVersion returns the newest PDS chart version of the release line preceding tc.PDSChartConfig.Version.
+func (tc *TargetCluster) PreviousPDSChartVersion() (string, error) {
+	return tc.PDSChartHelmProvider.PreviousReleaseVersion(tc.PDSChartConfig.Version)
+}
+
+// MustWaitForPDSOperatorsReady waits until the deployments of all PDS chart operators are rolled out and available.
+func (tc *TargetCluster) MustWaitForPDSOperatorsReady(ctx context.Context, t tests.T) {
+	wait.For(t, wait.StandardTimeout, wait.RetryInterval, func(t tests.T) {
+		for _, operator := range PDSOperators {
+			deployment, err := tc.GetDeployment(ctx, PDSChartNamespace, operator.Deployment)
+			require.NoErrorf(t, err, "Getting %s operator deployment.", operator.Name)
+			require.Equalf(t, deployment.Generation, deployment.Status.ObservedGeneration, "Operator %s deployment is not observed yet.", operator.Name)
+			replicas := *deployment.Spec.Replicas
+			require.Equalf(t, replicas, deployment.Status.UpdatedReplicas, "Operator %s deployment is not updated.", operator.Name)
+			require.Equalf(t, replicas, deployment.Status.AvailableReplicas, "Operator %s deployment is not available.", operator.Name)
+			require.Equalf(t, replicas, deployment.Status.Replicas, "Operator %s deployment has old replicas.", operator.Name)
+		}
+	})
+}
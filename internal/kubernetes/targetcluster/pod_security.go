@@ -0,0 +1,123 @@
+package targetcluster
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/psa"
+	"github.com/portworx/pds-integration-test/internal/tests"
+)
+
+// AuditPodSecurity evaluates pods and pod templates of stateful sets, deployments, daemon sets and jobs
+// matching the label selector against the pod security level. Templates are audited as well,
+// because pods of finished jobs may be already removed.
+func (tc *TargetCluster) AuditPodSecurity(ctx context.Context, namespace, level string, labelSelector map[string]string) (*psa.Report, error) {
+	report := psa.NewReport(level)
+	listOptions := metav1.ListOptions{LabelSelector: labels.FormatLabels(labelSelector)}
+
+	pods, err := tc.Clientset.CoreV1().Pods(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		err = report.Audit("Pod", pod.ObjectMeta, pod.ObjectMeta, pod.Spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	statefulSets, err := tc.Clientset.AppsV1().StatefulSets(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, set := range statefulSets.Items {
+		err = report.Audit("StatefulSet", set.ObjectMeta, set.Spec.Template.ObjectMeta, set.Spec.Template.Spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deployments, err := tc.Clientset.AppsV1().Deployments(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		err = report.Audit("Deployment", deployment.ObjectMeta, deployment.Spec.Template.ObjectMeta, deployment.Spec.Template.Spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	daemonSets, err := tc.Clientset.AppsV1().DaemonSets(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, set := range daemonSets.Items {
+		err = report.Audit("DaemonSet", set.ObjectMeta, set.Spec.Template.ObjectMeta, set.Spec.Template.Spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jobs, err := tc.Clientset.BatchV1().Jobs(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs.Items {
+		err = report.Audit("Job", job.ObjectMeta, job.Spec.Template.ObjectMeta, job.Spec.Template.Spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// AuditJobsPodSecurity evaluates the named jobs and their pods against the pod security level, e.g. backup and
+// restore jobs which are not labeled by the deployment.
+func (tc *TargetCluster) AuditJobsPodSecurity(ctx context.Context, namespace, level string, jobNames []string) (*psa.Report, error) {
+	report := psa.NewReport(level)
+	for _, jobName := range jobNames {
+		job, err := tc.GetJob(ctx, namespace, jobName)
+		if err != nil {
+			return nil, err
+		}
+		err = report.Audit("Job", job.ObjectMeta, job.Spec.Template.ObjectMeta, job.Spec.Template.Spec)
+		if err != nil {
+			return nil, err
+		}
+
+		pods, err := tc.ListPods(ctx, namespace, map[string]string{"job-name": jobName})
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods.Items {
+			err = report.Audit("Pod", pod.ObjectMeta, pod.ObjectMeta, pod.Spec)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// MustPassPodSecurityAudit checks that all workloads matching the label selector comply with the pod security level.
+// Violations are reported per workload and field.
+func (tc *TargetCluster) MustPassPodSecurityAudit(ctx context.Context, t tests.T, namespace, level string, labelSelector map[string]string) {
+	report, err := tc.AuditPodSecurity(ctx, namespace, level, labelSelector)
+	require.NoErrorf(t, err, "Auditing pod security in namespace %s.", namespace)
+	require.NotEmptyf(t, report.Workloads, "No workloads to audit in namespace %s.", namespace)
+	assert.Falsef(t, report.HasViolations(), "Workloads in namespace %s violate the %s pod security level.\n%s", namespace, level, report)
+}
+
+// MustPassJobsPodSecurityAudit checks that the named jobs and their pods comply with the pod security level.
+func (tc *TargetCluster) MustPassJobsPodSecurityAudit(ctx context.Context, t tests.T, namespace, level string, jobNames []string) {
+	require.NotEmptyf(t, jobNames, "No jobs to audit in namespace %s.", namespace)
+	report, err := tc.AuditJobsPodSecurity(ctx, namespace, level, jobNames)
+	require.NoErrorf(t, err, "Auditing pod security of jobs %v in namespace %s.", jobNames, namespace)
+	assert.Falsef(t, report.HasViolations(), "Jobs in namespace %s violate the %s pod security level.\n%s", namespace, level, report)
+}
@@ -0,0 +1,41 @@
+package targetcluster_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/fixtures"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/psa"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+func TestAuditJobsPodSecurity(t *testing.T) {
+	ctx := context.Background()
+	pod := fixtures.JobPod("ns", "backup-job-1-abcde", "backup-job-1")
+	pod.Spec.Containers = []corev1.Container{{Name: "backup"}}
+	tc, err := targetcluster.NewFakeTargetCluster(
+		fixtures.Job("ns", "backup-job-1", nil, 1),
+		pod,
+		fixtures.JobPod("ns", "other-job-abcde", "other-job"),
+	)
+	require.NoError(t, err)
+
+	report, err := tc.AuditJobsPodSecurity(ctx, "ns", psa.PSAPolicyBaseline, []string{"backup-job-1"})
+	require.NoError(t, err)
+	require.Len(t, report.Workloads, 2)
+	assert.Equal(t, "Job", report.Workloads[0].Kind)
+	assert.Equal(t, "backup-job-1-abcde", report.Workloads[1].Name)
+	assert.False(t, report.HasViolations())
+
+	// The container of the pod has no security context, e.g. it does not drop all capabilities.
+	report, err = tc.AuditJobsPodSecurity(ctx, "ns", psa.PSAPolicyRestricted, []string{"backup-job-1"})
+	require.NoError(t, err)
+	assert.True(t, report.HasViolations())
+
+	_, err = tc.AuditJobsPodSecurity(ctx, "ns", psa.PSAPolicyBaseline, []string{"missing-job"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package targetcluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/portworx/pds-integration-test/internal/resourceusage"
+)
+
+const podMetricsPath = "/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods"
+
+// podMetricsList is the subset of metrics.k8s.io/v1beta1 PodMetricsList used by the sampler.
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Timestamp  time.Time `json:"timestamp"`
+		Containers []struct {
+			Name  string `json:"name"`
+			Usage struct {
+				CPU    resource.Quantity `json:"cpu"`
+				Memory resource.Quantity `json:"memory"`
+			} `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// GetPodResourceUsage returns the current CPU and memory usage of the container of the matching pods as reported by
+// metrics.k8s.io. Sidecars are not sampled, they are not covered by the resource settings template. Pods without
+// metrics of the container are skipped. The metrics server must be installed in the cluster.
+func (tc *TargetCluster) GetPodResourceUsage(ctx context.Context, namespace string, labelSelector map[string]string, containerName string) ([]resourceusage.Sample, error) {
+	data, err := tc.Clientset.Discovery().RESTClient().Get().
+		AbsPath(fmt.Sprintf(podMetricsPath, namespace)).
+		Param("labelSelector", labels.FormatLabels(labelSelector)).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting pod metrics in namespace %s: %w", namespace, err)
+	}
+
+	var list podMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parsing pod metrics: %w", err)
+	}
+
+	samples := make([]resourceusage.Sample, 0, len(list.Items))
+	for _, item := range list.Items {
+		for _, container := range item.Containers {
+			if container.Name != containerName {
+				continue
+			}
+			samples = append(samples, resourceusage.Sample{
+				Time:        item.Timestamp,
+				Pod:         item.Metadata.Name,
+				CPUMillis:   container.Usage.CPU.MilliValue(),
+				MemoryBytes: container.Usage.Memory.Value(),
+			})
+		}
+	}
+	return samples, nil
+}
+
+// ResourceUsageSampler polls the resource usage of pods until it is stopped.
+type ResourceUsageSampler struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	samples []resourceusage.Sample
+	seen    map[string]bool
+	lastErr error
+}
+
+// StartResourceUsageSampler polls the resource usage of the container of the matching pods in the given interval.
+// The metrics server refreshes the usage only every few seconds, repeated samples of a pod are dropped.
+func (tc *TargetCluster) StartResourceUsageSampler(ctx context.Context, namespace string, labelSelector map[string]string, containerName string, interval time.Duration) *ResourceUsageSampler {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &ResourceUsageSampler{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		seen:   make(map[string]bool),
+	}
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			samples, err := tc.GetPodResourceUsage(ctx, namespace, labelSelector, containerName)
+			s.add(samples, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return s
+}
+
+// Len returns the number of samples so far.
+func (s *ResourceUsageSampler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.samples)
+}
+
+// Stop stops the polling and returns the samples. The error of the last failed poll is returned if there are no samples.
+func (s *ResourceUsageSampler) Stop() ([]resourceusage.Sample, error) {
+	s.cancel()
+	<-s.done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.samples) == 0 && s.lastErr != nil {
+		return nil, s.lastErr
+	}
+	return s.samples, nil
+}
+
+func (s *ResourceUsageSampler) add(samples []resourceusage.Sample, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.lastErr = err
+		return
+	}
+	for _, sample := range samples {
+		key := sample.Pod + "/" + sample.Time.String()
+		if s.seen[key] {
+			continue
+		}
+		s.seen[key] = true
+		s.samples = append(s.samples, sample)
+	}
+}
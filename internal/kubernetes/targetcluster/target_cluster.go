@@ -13,12 +13,16 @@ import (
 	openstorage "github.com/libopenstorage/operator/pkg/client/clientset/versioned"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	backupsv1 "github.com/portworx/pds-operator-backups/api/v1"
+	deploymentsv1 "github.com/portworx/pds-operator-deployments/api/v1"
+
 	"github.com/portworx/pds-integration-test/internal/helminstaller"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/cluster"
 	"github.com/portworx/pds-integration-test/internal/portworx"
@@ -66,7 +70,7 @@ func NewTargetCluster(
 	}
 
 	// Every custom resource has to be added to the scheme in order to make it work with the controller-runtime client.
-	err = certmanagerv1.AddToScheme(ctrlRuntimeClient.Scheme())
+	err = AddToScheme(ctrlRuntimeClient.Scheme())
 	if err != nil {
 		return nil, err
 	}
@@ -92,12 +96,12 @@ func NewTargetCluster(
 		return nil, err
 	}
 
-	pdsChartHelmProvider, err := helminstaller.NewHelmProviderPDS(PDSChartNamespace)
+	pdsChartHelmProvider, err := helminstaller.NewHelmProviderPDS(PDSChartNamespace, pdsChartConfig.Source)
 	if err != nil {
 		return nil, err
 	}
 
-	certManagerHelmProvider, err := helminstaller.NewHelmProviderCertManager(CertManagerNamespace)
+	certManagerHelmProvider, err := helminstaller.NewHelmProviderCertManager(CertManagerNamespace, certManagerChartConfig.Source)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +118,21 @@ func NewTargetCluster(
 	}, nil
 }
 
+// AddToScheme registers the custom resources used by the tests, i.e. cert-manager and PDS resources.
+func AddToScheme(scheme *runtime.Scheme) error {
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		certmanagerv1.AddToScheme,
+		backupsv1.AddToScheme,
+		deploymentsv1.AddToScheme,
+	} {
+		err := addToScheme(scheme)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // DeleteCRDs deletes all pds in the target cluster. Used in the test cleanup.
 func (tc *TargetCluster) DeleteCRDs(ctx context.Context) error {
 	listOptions := metav1.ListOptions{}
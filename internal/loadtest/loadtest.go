@@ -0,0 +1,136 @@
+// Package loadtest reads the per-operation summaries logged by the load-test container into structured results.
+package loadtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Summary is the summary of the operations of a single run of the load-test container. With SUMMARY=json the
+// container logs it as the last line of the run, e.g.
+//
+//	{"summary":{"ops":1200,"errors":3,"start":"2023-06-01T12:00:00Z","end":"2023-06-01T12:00:30Z","latencyMs":{"1":800,"2":390,"12":10}}}
+type Summary struct {
+	// Ops is the number of operations, including the failed ones.
+	Ops int64 `json:"ops"`
+	// Errors is the number of failed operations.
+	Errors int64 `json:"errors"`
+	// Start and End of the workload, without the start-up of the container.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// LatencyMs is a histogram of the operation latencies. It maps the upper bound of each bucket in milliseconds to
+	// the number of operations in the bucket, the bound of the last bucket is the maximum latency.
+	LatencyMs map[string]int64 `json:"latencyMs"`
+}
+
+// ParseSummary returns the last summary in the logs of a run.
+func ParseSummary(logs string) (Summary, error) {
+	lines := strings.Split(logs, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var out struct {
+			Summary *Summary `json:"summary"`
+		}
+		if err := json.Unmarshal([]byte(line), &out); err != nil || out.Summary == nil {
+			continue
+		}
+		return *out.Summary, nil
+	}
+	return Summary{}, errors.New("no load-test summary found in logs")
+}
+
+// Results are the totals and latencies of the operations of a load test.
+type Results struct {
+	Ops      int64         `json:"ops"`
+	Errors   int64         `json:"errors"`
+	P50      time.Duration `json:"p50"`
+	P99      time.Duration `json:"p99"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Summarize returns the results of the summaries of all runs of a load test. The latency histograms of the runs are
+// merged, so the percentiles are the upper bounds of the buckets. The duration is the time from the start of the
+// first workload to the end of the last one.
+func Summarize(summaries []Summary) (Results, error) {
+	if len(summaries) == 0 {
+		return Results{}, nil
+	}
+
+	var results Results
+	histogram := make(map[float64]int64)
+	start, end := summaries[0].Start, summaries[0].End
+	for _, summary := range summaries {
+		results.Ops += summary.Ops
+		results.Errors += summary.Errors
+		if summary.Start.Before(start) {
+			start = summary.Start
+		}
+		if summary.End.After(end) {
+			end = summary.End
+		}
+		for bound, count := range summary.LatencyMs {
+			millis, err := strconv.ParseFloat(bound, 64)
+			if err != nil || millis < 0 || count < 0 {
+				return Results{}, fmt.Errorf("invalid latency bucket %q: %d", bound, count)
+			}
+			histogram[millis] += count
+		}
+	}
+	results.P50 = percentile(histogram, 50)
+	results.P99 = percentile(histogram, 99)
+	results.Duration = end.Sub(start)
+	return results, nil
+}
+
+// percentile returns the upper bound of the bucket holding the nearest-rank percentile of the histogram.
+func percentile(histogram map[float64]int64, p int64) time.Duration {
+	bounds := make([]float64, 0, len(histogram))
+	var total int64
+	for bound, count := range histogram {
+		bounds = append(bounds, bound)
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+	sort.Float64s(bounds)
+
+	rank := (p*total + 99) / 100
+	var cumulative int64
+	for _, bound := range bounds {
+		cumulative += histogram[bound]
+		if cumulative >= rank {
+			return time.Duration(bound * float64(time.Millisecond))
+		}
+	}
+	return time.Duration(bounds[len(bounds)-1] * float64(time.Millisecond))
+}
+
+// ErrorRate is the fraction of failed operations.
+func (r Results) ErrorRate() float64 {
+	if r.Ops == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Ops)
+}
+
+// Throughput is the number of operations per second, zero if the duration is unknown.
+func (r Results) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Ops) / r.Duration.Seconds()
+}
+
+func (r Results) String() string {
+	return fmt.Sprintf("%d ops, %d errors (%.2f%%), %.1f ops/s, p50 %s, p99 %s",
+		r.Ops, r.Errors, 100*r.ErrorRate(), r.Throughput(), r.P50, r.P99)
+}
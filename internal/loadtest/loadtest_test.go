@@ -0,0 +1,79 @@
+package loadtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSummary(t *testing.T) {
+	logs := `connecting to pg-test-pds-test
+{"level":"info","msg":"iteration done"}
+{"summary":{"ops":1200,"errors":3,"start":"2023-06-01T12:00:00Z","end":"2023-06-01T12:00:30Z","latencyMs":{"1":800,"2.5":390,"12":10}}}
+`
+	summary, err := ParseSummary(logs)
+	require.NoError(t, err)
+	assert.Equal(t, Summary{
+		Ops:       1200,
+		Errors:    3,
+		Start:     time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		End:       time.Date(2023, 6, 1, 12, 0, 30, 0, time.UTC),
+		LatencyMs: map[string]int64{"1": 800, "2.5": 390, "12": 10},
+	}, summary)
+
+	_, err = ParseSummary("connecting to pg-test-pds-test\ndone\n")
+	assert.Error(t, err)
+}
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	summaries := []Summary{
+		{
+			Ops: 600, Errors: 1, Start: start.Add(10 * time.Second), End: start.Add(40 * time.Second),
+			LatencyMs: map[string]int64{"1": 500, "2": 99, "20": 1},
+		},
+		{
+			Ops: 400, Errors: 9, Start: start, End: start.Add(50 * time.Second),
+			LatencyMs: map[string]int64{"1": 100, "2": 280, "5": 15, "40": 5},
+		},
+	}
+
+	results, err := Summarize(summaries)
+	require.NoError(t, err)
+	assert.Equal(t, Results{
+		Ops:      1000,
+		Errors:   10,
+		P50:      time.Millisecond,
+		P99:      5 * time.Millisecond,
+		Duration: 50 * time.Second,
+	}, results)
+	assert.Equal(t, 0.01, results.ErrorRate())
+	assert.Equal(t, 20.0, results.Throughput())
+}
+
+func TestSummarizeNoSummaries(t *testing.T) {
+	results, err := Summarize(nil)
+	require.NoError(t, err)
+	assert.Equal(t, Results{}, results)
+	assert.Zero(t, results.ErrorRate())
+	assert.Zero(t, results.Throughput())
+}
+
+func TestSummarizeInvalidBucket(t *testing.T) {
+	_, err := Summarize([]Summary{{Ops: 1, LatencyMs: map[string]int64{"fast": 1}}})
+	assert.Error(t, err)
+}
+
+func TestPercentile(t *testing.T) {
+	histogram := make(map[float64]int64)
+	for i := 1; i <= 100; i++ {
+		histogram[float64(i)] = 1
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(histogram, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(histogram, 99))
+	assert.Equal(t, 1500*time.Microsecond, percentile(map[float64]int64{1.5: 1}, 50))
+	assert.Equal(t, 2*time.Millisecond, percentile(map[float64]int64{1: 1, 2: 1}, 99))
+	assert.Zero(t, percentile(map[float64]int64{}, 50))
+}
@@ -0,0 +1,234 @@
+// Package logscan matches container log lines against error patterns and attributes the matches
+// to the test which was running at the time the line was logged.
+package logscan
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultPatterns are used if the configuration defines no patterns. A line is reported once,
+// for the first matching pattern, so more specific patterns go first.
+var DefaultPatterns = []Pattern{
+	{Name: "panic", Regexp: `^panic: |goroutine \d+ \[running\]:`},
+	{Name: "dataRace", Regexp: `WARNING: DATA RACE`},
+	{Name: "reconcileError", Regexp: `Reconciler error`},
+	{Name: "error", Regexp: `level=error|"level":"error"`},
+}
+
+// Pattern is a named regular expression matched against log lines.
+type Pattern struct {
+	Name   string `json:"name"`
+	Regexp string `json:"regexp"`
+}
+
+// AllowRule allows known matches. Allowed matches are reported as warnings instead of failures.
+// Empty Pattern, Pod and Container match anything.
+type AllowRule struct {
+	// Pattern is the name of the pattern the rule applies to.
+	Pattern string `json:"pattern,omitempty"`
+	// Regexp is matched against the log line.
+	Regexp string `json:"regexp"`
+	// Pod is a regular expression matched against the pod name.
+	Pod string `json:"pod,omitempty"`
+	// Container is the container name.
+	Container string `json:"container,omitempty"`
+	// Reason explains why the match is allowed, e.g. a link to a known issue.
+	Reason string `json:"reason"`
+}
+
+// Config is the content of a log scan configuration file.
+type Config struct {
+	Patterns []Pattern   `json:"patterns,omitempty"`
+	Allow    []AllowRule `json:"allow,omitempty"`
+}
+
+// LoadConfig reads a YAML log scan configuration file.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return config, fmt.Errorf("parsing log scan config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Hit is a log line matching one of the patterns.
+type Hit struct {
+	Time      time.Time
+	Pod       string
+	Container string
+	Pattern   string
+	Line      string
+	// Test is the name of the test running when the line was logged, empty if no test was running.
+	Test string
+	// Allowed is set if an allow rule matched the hit.
+	Allowed bool
+	Reason  string
+}
+
+func (h Hit) String() string {
+	test := h.Test
+	if test == "" {
+		test = "<no test>"
+	}
+	return fmt.Sprintf("[%s] %s %s/%s (%s): %s", test, h.Time.Format(time.RFC3339), h.Pod, h.Container, h.Pattern, h.Line)
+}
+
+// Scanner matches log lines against patterns. It is safe for concurrent use.
+type Scanner struct {
+	patterns []compiledPattern
+	allow    []compiledRule
+
+	mu    sync.Mutex
+	tests []testSpan
+	hits  []Hit
+}
+
+type compiledPattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+type compiledRule struct {
+	AllowRule
+	re    *regexp.Regexp
+	podRe *regexp.Regexp
+}
+
+type testSpan struct {
+	name       string
+	start, end time.Time
+}
+
+// NewScanner compiles the patterns and allow rules of the configuration.
+func NewScanner(config Config) (*Scanner, error) {
+	patterns := config.Patterns
+	if len(patterns) == 0 {
+		patterns = DefaultPatterns
+	}
+
+	s := &Scanner{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern.Regexp)
+		if err != nil {
+			return nil, fmt.Errorf("compiling pattern %s: %w", pattern.Name, err)
+		}
+		s.patterns = append(s.patterns, compiledPattern{name: pattern.Name, re: re})
+	}
+	for i, rule := range config.Allow {
+		re, err := regexp.Compile(rule.Regexp)
+		if err != nil {
+			return nil, fmt.Errorf("compiling allow rule %d: %w", i, err)
+		}
+		compiled := compiledRule{AllowRule: rule, re: re}
+		if rule.Pod != "" {
+			compiled.podRe, err = regexp.Compile(rule.Pod)
+			if err != nil {
+				return nil, fmt.Errorf("compiling pod of allow rule %d: %w", i, err)
+			}
+		}
+		s.allow = append(s.allow, compiled)
+	}
+	return s, nil
+}
+
+// BeginTest marks the start of the test. Nested tests, e.g. subtests, take precedence over their parents.
+func (s *Scanner) BeginTest(name string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tests = append(s.tests, testSpan{name: name, start: at})
+}
+
+// EndTest marks the end of the most recently started test with the name.
+func (s *Scanner) EndTest(name string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.tests) - 1; i >= 0; i-- {
+		if s.tests[i].name == name && s.tests[i].end.IsZero() {
+			s.tests[i].end = at
+			return
+		}
+	}
+}
+
+// Scan matches the line logged by the container at the given time.
+func (s *Scanner) Scan(pod, container, line string, at time.Time) {
+	for _, pattern := range s.patterns {
+		if !pattern.re.MatchString(line) {
+			continue
+		}
+		hit := Hit{Time: at, Pod: pod, Container: container, Pattern: pattern.name, Line: line}
+		for _, rule := range s.allow {
+			if rule.matches(hit) {
+				hit.Allowed = true
+				hit.Reason = rule.Reason
+				break
+			}
+		}
+
+		s.mu.Lock()
+		hit.Test = s.testAt(at)
+		s.hits = append(s.hits, hit)
+		s.mu.Unlock()
+		return
+	}
+}
+
+// Hits returns all hits ordered by time.
+func (s *Scanner) Hits() []Hit {
+	s.mu.Lock()
+	hits := make([]Hit, len(s.hits))
+	copy(hits, s.hits)
+	s.mu.Unlock()
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Time.Before(hits[j].Time) })
+	return hits
+}
+
+// testAt returns the most recently started test running at the time. Must be called with the lock held.
+func (s *Scanner) testAt(at time.Time) string {
+	for i := len(s.tests) - 1; i >= 0; i-- {
+		span := s.tests[i]
+		if !at.Before(span.start) && (span.end.IsZero() || !at.After(span.end)) {
+			return span.name
+		}
+	}
+	return ""
+}
+
+func (r compiledRule) matches(hit Hit) bool {
+	if r.Pattern != "" && r.Pattern != hit.Pattern {
+		return false
+	}
+	if r.Container != "" && r.Container != hit.Container {
+		return false
+	}
+	if r.podRe != nil && !r.podRe.MatchString(hit.Pod) {
+		return false
+	}
+	return r.re.MatchString(hit.Line)
+}
+
+// SplitTimestamp splits the RFC3339 timestamp prefix added by the kubelet to log lines requested with timestamps.
+func SplitTimestamp(line string) (time.Time, string, bool) {
+	timestamp, rest, found := strings.Cut(line, " ")
+	if !found {
+		return time.Time{}, line, false
+	}
+	at, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return at, rest, true
+}
@@ -0,0 +1,101 @@
+package logscan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner(t *testing.T) {
+	scanner, err := NewScanner(Config{
+		Allow: []AllowRule{{
+			Pattern: "reconcileError",
+			Regexp:  "the object has been modified",
+			Pod:     "^pds-deployment-operator-",
+			Reason:  "optimistic locking conflicts are retried",
+		}},
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	scanner.BeginTest("TestA", start)
+	scanner.BeginTest("TestA/sub", start.Add(time.Minute))
+	scanner.EndTest("TestA/sub", start.Add(2*time.Minute))
+	scanner.EndTest("TestA", start.Add(3*time.Minute))
+
+	scanner.Scan("pds-deployment-operator-1", "manager", `level=info msg="all good"`, start.Add(30*time.Second))
+	scanner.Scan("pds-deployment-operator-1", "manager", `level=error msg="Reconciler error" err="the object has been modified"`, start.Add(90*time.Second))
+	scanner.Scan("pds-target-operator-1", "manager", `level=error msg="Reconciler error" err="the object has been modified"`, start.Add(150*time.Second))
+	scanner.Scan("pds-target-operator-1", "manager", `panic: runtime error: invalid memory address`, start.Add(10*time.Minute))
+	scanner.Scan("pds-target-operator-1", "manager", `{"level":"error","msg":"failed"}`, start.Add(-time.Minute))
+
+	expected := []Hit{
+		{
+			Time: start.Add(-time.Minute), Pod: "pds-target-operator-1", Container: "manager",
+			Pattern: "error", Line: `{"level":"error","msg":"failed"}`,
+		},
+		{
+			Time: start.Add(90 * time.Second), Pod: "pds-deployment-operator-1", Container: "manager",
+			Pattern: "reconcileError", Line: `level=error msg="Reconciler error" err="the object has been modified"`,
+			Test: "TestA/sub", Allowed: true, Reason: "optimistic locking conflicts are retried",
+		},
+		{
+			Time: start.Add(150 * time.Second), Pod: "pds-target-operator-1", Container: "manager",
+			Pattern: "reconcileError", Line: `level=error msg="Reconciler error" err="the object has been modified"`,
+			Test: "TestA",
+		},
+		{
+			Time: start.Add(10 * time.Minute), Pod: "pds-target-operator-1", Container: "manager",
+			Pattern: "panic", Line: `panic: runtime error: invalid memory address`,
+		},
+	}
+	assert.Equal(t, expected, scanner.Hits())
+}
+
+func TestNewScanner_InvalidPattern(t *testing.T) {
+	_, err := NewScanner(Config{Patterns: []Pattern{{Name: "broken", Regexp: "("}}})
+	assert.Error(t, err)
+
+	_, err = NewScanner(Config{Allow: []AllowRule{{Regexp: "("}}})
+	assert.Error(t, err)
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logscan.yaml")
+	content := `
+patterns:
+  - name: fatal
+    regexp: "level=fatal"
+allow:
+  - pattern: fatal
+    regexp: "shutting down"
+    reason: expected on operator restarts
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		Patterns: []Pattern{{Name: "fatal", Regexp: "level=fatal"}},
+		Allow:    []AllowRule{{Pattern: "fatal", Regexp: "shutting down", Reason: "expected on operator restarts"}},
+	}, config)
+
+	require.NoError(t, os.WriteFile(path, []byte("unknown: field\n"), 0o600))
+	_, err = LoadConfig(path)
+	assert.Error(t, err)
+}
+
+func TestSplitTimestamp(t *testing.T) {
+	at, line, ok := SplitTimestamp("2023-01-01T10:00:00.123456789Z panic: boom")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2023, 1, 1, 10, 0, 0, 123456789, time.UTC), at)
+	assert.Equal(t, "panic: boom", line)
+
+	_, line, ok = SplitTimestamp("panic: boom")
+	assert.False(t, ok)
+	assert.Equal(t, "panic: boom", line)
+}
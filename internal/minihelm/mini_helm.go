@@ -3,22 +3,23 @@ package minihelm
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v2"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/cli"
-	"helm.sh/helm/v3/pkg/cli/values"
 	"helm.sh/helm/v3/pkg/downloader"
 	"helm.sh/helm/v3/pkg/getter"
 	"helm.sh/helm/v3/pkg/helmpath"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
-	"helm.sh/helm/v3/pkg/strvals"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
@@ -27,16 +28,19 @@ type Client interface {
 	HasRepoWithNameAndURL(repoName, url string) bool
 	UpdateRepo(repoName string) error
 	GetChartVersions(repoName, chartName string) ([]string, error)
-	InstallChart(ctx context.Context, restGetter genericclioptions.RESTClientGetter, repoName, releaseName, chartName, chartVersion, chartVals string, logger action.DebugLog) error
-	UpgradeChart(ctx context.Context, restGetter genericclioptions.RESTClientGetter, repoName, releaseName, chartName, chartVersion, chartVals string, logger action.DebugLog) error
+	GetLocalChartVersions(chartPath string) ([]string, error)
+	GetOCIChartVersions(chartRef string) ([]string, error)
+	InstallChart(ctx context.Context, restGetter genericclioptions.RESTClientGetter, chartRef, releaseName, chartVersion string, chartVals map[string]interface{}, logger action.DebugLog) error
+	UpgradeChart(ctx context.Context, restGetter genericclioptions.RESTClientGetter, chartRef, releaseName, chartVersion string, chartVals map[string]interface{}, logger action.DebugLog) error
 	UninstallChartVersion(ctx context.Context, restGetter genericclioptions.RESTClientGetter, releaseName string, logger action.DebugLog) error
 }
 
 // miniHelm is a partial implementation of HelmCmd, w/o Helm storage mutating features (Add Chart, Add Repo etc.).
 type miniHelm struct {
-	settings  *cli.EnvSettings
-	providers getter.Providers
-	storage   *repo.File
+	settings       *cli.EnvSettings
+	providers      getter.Providers
+	storage        *repo.File
+	registryClient *registry.Client
 }
 
 type ClientOptions struct {
@@ -66,16 +70,24 @@ func New(options *ClientOptions) (Client, error) {
 		return nil, err
 	}
 
+	// A missing repositories file is fine for charts installed from a local path or an OCI registry.
+	client.storage = &repo.File{}
 	bs, err := os.ReadFile(repoFile)
-	if err != nil {
+	if err != nil && !os.IsNotExist(err) {
 		return nil, err
 	}
-
-	client.storage = &repo.File{}
 	if err := yaml.Unmarshal(bs, client.storage); err != nil {
 		return nil, err
 	}
 
+	client.registryClient, err = registry.NewClient(
+		registry.ClientOptCredentialsFile(client.settings.RegistryConfig),
+		registry.ClientOptWriter(io.Discard),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating registry client: %w", err)
+	}
+
 	return &client, nil
 }
 
@@ -122,13 +134,29 @@ func (m *miniHelm) GetChartVersions(repoName, chartName string) ([]string, error
 	return nil, fmt.Errorf("chart %s not found", chartName)
 }
 
-func (m *miniHelm) InstallChart(ctx context.Context, restGetter genericclioptions.RESTClientGetter, repoName, releaseName, chartName, chartVersion, chartVals string, logger action.DebugLog) error {
-	_, err := installChart(ctx, m.settings, restGetter, repoName, releaseName, chartName, chartVersion, chartVals, logger)
+// GetLocalChartVersions returns the version of the chart in a local chart directory or packaged .tgz chart.
+func (m *miniHelm) GetLocalChartVersions(chartPath string) ([]string, error) {
+	chart, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading chart %s: %w", chartPath, err)
+	}
+	return []string{chart.Metadata.Version}, nil
+}
+
+// GetOCIChartVersions returns the semver tags of the chart in an OCI registry, newest first.
+func (m *miniHelm) GetOCIChartVersions(chartRef string) ([]string, error) {
+	return m.registryClient.Tags(strings.TrimPrefix(chartRef, fmt.Sprintf("%s://", registry.OCIScheme)))
+}
+
+// InstallChart installs the chart referenced as repo/chart, by a local path or by an oci:// reference.
+func (m *miniHelm) InstallChart(ctx context.Context, restGetter genericclioptions.RESTClientGetter, chartRef, releaseName, chartVersion string, chartVals map[string]interface{}, logger action.DebugLog) error {
+	_, err := installChart(ctx, m.settings, m.registryClient, restGetter, chartRef, releaseName, chartVersion, chartVals, logger)
 	return err
 }
 
-func (m *miniHelm) UpgradeChart(ctx context.Context, restGetter genericclioptions.RESTClientGetter, repoName, releaseName, chartName, chartVersion, chartVals string, logger action.DebugLog) error {
-	_, err := upgradeChart(ctx, m.settings, restGetter, repoName, releaseName, chartName, chartVersion, chartVals, logger)
+// UpgradeChart upgrades the release to the chart referenced as repo/chart, by a local path or by an oci:// reference.
+func (m *miniHelm) UpgradeChart(ctx context.Context, restGetter genericclioptions.RESTClientGetter, chartRef, releaseName, chartVersion string, chartVals map[string]interface{}, logger action.DebugLog) error {
+	_, err := upgradeChart(ctx, m.settings, m.registryClient, restGetter, chartRef, releaseName, chartVersion, chartVals, logger)
 	return err
 }
 
@@ -138,13 +166,14 @@ func (m *miniHelm) UninstallChartVersion(ctx context.Context, restGetter generic
 	return err
 }
 
-func installChart(ctx context.Context, settings *cli.EnvSettings, restGetter genericclioptions.RESTClientGetter, repoName, releaseName, chartName, chartVer, chartVals string, logger action.DebugLog) (*release.Release, error) {
+func installChart(ctx context.Context, settings *cli.EnvSettings, registryClient *registry.Client, restGetter genericclioptions.RESTClientGetter, chartRef, releaseName, chartVer string, chartVals map[string]interface{}, logger action.DebugLog) (*release.Release, error) {
 	namespace := setGetterNamespace(settings, restGetter)
 
 	var actionConfig action.Configuration
 	if err := actionConfig.Init(restGetter, namespace, os.Getenv("HELM_DRIVER"), logger); err != nil {
 		return nil, err
 	}
+	actionConfig.RegistryClient = registryClient
 	client := action.NewInstall(&actionConfig)
 
 	client.ReleaseName = releaseName
@@ -154,21 +183,12 @@ func installChart(ctx context.Context, settings *cli.EnvSettings, restGetter gen
 	}
 	client.CreateNamespace = true
 
-	cp, err := client.ChartPathOptions.LocateChart(fmt.Sprintf("%s/%s", repoName, chartName), settings)
+	cp, err := client.ChartPathOptions.LocateChart(chartRef, settings)
 	if err != nil {
 		return nil, err
 	}
 
 	providerGetters := getter.All(settings)
-	valueOpts := &values.Options{}
-	vals, err := valueOpts.MergeValues(providerGetters)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := strvals.ParseInto(chartVals, vals); err != nil {
-		return nil, err
-	}
 
 	chartRequested, err := loader.Load(cp)
 	if err != nil {
@@ -196,37 +216,29 @@ func installChart(ctx context.Context, settings *cli.EnvSettings, restGetter gen
 	}
 
 	client.Namespace = settings.Namespace()
-	return client.RunWithContext(ctx, chartRequested, vals)
+	return client.RunWithContext(ctx, chartRequested, chartVals)
 }
 
-func upgradeChart(ctx context.Context, settings *cli.EnvSettings, restGetter genericclioptions.RESTClientGetter, repoName, releaseName, chartName, chartVer, chartVals string, logger action.DebugLog) (*release.Release, error) {
+func upgradeChart(ctx context.Context, settings *cli.EnvSettings, registryClient *registry.Client, restGetter genericclioptions.RESTClientGetter, chartRef, releaseName, chartVer string, chartVals map[string]interface{}, logger action.DebugLog) (*release.Release, error) {
 	namespace := setGetterNamespace(settings, restGetter)
 
 	var actionConfig action.Configuration
 	if err := actionConfig.Init(restGetter, namespace, os.Getenv("HELM_DRIVER"), logger); err != nil {
 		return nil, err
 	}
+	actionConfig.RegistryClient = registryClient
 	client := action.NewUpgrade(&actionConfig)
 	client.Version = chartVer
 	if client.Version == "" {
 		client.Version = ">0.0.0-0"
 	}
 
-	cp, err := client.ChartPathOptions.LocateChart(fmt.Sprintf("%s/%s", repoName, chartName), settings)
+	cp, err := client.ChartPathOptions.LocateChart(chartRef, settings)
 	if err != nil {
 		return nil, err
 	}
 
 	providerGetters := getter.All(settings)
-	valueOpts := &values.Options{}
-	vals, err := valueOpts.MergeValues(providerGetters)
-	if err != nil {
-		return nil, err
-	}
-
-	if err := strvals.ParseInto(chartVals, vals); err != nil {
-		return nil, err
-	}
 
 	chartRequested, err := loader.Load(cp)
 	if err != nil {
@@ -254,7 +266,7 @@ func upgradeChart(ctx context.Context, settings *cli.EnvSettings, restGetter gen
 	}
 
 	client.Namespace = settings.Namespace()
-	return client.RunWithContext(ctx, releaseName, chartRequested, vals)
+	return client.RunWithContext(ctx, releaseName, chartRequested, chartVals)
 }
 
 func uninstallPDSChartWithContext(settings *cli.EnvSettings, restGetter genericclioptions.RESTClientGetter, releaseName string, logger action.DebugLog) (*release.UninstallReleaseResponse, error) {
@@ -0,0 +1,80 @@
+// Package pairwise reduces a combinatorial parameter space to a smaller set of combinations
+// which still covers every pair of values across any two dimensions.
+package pairwise
+
+// Combinations returns value index combinations over dimensions with the given sizes.
+// Every pair of values from any two dimensions appears in at least one combination.
+// Each returned combination holds one value index per dimension.
+// The result is deterministic for the same input.
+func Combinations(sizes ...int) [][]int {
+	for _, size := range sizes {
+		if size <= 0 {
+			return nil
+		}
+	}
+
+	all := product(sizes)
+	if len(sizes) < 2 {
+		return all
+	}
+
+	uncovered := make(map[pair]struct{})
+	for _, combination := range all {
+		for _, p := range pairsOf(combination) {
+			uncovered[p] = struct{}{}
+		}
+	}
+
+	// Greedy selection: always pick the combination which covers the most pairs not covered yet.
+	var result [][]int
+	for len(uncovered) > 0 {
+		best, bestCount := -1, 0
+		for i, combination := range all {
+			count := 0
+			for _, p := range pairsOf(combination) {
+				if _, ok := uncovered[p]; ok {
+					count++
+				}
+			}
+			if count > bestCount {
+				best, bestCount = i, count
+			}
+		}
+		for _, p := range pairsOf(all[best]) {
+			delete(uncovered, p)
+		}
+		result = append(result, all[best])
+	}
+	return result
+}
+
+type pair struct {
+	dim1, value1 int
+	dim2, value2 int
+}
+
+func pairsOf(combination []int) []pair {
+	var pairs []pair
+	for i := 0; i < len(combination); i++ {
+		for j := i + 1; j < len(combination); j++ {
+			pairs = append(pairs, pair{dim1: i, value1: combination[i], dim2: j, value2: combination[j]})
+		}
+	}
+	return pairs
+}
+
+func product(sizes []int) [][]int {
+	result := [][]int{{}}
+	for _, size := range sizes {
+		var next [][]int
+		for _, prefix := range result {
+			for value := 0; value < size; value++ {
+				combination := make([]int, len(prefix), len(prefix)+1)
+				copy(combination, prefix)
+				next = append(next, append(combination, value))
+			}
+		}
+		result = next
+	}
+	return result
+}
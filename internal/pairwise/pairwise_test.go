@@ -0,0 +1,74 @@
+package pairwise
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombinations_CoversAllPairs(t *testing.T) {
+	testCases := [][]int{
+		{2, 2},
+		{3, 2, 2},
+		{2, 2, 2, 2, 2},
+		{3, 3, 3, 3},
+		{4, 1, 3},
+	}
+	for _, sizes := range testCases {
+		t.Run(fmt.Sprint(sizes), func(t *testing.T) {
+			combinations := Combinations(sizes...)
+
+			for _, combination := range combinations {
+				require.Len(t, combination, len(sizes))
+				for dim, value := range combination {
+					require.GreaterOrEqual(t, value, 0)
+					require.Less(t, value, sizes[dim])
+				}
+			}
+			for dim1 := 0; dim1 < len(sizes); dim1++ {
+				for dim2 := dim1 + 1; dim2 < len(sizes); dim2++ {
+					for value1 := 0; value1 < sizes[dim1]; value1++ {
+						for value2 := 0; value2 < sizes[dim2]; value2++ {
+							assert.Truef(t, covers(combinations, dim1, value1, dim2, value2),
+								"Pair dim%d=%d, dim%d=%d is not covered.", dim1, value1, dim2, value2)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCombinations_ReducesProduct(t *testing.T) {
+	combinations := Combinations(2, 2, 2, 2, 2)
+	assert.Less(t, len(combinations), 32)
+
+	// At least the largest pair of dimensions has to be enumerated.
+	combinations = Combinations(3, 3, 3, 3)
+	assert.GreaterOrEqual(t, len(combinations), 9)
+	assert.Less(t, len(combinations), 81)
+}
+
+func TestCombinations_Deterministic(t *testing.T) {
+	expected := Combinations(3, 2, 2, 2, 3)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected, Combinations(3, 2, 2, 2, 3))
+	}
+}
+
+func TestCombinations_EdgeCases(t *testing.T) {
+	assert.Nil(t, Combinations(2, 0, 3))
+	assert.Equal(t, [][]int{{0}, {1}, {2}}, Combinations(3))
+	assert.Equal(t, [][]int{{}}, Combinations())
+}
+
+func covers(combinations [][]int, dim1, value1, dim2, value2 int) bool {
+	for _, combination := range combinations {
+		if combination[dim1] == value1 && combination[dim2] == value2 {
+			return true
+		}
+	}
+	return false
+}
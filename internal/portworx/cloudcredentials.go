@@ -20,6 +20,9 @@ type PXCloudCredential struct {
 		Endpoint  string `json:"endpoint"`
 		Region    string `json:"region"`
 	} `json:"aws_credential"`
+	AzureCredential struct {
+		AccountName string `json:"account_name"`
+	} `json:"azure_credential"`
 }
 
 type AWSCredentialsRequest struct {
@@ -27,12 +30,21 @@ type AWSCredentialsRequest struct {
 	Endpoint  string `json:"endpoint,omitempty"`
 	Region    string `json:"region,omitempty"`
 	SecretKey string `json:"secret_key,omitempty"`
+
+	DisablePathStyle bool `json:"disable_path_style,omitempty"`
+	DisableSSL       bool `json:"disable_ssl,omitempty"`
+}
+
+type AzureCredentialsRequest struct {
+	AccountName string `json:"account_name,omitempty"`
+	AccountKey  string `json:"account_key,omitempty"`
 }
 
 type CreateCredentialsRequest struct {
-	AwsCredential *AWSCredentialsRequest `json:"aws_credential,omitempty"`
-	Bucket        string                 `json:"bucket,omitempty"`
-	Name          string                 `json:"name"`
+	AwsCredential   *AWSCredentialsRequest   `json:"aws_credential,omitempty"`
+	AzureCredential *AzureCredentialsRequest `json:"azure_credential,omitempty"`
+	Bucket          string                   `json:"bucket,omitempty"`
+	Name            string                   `json:"name"`
 }
 
 // GetPXCloudCredential gets single Portworx cloud credential.
@@ -117,7 +129,7 @@ func (p *Portworx) FindCloudCredentialByName(ctx context.Context, name string) (
 }
 
 func (p *Portworx) CreatePXCloudCredentialsForS3(ctx context.Context, name, bucket string, s3 controlplane.S3Credentials) error {
-	requestBody := CreateCredentialsRequest{
+	return p.createPXCloudCredentials(ctx, CreateCredentialsRequest{
 		Name:   name,
 		Bucket: bucket,
 		AwsCredential: &AWSCredentialsRequest{
@@ -126,8 +138,57 @@ func (p *Portworx) CreatePXCloudCredentialsForS3(ctx context.Context, name, buck
 			Region:    "us-west-2",
 			Endpoint:  s3.Endpoint,
 		},
+	})
+}
+
+// CreatePXCloudCredentialsForS3Compatible creates Portworx cloud credentials for an S3-compatible object store.
+// Endpoints with the http:// scheme are accessed without SSL.
+func (p *Portworx) CreatePXCloudCredentialsForS3Compatible(ctx context.Context, name, bucket, region string, s3 controlplane.S3CompatibleCredentials) error {
+	endpoint := strings.TrimPrefix(s3.Endpoint, "https://")
+	disableSSL := strings.HasPrefix(endpoint, "http://")
+	endpoint = strings.TrimPrefix(endpoint, "http://")
+
+	return p.createPXCloudCredentials(ctx, CreateCredentialsRequest{
+		Name:   name,
+		Bucket: bucket,
+		AwsCredential: &AWSCredentialsRequest{
+			AccessKey:        s3.AccessKey,
+			SecretKey:        s3.SecretKey,
+			Region:           region,
+			Endpoint:         endpoint,
+			DisablePathStyle: !s3.PathStyle,
+			DisableSSL:       disableSSL,
+		},
+	})
+}
+
+// CreatePXCloudCredentialsForAzure creates Portworx cloud credentials for Azure Blob storage.
+func (p *Portworx) CreatePXCloudCredentialsForAzure(ctx context.Context, name, container string, azure controlplane.AzureCredentials) error {
+	return p.createPXCloudCredentials(ctx, CreateCredentialsRequest{
+		Name:   name,
+		Bucket: container,
+		AzureCredential: &AzureCredentialsRequest{
+			AccountName: azure.AccountName,
+			AccountKey:  azure.AccountKey,
+		},
+	})
+}
+
+// CreatePXCloudCredentialsForKind creates Portworx cloud credentials for the given object store kind.
+func (p *Portworx) CreatePXCloudCredentialsForKind(ctx context.Context, kind controlplane.ObjectStoreKind, name, bucket, region string, creds controlplane.BackupCredentials) error {
+	switch kind {
+	case controlplane.ObjectStoreS3:
+		return p.CreatePXCloudCredentialsForS3(ctx, name, bucket, creds.S3)
+	case controlplane.ObjectStoreS3Compatible:
+		return p.CreatePXCloudCredentialsForS3Compatible(ctx, name, bucket, region, creds.S3Compatible)
+	case controlplane.ObjectStoreAzure:
+		return p.CreatePXCloudCredentialsForAzure(ctx, name, bucket, creds.Azure)
+	default:
+		return fmt.Errorf("unsupported object store kind %q", kind)
 	}
+}
 
+func (p *Portworx) createPXCloudCredentials(ctx context.Context, requestBody CreateCredentialsRequest) error {
 	rawBody, err := json.Marshal(requestBody)
 	if err != nil {
 		return err
@@ -2,8 +2,48 @@ package portworx
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
+// PXVolume is a reduced Portworx volume detail.
+type PXVolume struct {
+	ID            string `json:"id"`
+	AttachedState string `json:"attached_state"`
+	Locator       struct {
+		Name string `json:"name"`
+	} `json:"locator"`
+	Spec struct {
+		HALevel       flexInt64 `json:"ha_level"`
+		Format        string    `json:"format"`
+		Encrypted     bool      `json:"encrypted"`
+		Size          flexInt64 `json:"size"`
+		GroupEnforced bool      `json:"group_enforced"`
+	} `json:"spec"`
+	ReplicaSets []struct {
+		Nodes []string `json:"nodes"`
+	} `json:"replica_sets"`
+}
+
+// flexInt64 accepts both JSON numbers and strings, Portworx API encodes 64-bit integers as strings.
+type flexInt64 int64
+
+func (f *flexInt64) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "" || value == "null" {
+		*f = 0
+		return nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexInt64(parsed)
+	return nil
+}
+
 func (p *Portworx) GetPXVolumes(
 	ctx context.Context,
 ) ([]byte, error) {
@@ -13,6 +53,47 @@ func (p *Portworx) GetPXVolumes(
 	).Do(ctx).Raw()
 }
 
+// ListPXVolumes lists all Portworx volumes.
+func (p *Portworx) ListPXVolumes(ctx context.Context) ([]PXVolume, error) {
+	// pxVolumesResponse is response from the Portworx API containing the volume details.
+	type pxVolumesResponse struct {
+		Volumes []struct {
+			Volume PXVolume `json:"volume"`
+		} `json:"volumes"`
+	}
+
+	volumesJSON, err := p.GetPXVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var response pxVolumesResponse
+	err = json.Unmarshal(volumesJSON, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]PXVolume, 0, len(response.Volumes))
+	for _, volume := range response.Volumes {
+		volumes = append(volumes, volume.Volume)
+	}
+	return volumes, nil
+}
+
+// FindPXVolumeByName finds Portworx volume by its name, which is the name of the Kubernetes PersistentVolume.
+func (p *Portworx) FindPXVolumeByName(ctx context.Context, name string) (*PXVolume, error) {
+	volumes, err := p.ListPXVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, volume := range volumes {
+		if volume.Locator.Name == name {
+			return &volume, nil
+		}
+	}
+	return nil, fmt.Errorf("volume '%s' not found", name)
+}
+
 func (p *Portworx) DeletePXVolume(
 	ctx context.Context,
 	volumeId string,
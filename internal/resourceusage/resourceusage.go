@@ -0,0 +1,227 @@
+// Package resourceusage summarizes CPU and memory usage of data service pods and compares it
+// with the requests and limits of the resource settings template the pods were deployed with.
+package resourceusage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
+)
+
+// Verdicts of a template evaluation.
+const (
+	VerdictFits             = "fits"
+	VerdictUnderProvisioned = "under-provisioned"
+	VerdictOverProvisioned  = "over-provisioned"
+	VerdictTooFewSamples    = "too few samples"
+)
+
+// Sample is the CPU and memory usage of the data service container of a pod at a point in time.
+type Sample struct {
+	Time        time.Time `json:"time"`
+	Pod         string    `json:"pod"`
+	CPUMillis   int64     `json:"cpuMillis"`
+	MemoryBytes int64     `json:"memoryBytes"`
+}
+
+// Stats are the peaks and averages of the samples of all pods of a deployment.
+type Stats struct {
+	Samples         int   `json:"samples"`
+	CPUPeakMillis   int64 `json:"cpuPeakMillis"`
+	CPUAvgMillis    int64 `json:"cpuAvgMillis"`
+	MemoryPeakBytes int64 `json:"memoryPeakBytes"`
+	MemoryAvgBytes  int64 `json:"memoryAvgBytes"`
+}
+
+// Summarize computes the peaks over all pods and the averages over all samples.
+func Summarize(samples []Sample) Stats {
+	stats := Stats{Samples: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	var cpuSum, memorySum int64
+	for _, sample := range samples {
+		cpuSum += sample.CPUMillis
+		memorySum += sample.MemoryBytes
+		if sample.CPUMillis > stats.CPUPeakMillis {
+			stats.CPUPeakMillis = sample.CPUMillis
+		}
+		if sample.MemoryBytes > stats.MemoryPeakBytes {
+			stats.MemoryPeakBytes = sample.MemoryBytes
+		}
+	}
+	stats.CPUAvgMillis = cpuSum / int64(len(samples))
+	stats.MemoryAvgBytes = memorySum / int64(len(samples))
+	return stats
+}
+
+// Template holds the requests and limits of a resource settings template per pod.
+type Template struct {
+	Name               string `json:"name"`
+	CPURequestMillis   int64  `json:"cpuRequestMillis"`
+	CPULimitMillis     int64  `json:"cpuLimitMillis"`
+	MemoryRequestBytes int64  `json:"memoryRequestBytes"`
+	MemoryLimitBytes   int64  `json:"memoryLimitBytes"`
+}
+
+// TemplateFromRequest parses the quantities of a template from dataservices.TemplateSpecs.
+func TemplateFromRequest(request pds.ControllersCreateResourceSettingsTemplateRequest) (Template, error) {
+	template := Template{Name: request.GetName()}
+	quantities := []struct {
+		name  string
+		value string
+		milli bool
+		into  *int64
+	}{
+		{name: "cpu request", value: request.GetCpuRequest(), milli: true, into: &template.CPURequestMillis},
+		{name: "cpu limit", value: request.GetCpuLimit(), milli: true, into: &template.CPULimitMillis},
+		{name: "memory request", value: request.GetMemoryRequest(), into: &template.MemoryRequestBytes},
+		{name: "memory limit", value: request.GetMemoryLimit(), into: &template.MemoryLimitBytes},
+	}
+	for _, q := range quantities {
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return template, fmt.Errorf("parsing %s of template %s: %w", q.name, template.Name, err)
+		}
+		if q.milli {
+			*q.into = quantity.MilliValue()
+		} else {
+			*q.into = quantity.Value()
+		}
+	}
+	return template, nil
+}
+
+// Thresholds control when a template is flagged, as fractions of the requests and limits.
+type Thresholds struct {
+	// PeakOfLimit flags a template as under-provisioned if the peak usage exceeds this fraction of the limit.
+	PeakOfLimit float64
+	// AverageOfRequest flags a template as under-provisioned if the average usage exceeds this fraction of the request.
+	AverageOfRequest float64
+	// PeakOfRequest flags a template as over-provisioned if the peak usage stays below this fraction of the request.
+	PeakOfRequest float64
+	// MinSamples is the number of samples required for a verdict, a short load test does not show the peak usage.
+	MinSamples int
+}
+
+// DefaultThresholds are used by the suites.
+var DefaultThresholds = Thresholds{
+	PeakOfLimit:      0.9,
+	AverageOfRequest: 1,
+	PeakOfRequest:    0.25,
+	MinSamples:       8,
+}
+
+// Entry is the evaluation of a template for a single deployment.
+type Entry struct {
+	DataService string   `json:"dataService"`
+	Version     string   `json:"version"`
+	Template    Template `json:"template"`
+	Stats       Stats    `json:"stats"`
+	Verdict     string   `json:"verdict"`
+	Reasons     []string `json:"reasons,omitempty"`
+}
+
+// Evaluate compares the usage with the requests and limits of the template.
+// Under-provisioning takes precedence, a template can have spare memory but not enough CPU.
+func Evaluate(dataService, version string, template Template, stats Stats, thresholds Thresholds) Entry {
+	entry := Entry{DataService: dataService, Version: version, Template: template, Stats: stats, Verdict: VerdictFits}
+	if stats.Samples == 0 || stats.Samples < thresholds.MinSamples {
+		entry.Verdict = VerdictTooFewSamples
+		entry.Reasons = []string{fmt.Sprintf("%d samples, expected at least %d", stats.Samples, thresholds.MinSamples)}
+		return entry
+	}
+
+	var under, over []string
+	check := func(resourceName string, peak, avg, request, limit int64, format func(int64) string) {
+		if limit > 0 && float64(peak) > thresholds.PeakOfLimit*float64(limit) {
+			under = append(under, fmt.Sprintf("%s peak %s is above %.0f%% of the limit %s", resourceName, format(peak), thresholds.PeakOfLimit*100, format(limit)))
+		}
+		if request > 0 && float64(avg) > thresholds.AverageOfRequest*float64(request) {
+			under = append(under, fmt.Sprintf("%s average %s is above %.0f%% of the request %s", resourceName, format(avg), thresholds.AverageOfRequest*100, format(request)))
+		}
+		if request > 0 && float64(peak) < thresholds.PeakOfRequest*float64(request) {
+			over = append(over, fmt.Sprintf("%s peak %s is below %.0f%% of the request %s", resourceName, format(peak), thresholds.PeakOfRequest*100, format(request)))
+		}
+	}
+	check("cpu", stats.CPUPeakMillis, stats.CPUAvgMillis, template.CPURequestMillis, template.CPULimitMillis, formatMillis)
+	check("memory", stats.MemoryPeakBytes, stats.MemoryAvgBytes, template.MemoryRequestBytes, template.MemoryLimitBytes, formatBytes)
+
+	switch {
+	case len(under) > 0:
+		entry.Verdict = VerdictUnderProvisioned
+		entry.Reasons = under
+	case len(over) > 0:
+		entry.Verdict = VerdictOverProvisioned
+		entry.Reasons = over
+	}
+	return entry
+}
+
+// Report collects the entries of a suite.
+type Report struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Flagged returns the entries with under- or over-provisioned templates.
+func (r Report) Flagged() []Entry {
+	var flagged []Entry
+	for _, entry := range r.Entries {
+		if entry.Verdict == VerdictUnderProvisioned || entry.Verdict == VerdictOverProvisioned {
+			flagged = append(flagged, entry)
+		}
+	}
+	return flagged
+}
+
+// String renders the report as a table ordered by data service, version and template.
+func (r Report) String() string {
+	entries := make([]Entry, len(r.Entries))
+	copy(entries, r.Entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].DataService != entries[j].DataService {
+			return entries[i].DataService < entries[j].DataService
+		}
+		if entries[i].Version != entries[j].Version {
+			return entries[i].Version < entries[j].Version
+		}
+		return entries[i].Template.Name < entries[j].Template.Name
+	})
+
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATA SERVICE\tVERSION\tTEMPLATE\tCPU PEAK/AVG (REQ/LIM)\tMEMORY PEAK/AVG (REQ/LIM)\tVERDICT")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s (%s/%s)\t%s/%s (%s/%s)\t%s\n",
+			e.DataService, e.Version, e.Template.Name,
+			formatMillis(e.Stats.CPUPeakMillis), formatMillis(e.Stats.CPUAvgMillis),
+			formatMillis(e.Template.CPURequestMillis), formatMillis(e.Template.CPULimitMillis),
+			formatBytes(e.Stats.MemoryPeakBytes), formatBytes(e.Stats.MemoryAvgBytes),
+			formatBytes(e.Template.MemoryRequestBytes), formatBytes(e.Template.MemoryLimitBytes),
+			e.Verdict,
+		)
+	}
+	_ = w.Flush()
+
+	for _, e := range entries {
+		for _, reason := range e.Reasons {
+			fmt.Fprintf(&sb, "%s %s %s: %s\n", e.DataService, e.Version, e.Template.Name, reason)
+		}
+	}
+	return sb.String()
+}
+
+func formatMillis(millis int64) string {
+	return resource.NewMilliQuantity(millis, resource.DecimalSI).String()
+}
+
+func formatBytes(bytes int64) string {
+	return resource.NewQuantity(bytes, resource.BinarySI).String()
+}
@@ -0,0 +1,117 @@
+package resourceusage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+
+	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
+)
+
+func TestSummarize(t *testing.T) {
+	now := time.Now()
+	stats := Summarize([]Sample{
+		{Time: now, Pod: "pg-0", CPUMillis: 100, MemoryBytes: 1000},
+		{Time: now, Pod: "pg-1", CPUMillis: 300, MemoryBytes: 2000},
+		{Time: now.Add(time.Minute), Pod: "pg-0", CPUMillis: 200, MemoryBytes: 6000},
+	})
+	assert.Equal(t, Stats{Samples: 3, CPUPeakMillis: 300, CPUAvgMillis: 200, MemoryPeakBytes: 6000, MemoryAvgBytes: 3000}, stats)
+	assert.Equal(t, Stats{}, Summarize(nil))
+}
+
+func TestTemplateFromRequest(t *testing.T) {
+	template, err := TemplateFromRequest(pds.ControllersCreateResourceSettingsTemplateRequest{
+		Name:          pointer.String("small"),
+		CpuRequest:    pointer.String("1"),
+		CpuLimit:      pointer.String("1.25"),
+		MemoryRequest: pointer.String("1500M"),
+		MemoryLimit:   pointer.String("2Gi"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Template{
+		Name:               "small",
+		CPURequestMillis:   1000,
+		CPULimitMillis:     1250,
+		MemoryRequestBytes: 1500 * 1000 * 1000,
+		MemoryLimitBytes:   2 * 1024 * 1024 * 1024,
+	}, template)
+
+	_, err = TemplateFromRequest(pds.ControllersCreateResourceSettingsTemplateRequest{Name: pointer.String("broken")})
+	assert.Error(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	template := Template{Name: "small", CPURequestMillis: 1000, CPULimitMillis: 2000, MemoryRequestBytes: 1000, MemoryLimitBytes: 2000}
+
+	testCases := []struct {
+		name            string
+		stats           Stats
+		expectedVerdict string
+		expectedReasons int
+	}{
+		{
+			name:            "fits",
+			stats:           Stats{Samples: 10, CPUPeakMillis: 1500, CPUAvgMillis: 800, MemoryPeakBytes: 1500, MemoryAvgBytes: 900},
+			expectedVerdict: VerdictFits,
+		},
+		{
+			name:            "peak near limit",
+			stats:           Stats{Samples: 10, CPUPeakMillis: 1900, CPUAvgMillis: 800, MemoryPeakBytes: 1500, MemoryAvgBytes: 900},
+			expectedVerdict: VerdictUnderProvisioned,
+			expectedReasons: 1,
+		},
+		{
+			name:            "average above request wins over idle memory",
+			stats:           Stats{Samples: 10, CPUPeakMillis: 1500, CPUAvgMillis: 1200, MemoryPeakBytes: 100, MemoryAvgBytes: 50},
+			expectedVerdict: VerdictUnderProvisioned,
+			expectedReasons: 1,
+		},
+		{
+			name:            "idle",
+			stats:           Stats{Samples: 10, CPUPeakMillis: 100, CPUAvgMillis: 50, MemoryPeakBytes: 100, MemoryAvgBytes: 50},
+			expectedVerdict: VerdictOverProvisioned,
+			expectedReasons: 2,
+		},
+		{
+			name:            "no samples",
+			expectedVerdict: VerdictTooFewSamples,
+			expectedReasons: 1,
+		},
+		{
+			name:            "too few samples",
+			stats:           Stats{Samples: 3, CPUPeakMillis: 1900, CPUAvgMillis: 800, MemoryPeakBytes: 1500, MemoryAvgBytes: 900},
+			expectedVerdict: VerdictTooFewSamples,
+			expectedReasons: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry := Evaluate("PostgreSQL", "14.6", template, tc.stats, DefaultThresholds)
+			assert.Equal(t, tc.expectedVerdict, entry.Verdict)
+			assert.Len(t, entry.Reasons, tc.expectedReasons)
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	template := Template{Name: "small", CPURequestMillis: 1000, CPULimitMillis: 2000, MemoryRequestBytes: 1 << 30, MemoryLimitBytes: 2 << 30}
+	report := Report{Entries: []Entry{
+		Evaluate("Redis", "7.0", template, Stats{Samples: 10, CPUPeakMillis: 1500, CPUAvgMillis: 800, MemoryPeakBytes: 1 << 30, MemoryAvgBytes: 1 << 29}, DefaultThresholds),
+		Evaluate("Cassandra", "4.1", template, Stats{Samples: 10, CPUPeakMillis: 1950, CPUAvgMillis: 800, MemoryPeakBytes: 1 << 30, MemoryAvgBytes: 1 << 29}, DefaultThresholds),
+	}}
+
+	flagged := report.Flagged()
+	require.Len(t, flagged, 1)
+	assert.Equal(t, "Cassandra", flagged[0].DataService)
+
+	out := report.String()
+	assert.Contains(t, out, "1950m/800m (1/2)")
+	assert.Contains(t, out, "1Gi/512Mi (1Gi/2Gi)")
+	assert.Contains(t, out, "Cassandra 4.1 small: cpu peak 1950m is above 90% of the limit 2")
+	assert.Less(t, strings.Index(out, "Cassandra"), strings.Index(out, "Redis"))
+}
@@ -0,0 +1,144 @@
+package tlscheck
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// directTLS is embedded by protocols which start the TLS handshake right after connecting.
+type directTLS struct{}
+
+func (directTLS) StartTLS(net.Conn) error {
+	return nil
+}
+
+// Redis is the RESP protocol with TLS enabled on the client port.
+type Redis struct {
+	directTLS
+}
+
+func (Redis) Name() string {
+	return "redis"
+}
+
+// AcceptsPlaintext sends a PING, any RESP reply including an authentication error means plaintext is served.
+func (Redis) AcceptsPlaintext(conn net.Conn, _ Credentials) (bool, error) {
+	_, err := conn.Write([]byte("PING\r\n"))
+	if err != nil {
+		return false, err
+	}
+	reply, err := bufio.NewReader(conn).ReadByte()
+	if err != nil {
+		return false, err
+	}
+	return reply == '+' || reply == '-', nil
+}
+
+// Kafka is the Kafka wire protocol with an SSL listener on the client port.
+type Kafka struct {
+	directTLS
+}
+
+func (Kafka) Name() string {
+	return "kafka"
+}
+
+// AcceptsPlaintext sends an ApiVersions request, a response with the same correlation ID means plaintext is served.
+func (Kafka) AcceptsPlaintext(conn net.Conn, _ Credentials) (bool, error) {
+	const (
+		apiVersionsKey = 18
+		correlationID  = 0x70647331
+		clientID       = "pds-integration-test"
+	)
+	request := make([]byte, 14, 14+len(clientID))
+	binary.BigEndian.PutUint32(request[0:4], uint32(10+len(clientID)))
+	binary.BigEndian.PutUint16(request[4:6], apiVersionsKey)
+	binary.BigEndian.PutUint16(request[6:8], 0)
+	binary.BigEndian.PutUint32(request[8:12], correlationID)
+	binary.BigEndian.PutUint16(request[12:14], uint16(len(clientID)))
+	request = append(request, clientID...)
+	_, err := conn.Write(request)
+	if err != nil {
+		return false, err
+	}
+
+	response := make([]byte, 8)
+	_, err = io.ReadFull(conn, response)
+	if err != nil {
+		return false, err
+	}
+	return binary.BigEndian.Uint32(response[4:8]) == correlationID, nil
+}
+
+// MongoDB is the MongoDB wire protocol with net.tls.mode set on the client port.
+type MongoDB struct {
+	directTLS
+}
+
+func (MongoDB) Name() string {
+	return "mongodb"
+}
+
+// AcceptsPlaintext sends a hello command, a reply to the request means plaintext is served.
+func (MongoDB) AcceptsPlaintext(conn net.Conn, _ Credentials) (bool, error) {
+	const (
+		requestID = 0x70647331
+		opMsg     = 2013
+	)
+	document := bsonDocument(
+		bsonInt32("hello", 1),
+		bsonString("$db", "admin"),
+	)
+
+	// Header (16), flag bits (4), section kind (1) and the document.
+	message := make([]byte, 21, 21+len(document))
+	binary.LittleEndian.PutUint32(message[0:4], uint32(21+len(document)))
+	binary.LittleEndian.PutUint32(message[4:8], requestID)
+	binary.LittleEndian.PutUint32(message[12:16], opMsg)
+	message = append(message, document...)
+	_, err := conn.Write(message)
+	if err != nil {
+		return false, err
+	}
+
+	header := make([]byte, 16)
+	_, err = io.ReadFull(conn, header)
+	if err != nil {
+		return false, err
+	}
+	responseTo := binary.LittleEndian.Uint32(header[8:12])
+	if responseTo != requestID {
+		return false, fmt.Errorf("unexpected response to request %d", responseTo)
+	}
+	return true, nil
+}
+
+func bsonDocument(elements ...[]byte) []byte {
+	length := 5
+	for _, element := range elements {
+		length += len(element)
+	}
+	document := make([]byte, 4, length)
+	binary.LittleEndian.PutUint32(document, uint32(length))
+	for _, element := range elements {
+		document = append(document, element...)
+	}
+	return append(document, 0)
+}
+
+func bsonInt32(name string, value int32) []byte {
+	element := append([]byte{0x10}, name...)
+	element = append(element, 0)
+	return binary.LittleEndian.AppendUint32(element, uint32(value))
+}
+
+func bsonString(name, value string) []byte {
+	element := append([]byte{0x02}, name...)
+	element = append(element, 0)
+	element = binary.LittleEndian.AppendUint32(element, uint32(len(value)+1))
+	element = append(element, value...)
+	return append(element, 0)
+}
@@ -0,0 +1,302 @@
+package tlscheck
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+const (
+	mysqlClientLongPassword     = 0x00000001
+	mysqlClientProtocol41       = 0x00000200
+	mysqlClientSSL              = 0x00000800
+	mysqlClientSecureConnection = 0x00008000
+	mysqlClientPluginAuth       = 0x00080000
+
+	mysqlMaxPacketSize = 1<<24 - 1
+	mysqlCharsetUTF8   = 33
+
+	mysqlNativePassword      = "mysql_native_password"
+	mysqlCachingSHA2Password = "caching_sha2_password"
+
+	// mysqlErrSecureTransportRequired is ER_SECURE_TRANSPORT_REQUIRED returned when require_secure_transport is set.
+	mysqlErrSecureTransportRequired = 3159
+)
+
+// MySQL negotiates TLS with the SSLRequest packet of the MySQL client/server protocol.
+type MySQL struct{}
+
+func (MySQL) Name() string {
+	return "mysql"
+}
+
+func (MySQL) StartTLS(conn net.Conn) error {
+	handshake, err := readMySQLHandshake(conn)
+	if err != nil {
+		return err
+	}
+	if handshake.capabilities&mysqlClientSSL == 0 {
+		return errors.New("server doesn't support SSL")
+	}
+
+	request := mysqlHandshakeHeader(mysqlClientSSL)
+	return writeMySQLPacket(conn, handshake.sequence+1, request)
+}
+
+// AcceptsPlaintext authenticates without TLS. MySQL rejects insecure transport only after a successful authentication,
+// so valid credentials are required.
+func (MySQL) AcceptsPlaintext(conn net.Conn, creds Credentials) (bool, error) {
+	handshake, err := readMySQLHandshake(conn)
+	if err != nil {
+		return false, err
+	}
+
+	plugin := handshake.authPlugin
+	nonce := handshake.authData
+	authResponse, err := mysqlScramble(plugin, creds.Password, nonce)
+	if err != nil {
+		return false, err
+	}
+
+	response := mysqlHandshakeHeader(0)
+	response = append(response, creds.Username...)
+	response = append(response, 0, byte(len(authResponse)))
+	response = append(response, authResponse...)
+	response = append(response, plugin...)
+	response = append(response, 0)
+	sequence := handshake.sequence + 1
+	err = writeMySQLPacket(conn, sequence, response)
+	if err != nil {
+		return false, err
+	}
+
+	for {
+		var packet []byte
+		sequence, packet, err = readMySQLPacket(conn)
+		if err != nil {
+			return false, err
+		}
+		if len(packet) == 0 {
+			return false, errors.New("empty packet")
+		}
+
+		switch packet[0] {
+		case 0x00:
+			return true, nil
+		case 0xff:
+			code, message := parseMySQLError(packet)
+			if code == mysqlErrSecureTransportRequired {
+				return false, nil
+			}
+			return false, fmt.Errorf("authentication failed with error %d: %s", code, message)
+		case 0xfe:
+			// Auth switch request with a new plugin and nonce.
+			name, data, _ := bytes.Cut(packet[1:], []byte{0})
+			plugin = string(name)
+			nonce = bytes.TrimSuffix(data, []byte{0})
+			authResponse, err = mysqlScramble(plugin, creds.Password, nonce)
+			if err != nil {
+				return false, err
+			}
+			sequence++
+			err = writeMySQLPacket(conn, sequence, authResponse)
+		case 0x01:
+			err = continueMySQLCachingSHA2Auth(conn, &sequence, packet[1:], creds.Password, nonce)
+		default:
+			return false, fmt.Errorf("unexpected packet 0x%02x", packet[0])
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// continueMySQLCachingSHA2Auth handles the extra data of caching_sha2_password. A full authentication over plaintext
+// requires the password encrypted with the server public key.
+func continueMySQLCachingSHA2Auth(conn net.Conn, sequence *byte, data []byte, password string, nonce []byte) error {
+	const (
+		fastAuthSuccess   = 3
+		fullAuthRequired  = 4
+		requestPublicKey  = 2
+		publicKeyResponse = '-'
+	)
+	switch {
+	case len(data) == 1 && data[0] == fastAuthSuccess:
+		return nil
+	case len(data) == 1 && data[0] == fullAuthRequired:
+		*sequence++
+		return writeMySQLPacket(conn, *sequence, []byte{requestPublicKey})
+	case len(data) > 0 && data[0] == publicKeyResponse:
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return errors.New("invalid server public key")
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("unexpected server public key type %T", key)
+		}
+		plain := append([]byte(password), 0)
+		for i := range plain {
+			plain[i] ^= nonce[i%len(nonce)]
+		}
+		encrypted, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, rsaKey, plain, nil) //nolint:gosec
+		if err != nil {
+			return err
+		}
+		*sequence++
+		return writeMySQLPacket(conn, *sequence, encrypted)
+	default:
+		return fmt.Errorf("unexpected auth data %v", data)
+	}
+}
+
+type mysqlHandshake struct {
+	sequence     byte
+	capabilities uint32
+	authData     []byte
+	authPlugin   string
+}
+
+func readMySQLHandshake(conn net.Conn) (mysqlHandshake, error) {
+	sequence, packet, err := readMySQLPacket(conn)
+	if err != nil {
+		return mysqlHandshake{}, err
+	}
+	if len(packet) > 0 && packet[0] == 0xff {
+		code, message := parseMySQLError(packet)
+		return mysqlHandshake{}, fmt.Errorf("server error %d: %s", code, message)
+	}
+	if len(packet) == 0 || packet[0] != 10 {
+		return mysqlHandshake{}, errors.New("unsupported handshake protocol version")
+	}
+
+	handshake := mysqlHandshake{sequence: sequence, authPlugin: mysqlNativePassword}
+	// Skip the NUL terminated server version.
+	end := bytes.IndexByte(packet[1:], 0)
+	if end < 0 {
+		return mysqlHandshake{}, errors.New("malformed handshake")
+	}
+	rest := packet[1+end+1:]
+	// Connection ID (4), auth data part 1 (8), filler (1), lower capability flags (2).
+	if len(rest) < 15 {
+		return mysqlHandshake{}, errors.New("malformed handshake")
+	}
+	handshake.authData = append(handshake.authData, rest[4:12]...)
+	handshake.capabilities = uint32(binary.LittleEndian.Uint16(rest[13:15]))
+	rest = rest[15:]
+
+	// Character set (1), status flags (2), upper capability flags (2), auth data length (1), reserved (10).
+	if len(rest) >= 16 {
+		handshake.capabilities |= uint32(binary.LittleEndian.Uint16(rest[3:5])) << 16
+		authDataLength := int(rest[5])
+		rest = rest[16:]
+		part2Length := authDataLength - 8
+		if part2Length < 13 {
+			part2Length = 13
+		}
+		if len(rest) >= part2Length {
+			handshake.authData = append(handshake.authData, bytes.TrimSuffix(rest[:part2Length], []byte{0})...)
+			rest = rest[part2Length:]
+		}
+		if plugin, _, found := bytes.Cut(rest, []byte{0}); found && len(plugin) > 0 {
+			handshake.authPlugin = string(plugin)
+		}
+	}
+	return handshake, nil
+}
+
+// mysqlHandshakeHeader returns the fixed part of HandshakeResponse41 and SSLRequest packets.
+func mysqlHandshakeHeader(extraCapabilities uint32) []byte {
+	header := make([]byte, 32)
+	capabilities := uint32(mysqlClientLongPassword|mysqlClientProtocol41|mysqlClientSecureConnection|mysqlClientPluginAuth) | extraCapabilities
+	binary.LittleEndian.PutUint32(header[0:4], capabilities)
+	binary.LittleEndian.PutUint32(header[4:8], mysqlMaxPacketSize)
+	header[8] = mysqlCharsetUTF8
+	return header
+}
+
+func mysqlScramble(plugin, password string, nonce []byte) ([]byte, error) {
+	if password == "" {
+		return nil, nil
+	}
+	switch plugin {
+	case mysqlNativePassword:
+		// SHA1(password) XOR SHA1(nonce + SHA1(SHA1(password)))
+		stage1 := sha1.Sum([]byte(password)) //nolint:gosec
+		stage2 := sha1.Sum(stage1[:])        //nolint:gosec
+		h := sha1.New()                      //nolint:gosec
+		h.Write(nonce)
+		h.Write(stage2[:])
+		return xorBytes(stage1[:], h.Sum(nil)), nil
+	case mysqlCachingSHA2Password:
+		// SHA256(password) XOR SHA256(SHA256(SHA256(password)) + nonce)
+		stage1 := sha256.Sum256([]byte(password))
+		stage2 := sha256.Sum256(stage1[:])
+		h := sha256.New()
+		h.Write(stage2[:])
+		h.Write(nonce)
+		return xorBytes(stage1[:], h.Sum(nil)), nil
+	default:
+		return nil, fmt.Errorf("unsupported auth plugin %q", plugin)
+	}
+}
+
+func xorBytes(a, b []byte) []byte {
+	result := make([]byte, len(a))
+	for i := range a {
+		result[i] = a[i] ^ b[i]
+	}
+	return result
+}
+
+func parseMySQLError(packet []byte) (uint16, string) {
+	if len(packet) < 3 {
+		return 0, ""
+	}
+	code := binary.LittleEndian.Uint16(packet[1:3])
+	message := packet[3:]
+	// Skip the SQL state marker and SQL state.
+	if len(message) >= 6 && message[0] == '#' {
+		message = message[6:]
+	}
+	return code, string(message)
+}
+
+func readMySQLPacket(conn net.Conn) (byte, []byte, error) {
+	header := make([]byte, 4)
+	_, err := io.ReadFull(conn, header)
+	if err != nil {
+		return 0, nil, err
+	}
+	length := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	payload := make([]byte, length)
+	_, err = io.ReadFull(conn, payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	return header[3], payload, nil
+}
+
+func writeMySQLPacket(conn net.Conn, sequence byte, payload []byte) error {
+	packet := make([]byte, 4, 4+len(payload))
+	packet[0] = byte(len(payload))
+	packet[1] = byte(len(payload) >> 8)
+	packet[2] = byte(len(payload) >> 16)
+	packet[3] = sequence
+	packet = append(packet, payload...)
+	_, err := conn.Write(packet)
+	return err
+}
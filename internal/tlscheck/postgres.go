@@ -0,0 +1,119 @@
+package tlscheck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+const (
+	postgresSSLRequestCode   = 80877103
+	postgresProtocolVersion3 = 196608
+	postgresMaxErrorLength   = 1 << 16
+
+	// postgresInvalidAuthorizationSpecification is the SQLSTATE returned when no pg_hba.conf entry matches the client,
+	// e.g. a plaintext connection to a server which allows only hostssl connections.
+	postgresInvalidAuthorizationSpecification = "28000"
+)
+
+// Postgres negotiates TLS with the SSLRequest message of the PostgreSQL protocol.
+type Postgres struct{}
+
+func (Postgres) Name() string {
+	return "postgres"
+}
+
+func (Postgres) StartTLS(conn net.Conn) error {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], postgresSSLRequestCode)
+	_, err := conn.Write(request)
+	if err != nil {
+		return err
+	}
+
+	response := make([]byte, 1)
+	_, err = io.ReadFull(conn, response)
+	if err != nil {
+		return err
+	}
+	if response[0] != 'S' {
+		return fmt.Errorf("server refused SSLRequest with %q", response[0])
+	}
+	return nil
+}
+
+// AcceptsPlaintext sends a startup message without TLS. The server accepts it by requesting authentication.
+// A server requiring TLS rejects the connection with the SQLSTATE invalid_authorization_specification, because
+// only hostssl entries of pg_hba.conf match the client. Other errors are returned.
+func (Postgres) AcceptsPlaintext(conn net.Conn, creds Credentials) (bool, error) {
+	var params bytes.Buffer
+	for _, param := range []string{"user", creds.Username, "database", creds.Username} {
+		params.WriteString(param)
+		params.WriteByte(0)
+	}
+	params.WriteByte(0)
+
+	message := make([]byte, 8, 8+params.Len())
+	binary.BigEndian.PutUint32(message[0:4], uint32(8+params.Len()))
+	binary.BigEndian.PutUint32(message[4:8], postgresProtocolVersion3)
+	message = append(message, params.Bytes()...)
+	_, err := conn.Write(message)
+	if err != nil {
+		return false, err
+	}
+
+	messageType := make([]byte, 1)
+	_, err = io.ReadFull(conn, messageType)
+	if err != nil {
+		return false, err
+	}
+	switch messageType[0] {
+	case 'R':
+		return true, nil
+	case 'E':
+		fields, err := readPostgresErrorFields(conn)
+		if err != nil {
+			return false, err
+		}
+		if fields['C'] == postgresInvalidAuthorizationSpecification {
+			return false, nil
+		}
+		return false, fmt.Errorf("server error %s: %s", fields['C'], fields['M'])
+	default:
+		return false, fmt.Errorf("unexpected message type %q", messageType[0])
+	}
+}
+
+// readPostgresErrorFields reads the body of an ErrorResponse message after the message type.
+// The fields are keyed by their type, e.g. 'C' for the SQLSTATE code and 'M' for the message.
+func readPostgresErrorFields(conn net.Conn) (map[byte]string, error) {
+	length := make([]byte, 4)
+	_, err := io.ReadFull(conn, length)
+	if err != nil {
+		return nil, err
+	}
+	bodyLength := int(binary.BigEndian.Uint32(length)) - 4
+	if bodyLength < 0 || bodyLength > postgresMaxErrorLength {
+		return nil, fmt.Errorf("invalid error response length %d", bodyLength)
+	}
+	body := make([]byte, bodyLength)
+	_, err = io.ReadFull(conn, body)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[byte]string)
+	for len(body) > 0 && body[0] != 0 {
+		value, rest, found := bytes.Cut(body[1:], []byte{0})
+		if !found {
+			return nil, errors.New("malformed error response")
+		}
+		fields[body[0]] = string(value)
+		body = rest
+	}
+	return fields, nil
+}
@@ -0,0 +1,165 @@
+// Package tlscheck performs TLS handshakes and plaintext probes against data service client ports
+// using their native wire protocols.
+package tlscheck
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+)
+
+// DefaultTimeout limits a single handshake or probe if the context has no deadline.
+const DefaultTimeout = 30 * time.Second
+
+// Credentials are used by protocols which refuse plaintext connections only after authentication.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Protocol negotiates TLS and probes plaintext access using the client protocol of a data service.
+type Protocol interface {
+	Name() string
+	// StartTLS prepares the plaintext connection for the TLS handshake, e.g. by sending a STARTTLS request.
+	StartTLS(conn net.Conn) error
+	// AcceptsPlaintext reports whether the server serves the protocol over the plaintext connection.
+	AcceptsPlaintext(conn net.Conn, creds Credentials) (bool, error)
+}
+
+var protocols = map[string]struct {
+	protocol Protocol
+	port     int
+}{
+	dataservices.Postgres: {Postgres{}, 5432},
+	dataservices.MySQL:    {MySQL{}, 3306},
+	dataservices.Redis:    {Redis{}, 6379},
+	dataservices.Kafka:    {Kafka{}, 9092},
+	dataservices.MongoDB:  {MongoDB{}, 27017},
+}
+
+// ForDataService returns the client protocol and port of the data service.
+func ForDataService(dataServiceType string) (Protocol, int, bool) {
+	p, ok := protocols[dataServiceType]
+	return p.protocol, p.port, ok
+}
+
+// Handshake connects to the address, negotiates TLS using the protocol and performs the TLS handshake.
+// The certificate chain isn't verified by the handshake, use VerifyChain and VerifyHostnames on the result.
+func Handshake(ctx context.Context, protocol Protocol, address string) (*tls.ConnectionState, error) {
+	ctx, cancel := withDefaultTimeout(ctx)
+	defer cancel()
+
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	err = protocol.StartTLS(conn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: start TLS: %w", protocol.Name(), err)
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		// Chain and hostnames are verified separately, the address is a port-forward to localhost.
+		InsecureSkipVerify: true, //nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	})
+	err = tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: TLS handshake: %w", protocol.Name(), err)
+	}
+	state := tlsConn.ConnectionState()
+	return &state, nil
+}
+
+// AcceptsPlaintext connects to the address and reports whether the server serves the protocol without TLS.
+// A closed or reset connection means the plaintext connection was refused. A server which doesn't answer within the
+// deadline is a probe error, it may be a slow plaintext listener.
+func AcceptsPlaintext(ctx context.Context, protocol Protocol, address string, creds Credentials) (bool, error) {
+	ctx, cancel := withDefaultTimeout(ctx)
+	defer cancel()
+
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	accepted, err := protocol.AcceptsPlaintext(conn, creds)
+	if isConnectionClosed(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: plaintext probe: %w", protocol.Name(), err)
+	}
+	return accepted, nil
+}
+
+// VerifyChain verifies the peer certificates against the root CAs.
+func VerifyChain(peerCertificates []*x509.Certificate, roots *x509.CertPool) error {
+	if len(peerCertificates) == 0 {
+		return errors.New("no peer certificates")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range peerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := peerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+// VerifyHostnames checks that the certificate is valid for at least one of the hostnames.
+func VerifyHostnames(cert *x509.Certificate, hostnames ...string) error {
+	for _, hostname := range hostnames {
+		if cert.VerifyHostname(hostname) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("certificate with SANs %s is not valid for any of %s",
+		strings.Join(cert.DNSNames, ","), strings.Join(hostnames, ","))
+}
+
+func dial(ctx context.Context, address string) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, DefaultTimeout)
+}
+
+func isConnectionClosed(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
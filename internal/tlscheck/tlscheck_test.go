@@ -0,0 +1,487 @@
+package tlscheck
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresHandshake(t *testing.T) {
+	ca, caKey := newCertificate(t, nil, nil, "test-ca")
+	serverCert, serverKey := newCertificate(t, ca, caKey, "pg", "pg.ns.svc", "*.pg-vip.ns.svc")
+
+	address := serve(t, func(conn net.Conn) {
+		request := make([]byte, 8)
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte{'S'})
+		tlsConn := tls.Server(conn, &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.Raw}, PrivateKey: serverKey}},
+		})
+		_ = tlsConn.Handshake()
+	})
+
+	state, err := Handshake(context.Background(), Postgres{}, address)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	assert.NoError(t, VerifyChain(state.PeerCertificates, roots))
+	assert.Error(t, VerifyChain(state.PeerCertificates, x509.NewCertPool()))
+
+	cert := state.PeerCertificates[0]
+	assert.NoError(t, VerifyHostnames(cert, "pg.ns.svc"))
+	assert.NoError(t, VerifyHostnames(cert, "pg-vip.ns.svc", "pg-0.pg-vip.ns.svc"))
+	assert.Error(t, VerifyHostnames(cert, "other.ns.svc"))
+}
+
+func TestPostgresAcceptsPlaintext(t *testing.T) {
+	testCases := []struct {
+		name        string
+		reply       []byte
+		expected    bool
+		expectedErr string
+	}{
+		{
+			name:     "authentication requested",
+			reply:    postgresMessage('R', []byte{0, 0, 0, 10}),
+			expected: true,
+		},
+		{
+			name:     "no pg_hba.conf entry",
+			reply:    postgresError("FATAL", "28000", `no pg_hba.conf entry for host "10.0.0.1", user "pds", database "pds", no encryption`),
+			expected: false,
+		},
+		{
+			name:        "database does not exist",
+			reply:       postgresError("FATAL", "3D000", `database "pds" does not exist`),
+			expectedErr: `server error 3D000: database "pds" does not exist`,
+		},
+		{
+			name:        "too many connections",
+			reply:       postgresError("FATAL", "53300", "sorry, too many clients already"),
+			expectedErr: "server error 53300: sorry, too many clients already",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address := serve(t, func(conn net.Conn) {
+				length := make([]byte, 4)
+				if _, err := io.ReadFull(conn, length); err != nil {
+					return
+				}
+				if _, err := io.ReadFull(conn, make([]byte, binary.BigEndian.Uint32(length)-4)); err != nil {
+					return
+				}
+				_, _ = conn.Write(tc.reply)
+			})
+
+			accepted, err := AcceptsPlaintext(context.Background(), Postgres{}, address, Credentials{Username: "pds"})
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, accepted)
+		})
+	}
+}
+
+func TestRedisAcceptsPlaintext(t *testing.T) {
+	testCases := []struct {
+		name     string
+		reply    string
+		expected bool
+	}{
+		{name: "pong", reply: "+PONG\r\n", expected: true},
+		{name: "authentication required", reply: "-NOAUTH Authentication required.\r\n", expected: true},
+		{name: "tls alert", reply: "\x15\x03\x01\x00\x02\x02\x46", expected: false},
+		{name: "connection closed", reply: "", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address := serve(t, func(conn net.Conn) {
+				_, _ = bufio.NewReader(conn).ReadString('\n')
+				_, _ = conn.Write([]byte(tc.reply))
+			})
+
+			accepted, err := AcceptsPlaintext(context.Background(), Redis{}, address, Credentials{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, accepted)
+		})
+	}
+}
+
+func TestAcceptsPlaintext_NoAnswer(t *testing.T) {
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	address := serve(t, func(conn net.Conn) {
+		_, _ = bufio.NewReader(conn).ReadString('\n')
+		<-done
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := AcceptsPlaintext(ctx, Redis{}, address, Credentials{})
+	require.Error(t, err, "A server which doesn't answer is not a refused plaintext connection.")
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+}
+
+func TestMySQLScramble(t *testing.T) {
+	// Known answers of the go-sql-driver/mysql authentication tests for the password "secret".
+	native, err := mysqlScramble(mysqlNativePassword, "secret",
+		[]byte{70, 114, 92, 94, 1, 38, 11, 116, 63, 114, 23, 101, 126, 103, 26, 95, 81, 17, 24, 21})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{53, 177, 140, 159, 251, 189, 127, 53, 109, 252, 172, 50, 211, 192, 240, 164, 26, 48, 207, 45}, native)
+
+	sha2, err := mysqlScramble(mysqlCachingSHA2Password, "secret",
+		[]byte{90, 105, 74, 126, 30, 48, 37, 56, 3, 23, 115, 127, 69, 22, 41, 84, 32, 123, 43, 118})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{102, 32, 5, 35, 143, 161, 140, 241, 171, 232, 56, 139, 43, 14, 107, 196, 249, 170, 147, 60,
+		220, 204, 120, 178, 214, 15, 184, 150, 26, 61, 57, 235}, sha2)
+
+	empty, err := mysqlScramble(mysqlNativePassword, "", []byte(testMySQLNonce))
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	_, err = mysqlScramble("unknown", "secret", []byte(testMySQLNonce))
+	assert.Error(t, err)
+}
+
+func TestMySQLHandshake(t *testing.T) {
+	ca, caKey := newCertificate(t, nil, nil, "test-ca")
+	serverCert, serverKey := newCertificate(t, ca, caKey, "mysql", "mysql.ns.svc")
+	sslRequest := make(chan []byte, 1)
+
+	address := serve(t, func(conn net.Conn) {
+		if writeMySQLPacket(conn, 0, mysqlServerHandshake(mysqlNativePassword, mysqlClientSSL)) != nil {
+			return
+		}
+		sequence, packet, err := readMySQLPacket(conn)
+		if err != nil || sequence != 1 {
+			return
+		}
+		sslRequest <- packet
+		tlsConn := tls.Server(conn, &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.Raw}, PrivateKey: serverKey}},
+		})
+		_ = tlsConn.Handshake()
+	})
+
+	state, err := Handshake(context.Background(), MySQL{}, address)
+	require.NoError(t, err)
+	assert.Equal(t, "mysql", state.PeerCertificates[0].Subject.CommonName)
+
+	packet := <-sslRequest
+	require.Len(t, packet, 32)
+	assert.NotZero(t, binary.LittleEndian.Uint32(packet[0:4])&mysqlClientSSL, "SSLRequest without CLIENT_SSL.")
+}
+
+func TestMySQLHandshake_SSLNotSupported(t *testing.T) {
+	address := serve(t, func(conn net.Conn) {
+		_ = writeMySQLPacket(conn, 0, mysqlServerHandshake(mysqlNativePassword, 0))
+	})
+
+	_, err := Handshake(context.Background(), MySQL{}, address)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server doesn't support SSL")
+}
+
+func TestMySQLAcceptsPlaintext(t *testing.T) {
+	const password = "secret"
+	nativeResponse, err := mysqlScramble(mysqlNativePassword, password, []byte(testMySQLNonce))
+	require.NoError(t, err)
+	sha2Response, err := mysqlScramble(mysqlCachingSHA2Password, password, []byte(testMySQLNonce))
+	require.NoError(t, err)
+	switchNonce := "abcdefghij0123456789"
+	switchResponse, err := mysqlScramble(mysqlNativePassword, password, []byte(switchNonce))
+	require.NoError(t, err)
+
+	okPacket := []byte{0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00}
+	testCases := []struct {
+		name        string
+		plugin      string
+		exchange    []mysqlExchange
+		expected    bool
+		expectedErr string
+	}{
+		{
+			name:     "native password accepted",
+			plugin:   mysqlNativePassword,
+			exchange: []mysqlExchange{{expectedAuth: nativeResponse, reply: okPacket}},
+			expected: true,
+		},
+		{
+			name:     "caching sha2 fast authentication",
+			plugin:   mysqlCachingSHA2Password,
+			exchange: []mysqlExchange{{expectedAuth: sha2Response, reply: []byte{0x01, 0x03}}},
+			expected: true,
+		},
+		{
+			name:   "auth switch",
+			plugin: mysqlCachingSHA2Password,
+			exchange: []mysqlExchange{
+				{expectedAuth: sha2Response, reply: append(append([]byte{0xfe}, mysqlNativePassword+"\x00"...), switchNonce+"\x00"...)},
+				{expectedAuth: switchResponse, reply: okPacket},
+			},
+			expected: true,
+		},
+		{
+			name:     "secure transport required",
+			plugin:   mysqlNativePassword,
+			exchange: []mysqlExchange{{expectedAuth: nativeResponse, reply: mysqlErrorPacket(mysqlErrSecureTransportRequired, "HY000", "Connections using insecure transport are prohibited while --require_secure_transport=ON.")}},
+			expected: false,
+		},
+		{
+			name:        "access denied",
+			plugin:      mysqlNativePassword,
+			exchange:    []mysqlExchange{{expectedAuth: nativeResponse, reply: mysqlErrorPacket(1045, "28000", "Access denied for user 'pds'")}},
+			expectedErr: "authentication failed with error 1045: Access denied for user 'pds'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address := serve(t, func(conn net.Conn) {
+				if writeMySQLPacket(conn, 0, mysqlServerHandshake(tc.plugin, 0)) != nil {
+					return
+				}
+				for i, exchange := range tc.exchange {
+					sequence, packet, err := readMySQLPacket(conn)
+					if err != nil {
+						return
+					}
+					if i == 0 {
+						// The auth response of HandshakeResponse41 follows the header and the NUL terminated user name.
+						user, rest, _ := bytes.Cut(packet[32:], []byte{0})
+						packet = rest[1 : 1+int(rest[0])]
+						if string(user) != "pds" {
+							return
+						}
+					}
+					if exchange.expectedAuth != nil && !bytes.Equal(exchange.expectedAuth, packet) {
+						_ = writeMySQLPacket(conn, sequence+1, mysqlErrorPacket(1045, "28000", "wrong auth response"))
+						return
+					}
+					if writeMySQLPacket(conn, sequence+1, exchange.reply) != nil {
+						return
+					}
+					// Fast authentication success is followed by the OK packet without a client packet.
+					if bytes.Equal(exchange.reply, []byte{0x01, 0x03}) {
+						_ = writeMySQLPacket(conn, sequence+2, okPacket)
+						return
+					}
+				}
+			})
+
+			accepted, err := AcceptsPlaintext(context.Background(), MySQL{}, address, Credentials{Username: "pds", Password: password})
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, accepted)
+		})
+	}
+}
+
+func TestKafkaAcceptsPlaintext(t *testing.T) {
+	testCases := []struct {
+		name          string
+		correlationID uint32
+		reply         []byte
+		expected      bool
+	}{
+		{name: "api versions response", correlationID: 0, expected: true},
+		{name: "other correlation id", correlationID: 42, expected: false},
+		{name: "tls alert", reply: []byte("\x15\x03\x01\x00\x02\x02\x46\x00"), expected: false},
+		{name: "connection closed", reply: []byte{}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address := serve(t, func(conn net.Conn) {
+				length := make([]byte, 4)
+				if _, err := io.ReadFull(conn, length); err != nil {
+					return
+				}
+				request := make([]byte, binary.BigEndian.Uint32(length))
+				if _, err := io.ReadFull(conn, request); err != nil {
+					return
+				}
+				reply := tc.reply
+				if reply == nil {
+					correlationID := tc.correlationID
+					if correlationID == 0 {
+						correlationID = binary.BigEndian.Uint32(request[4:8])
+					}
+					// Response length, correlation ID and the error code.
+					reply = binary.BigEndian.AppendUint32(nil, 6)
+					reply = binary.BigEndian.AppendUint32(reply, correlationID)
+					reply = binary.BigEndian.AppendUint16(reply, 0)
+				}
+				_, _ = conn.Write(reply)
+			})
+
+			accepted, err := AcceptsPlaintext(context.Background(), Kafka{}, address, Credentials{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, accepted)
+		})
+	}
+}
+
+func TestMongoDBAcceptsPlaintext(t *testing.T) {
+	testCases := []struct {
+		name        string
+		respondTo   func(requestID uint32) uint32
+		close       bool
+		expected    bool
+		expectedErr string
+	}{
+		{name: "hello reply", respondTo: func(requestID uint32) uint32 { return requestID }, expected: true},
+		{name: "reply to other request", respondTo: func(uint32) uint32 { return 1 }, expectedErr: "unexpected response to request 1"},
+		{name: "connection closed", close: true, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			address := serve(t, func(conn net.Conn) {
+				header := make([]byte, 16)
+				if _, err := io.ReadFull(conn, header); err != nil {
+					return
+				}
+				body := make([]byte, binary.LittleEndian.Uint32(header[0:4])-16)
+				if _, err := io.ReadFull(conn, body); err != nil || tc.close {
+					return
+				}
+				// OP_MSG reply with {ok: 1}.
+				document := bsonDocument(bsonInt32("ok", 1))
+				reply := make([]byte, 21, 21+len(document))
+				binary.LittleEndian.PutUint32(reply[0:4], uint32(21+len(document)))
+				binary.LittleEndian.PutUint32(reply[8:12], tc.respondTo(binary.LittleEndian.Uint32(header[4:8])))
+				binary.LittleEndian.PutUint32(reply[12:16], 2013)
+				_, _ = conn.Write(append(reply, document...))
+			})
+
+			accepted, err := AcceptsPlaintext(context.Background(), MongoDB{}, address, Credentials{})
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, accepted)
+		})
+	}
+}
+
+func serve(t *testing.T, handle func(conn net.Conn)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}()
+	return listener.Addr().String()
+}
+
+// testMySQLNonce is the 20 bytes authentication nonce of the fake MySQL server handshake.
+const testMySQLNonce = "0123456789ABCDEFGHIJ"
+
+// mysqlExchange is a packet of the client, checked against expectedAuth if set, and the reply of the fake server.
+type mysqlExchange struct {
+	expectedAuth []byte
+	reply        []byte
+}
+
+// mysqlServerHandshake returns a HandshakeV10 packet with the test nonce.
+func mysqlServerHandshake(plugin string, extraCapabilities uint32) []byte {
+	capabilities := mysqlClientLongPassword | mysqlClientProtocol41 | mysqlClientSecureConnection | mysqlClientPluginAuth | extraCapabilities
+	packet := append([]byte{10}, "8.0.32\x00"...)
+	packet = binary.LittleEndian.AppendUint32(packet, 1)
+	packet = append(packet, testMySQLNonce[:8]...)
+	packet = append(packet, 0)
+	packet = binary.LittleEndian.AppendUint16(packet, uint16(capabilities))
+	packet = append(packet, mysqlCharsetUTF8, 0x02, 0x00)
+	packet = binary.LittleEndian.AppendUint16(packet, uint16(capabilities>>16))
+	packet = append(packet, byte(len(testMySQLNonce)+1))
+	packet = append(packet, make([]byte, 10)...)
+	packet = append(packet, testMySQLNonce[8:]+"\x00"...)
+	return append(packet, plugin+"\x00"...)
+}
+
+func mysqlErrorPacket(code uint16, sqlState, message string) []byte {
+	packet := binary.LittleEndian.AppendUint16([]byte{0xff}, code)
+	return append(packet, "#"+sqlState+message...)
+}
+
+func postgresMessage(messageType byte, body []byte) []byte {
+	message := binary.BigEndian.AppendUint32([]byte{messageType}, uint32(4+len(body)))
+	return append(message, body...)
+}
+
+func postgresError(severity, code, message string) []byte {
+	var body []byte
+	for _, field := range []struct {
+		fieldType byte
+		value     string
+	}{{'S', severity}, {'V', severity}, {'C', code}, {'M', message}} {
+		body = append(body, field.fieldType)
+		body = append(body, field.value+"\x00"...)
+	}
+	return postgresMessage('E', append(body, 0))
+}
+
+// newCertificate creates a CA certificate if parent is nil, otherwise a server certificate signed by the parent.
+func newCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, commonName string, dnsNames ...string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
@@ -0,0 +1,239 @@
+// Package workload records the outcome of a continuous background workload against a data service and
+// evaluates the unavailability windows against per data service tolerances.
+package workload
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+)
+
+// Kinds of operations of the workload.
+const (
+	KindWrite = "write"
+	KindRead  = "read"
+)
+
+// maxReportedErrors bounds the distinct errors listed in the summary of a timeline.
+const maxReportedErrors = 5
+
+// Operation is a single write or read of the workload.
+type Operation struct {
+	Time    time.Time     `json:"time"`
+	Kind    string        `json:"kind"`
+	Latency time.Duration `json:"latency"`
+	Error   string        `json:"error,omitempty"`
+	// Lost is set on reads which found records of an acknowledged write overwritten by an older value or missing.
+	Lost bool `json:"lost,omitempty"`
+}
+
+// Failed returns true if the operation returned an error.
+func (o Operation) Failed() bool {
+	return o.Error != ""
+}
+
+// Window is a period of consecutive failed operations of a kind. It starts with the first failed operation and
+// ends with the next successful one. Open windows were not closed by a successful operation until the workload
+// was stopped and end with the last failed operation.
+type Window struct {
+	Kind   string    `json:"kind"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Failed int       `json:"failed"`
+	Open   bool      `json:"open"`
+}
+
+// Duration of the window.
+func (w Window) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+func (w Window) String() string {
+	s := fmt.Sprintf("%s %s for %s (%d failed)", w.Kind, w.Start.Format(time.RFC3339), w.Duration().Round(time.Second), w.Failed)
+	if w.Open {
+		s += ", not recovered"
+	}
+	return s
+}
+
+// Timeline is the list of operations of the workload in the order they were run.
+type Timeline []Operation
+
+// Windows returns the unavailability windows of the operations of the given kind.
+func (tl Timeline) Windows(kind string) []Window {
+	var (
+		windows []Window
+		current *Window
+	)
+	for _, op := range tl {
+		if op.Kind != kind {
+			continue
+		}
+		if !op.Failed() {
+			if current != nil {
+				current.End = op.Time
+				windows = append(windows, *current)
+				current = nil
+			}
+			continue
+		}
+		if current == nil {
+			current = &Window{Kind: kind, Start: op.Time}
+		}
+		current.End = op.Time
+		current.Failed++
+	}
+	if current != nil {
+		current.Open = true
+		windows = append(windows, *current)
+	}
+	return windows
+}
+
+// Longest returns the longest unavailability window of the operations of the given kind.
+func (tl Timeline) Longest(kind string) (Window, bool) {
+	var (
+		longest Window
+		found   bool
+	)
+	for _, window := range tl.Windows(kind) {
+		if !found || window.Duration() > longest.Duration() {
+			longest = window
+			found = true
+		}
+	}
+	return longest, found
+}
+
+// Count returns the number of all and of failed operations of the given kind.
+func (tl Timeline) Count(kind string) (total, failed int) {
+	for _, op := range tl {
+		if op.Kind != kind {
+			continue
+		}
+		total++
+		if op.Failed() {
+			failed++
+		}
+	}
+	return total, failed
+}
+
+// LostWrites returns the reads which found acknowledged writes lost.
+func (tl Timeline) LostWrites() []Operation {
+	var lost []Operation
+	for _, op := range tl {
+		if op.Lost {
+			lost = append(lost, op)
+		}
+	}
+	return lost
+}
+
+// Summary describes the operations, the unavailability windows and the distinct errors of the timeline.
+func (tl Timeline) Summary() string {
+	var b strings.Builder
+	for _, kind := range []string{KindWrite, KindRead} {
+		total, failed := tl.Count(kind)
+		fmt.Fprintf(&b, "%ss: %d, failed: %d\n", kind, total, failed)
+		for _, window := range tl.Windows(kind) {
+			fmt.Fprintf(&b, "  unavailable: %s\n", window)
+		}
+	}
+	if lost := tl.LostWrites(); len(lost) > 0 {
+		fmt.Fprintf(&b, "reads with lost writes: %d, first at %s\n", len(lost), lost[0].Time.Format(time.RFC3339))
+	}
+
+	seen := make(map[string]bool)
+	for _, op := range tl {
+		if !op.Failed() || seen[op.Error] {
+			continue
+		}
+		if len(seen) == maxReportedErrors {
+			b.WriteString("  ...\n")
+			break
+		}
+		seen[op.Error] = true
+		fmt.Fprintf(&b, "  error: %s\n", op.Error)
+	}
+	return b.String()
+}
+
+// Tolerance is the longest acceptable unavailability of writes and reads.
+type Tolerance struct {
+	MaxWriteUnavailability time.Duration
+	MaxReadUnavailability  time.Duration
+}
+
+// NoDisruption tolerates only short hiccups, e.g. of port-forwards, for changes which must not restart pods.
+var NoDisruption = Tolerance{MaxWriteUnavailability: 10 * time.Second, MaxReadUnavailability: 10 * time.Second}
+
+// singleNodeTolerance applies to single node deployments, which are unavailable while their only pod restarts.
+var singleNodeTolerance = Tolerance{MaxWriteUnavailability: 5 * time.Minute, MaxReadUnavailability: 5 * time.Minute}
+
+// rolloutTolerances of multi-node deployments during rolling updates and scaling.
+var rolloutTolerances = map[string]Tolerance{
+	dataservices.Cassandra:     {MaxWriteUnavailability: 30 * time.Second, MaxReadUnavailability: 30 * time.Second},
+	dataservices.Consul:        {MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute},
+	dataservices.Couchbase:     {MaxWriteUnavailability: 2 * time.Minute, MaxReadUnavailability: 2 * time.Minute},
+	dataservices.ElasticSearch: {MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute},
+	dataservices.Kafka:         {MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute},
+	dataservices.MongoDB:       {MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute},
+	dataservices.MySQL:         {MaxWriteUnavailability: 90 * time.Second, MaxReadUnavailability: 90 * time.Second},
+	dataservices.Postgres:      {MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute},
+	dataservices.RabbitMQ:      {MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute},
+	dataservices.Redis:         {MaxWriteUnavailability: 30 * time.Second, MaxReadUnavailability: 30 * time.Second},
+	dataservices.ZooKeeper:     {MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute},
+}
+
+// RolloutTolerance returns the tolerance of a deployment of the data service during rolling updates and scaling.
+func RolloutTolerance(dataServiceType string, nodeCount int32) Tolerance {
+	tolerance, ok := rolloutTolerances[dataServiceType]
+	if !ok || nodeCount < 2 {
+		return singleNodeTolerance
+	}
+	return tolerance
+}
+
+// Check returns an error listing the unavailability windows which exceed the tolerance. Windows which were
+// not recovered until the workload was stopped always exceed it, lost writes are never tolerated.
+func (tl Timeline) Check(tolerance Tolerance) error {
+	limits := []struct {
+		kind  string
+		limit time.Duration
+	}{
+		{KindWrite, tolerance.MaxWriteUnavailability},
+		{KindRead, tolerance.MaxReadUnavailability},
+	}
+	var violations []string
+	if lost := tl.LostWrites(); len(lost) > 0 {
+		violations = append(violations, fmt.Sprintf("%d reads found lost writes, first at %s: %s", len(lost), lost[0].Time.Format(time.RFC3339), lost[0].Error))
+	}
+	for _, l := range limits {
+		for _, window := range tl.Windows(l.kind) {
+			if window.Open || window.Duration() > l.limit {
+				violations = append(violations, fmt.Sprintf("%s exceeds %s", window, l.limit))
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("workload exceeds tolerance: %s", strings.Join(violations, "; "))
+}
+
+// Recovered returns true if the last operations of all kinds succeeded.
+func (tl Timeline) Recovered() bool {
+	last := make(map[string]bool)
+	for _, op := range tl {
+		last[op.Kind] = !op.Failed()
+	}
+	for _, ok := range last {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+)
+
+var start = time.Date(2023, 8, 1, 10, 0, 0, 0, time.UTC)
+
+func op(second int, kind string, err string) Operation {
+	return Operation{Time: start.Add(time.Duration(second) * time.Second), Kind: kind, Error: err}
+}
+
+func TestTimelineWindows(t *testing.T) {
+	timeline := Timeline{
+		op(0, KindWrite, ""),
+		op(0, KindRead, ""),
+		op(1, KindWrite, "connection refused"),
+		op(1, KindRead, ""),
+		op(5, KindWrite, "connection refused"),
+		op(6, KindWrite, ""),
+		op(7, KindRead, "timeout"),
+		op(8, KindRead, "timeout"),
+	}
+
+	writes := timeline.Windows(KindWrite)
+	require.Len(t, writes, 1)
+	assert.Equal(t, Window{Kind: KindWrite, Start: start.Add(time.Second), End: start.Add(6 * time.Second), Failed: 2}, writes[0])
+	assert.Equal(t, 5*time.Second, writes[0].Duration())
+
+	reads := timeline.Windows(KindRead)
+	require.Len(t, reads, 1)
+	assert.True(t, reads[0].Open)
+	assert.Equal(t, time.Second, reads[0].Duration())
+	assert.False(t, timeline.Recovered())
+
+	total, failed := timeline.Count(KindWrite)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, 2, failed)
+}
+
+func TestTimelineLongest(t *testing.T) {
+	timeline := Timeline{
+		op(0, KindWrite, "error"),
+		op(2, KindWrite, ""),
+		op(3, KindWrite, "error"),
+		op(10, KindWrite, ""),
+	}
+	longest, ok := timeline.Longest(KindWrite)
+	require.True(t, ok)
+	assert.Equal(t, 7*time.Second, longest.Duration())
+
+	_, ok = timeline.Longest(KindRead)
+	assert.False(t, ok)
+}
+
+func TestTimelineCheck(t *testing.T) {
+	timeline := Timeline{
+		op(0, KindWrite, "error"),
+		op(20, KindWrite, ""),
+		op(20, KindRead, ""),
+	}
+	assert.NoError(t, timeline.Check(Tolerance{MaxWriteUnavailability: 30 * time.Second}))
+	assert.Error(t, timeline.Check(Tolerance{MaxWriteUnavailability: 10 * time.Second}))
+
+	// Windows which did not recover always exceed the tolerance.
+	recovered := timeline
+	timeline = append(timeline, op(21, KindRead, "error"))
+	assert.Error(t, timeline.Check(Tolerance{MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute}))
+
+	// Lost writes are never tolerated, even if reads recovered.
+	lost := op(21, KindRead, "acknowledged write lost")
+	lost.Lost = true
+	timeline = append(recovered, lost, op(22, KindRead, ""))
+	assert.Len(t, timeline.LostWrites(), 1)
+	err := timeline.Check(Tolerance{MaxWriteUnavailability: time.Minute, MaxReadUnavailability: time.Minute})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 reads found lost writes")
+	assert.Contains(t, timeline.Summary(), "reads with lost writes: 1")
+}
+
+func TestRolloutTolerance(t *testing.T) {
+	assert.Equal(t, 30*time.Second, RolloutTolerance(dataservices.Redis, 6).MaxWriteUnavailability)
+	assert.Equal(t, singleNodeTolerance, RolloutTolerance(dataservices.Redis, 1))
+	assert.Equal(t, singleNodeTolerance, RolloutTolerance(dataservices.SqlServer, 1))
+}
@@ -0,0 +1,101 @@
+package agentupgrade_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/crosscluster"
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/random"
+	"github.com/portworx/pds-integration-test/internal/workload"
+	"github.com/portworx/pds-integration-test/suites/framework"
+)
+
+// upgradeDataServices is a representative set of data services deployed before the upgrade.
+var upgradeDataServices = []string{
+	dataservices.Postgres,
+	dataservices.Cassandra,
+	dataservices.Redis,
+}
+
+func (s *AgentUpgradeTestSuite) TestAgentUpgrade_PreviousRelease_DeploymentsKeepRunning() {
+	// Given.
+	name := framework.NewRandomName("agentupgrade-creds")
+	backupCredentials := s.controlPlane.MustCreateBackupCredentialsForKind(s.ctx, s.T(), s.backupTargetCfg.Kind, s.backupTargetCfg.Credentials, name)
+	s.T().Cleanup(func() { s.controlPlane.MustDeleteBackupCredentials(s.ctx, s.T(), backupCredentials.GetId()) })
+
+	backupTarget := s.controlPlane.MustCreateBackupTargetForKind(s.ctx, s.T(), s.backupTargetCfg.Kind, backupCredentials.GetId(), s.backupTargetCfg.Bucket, s.backupTargetCfg.Region)
+	s.crossCluster.MustEnsureBackupTargetCreatedInTC(s.ctx, s.T(), backupTarget.GetId())
+	s.T().Cleanup(func() { s.controlPlane.MustDeleteBackupTarget(s.ctx, s.T(), backupTarget.GetId()) })
+
+	backupPolicyName := fmt.Sprintf("integration-test-%s", random.AlphaNumericString(random.NameSuffixLength))
+	schedule := "*/5 * * * *"
+	var retention int32 = 4
+	backupPolicy := s.controlPlane.MustCreateBackupPolicy(s.ctx, s.T(), &backupPolicyName, &schedule, &retention)
+	s.T().Cleanup(func() {
+		_, _ = s.controlPlane.DeleteBackupPolicy(s.ctx, backupPolicy.GetId())
+	})
+
+	deploymentIDs := make([]string, 0, len(upgradeDataServices))
+	for _, dataService := range upgradeDataServices {
+		deployment := api.ShortDeploymentSpec{
+			DataServiceName:  dataService,
+			ImageVersionTag:  s.dsVersions.GetLatestVersion(dataService),
+			NodeCount:        1,
+			BackupPolicyname: backupPolicy.GetName(),
+			BackupTargetName: backupTarget.GetName(),
+		}
+		deployment.NamePrefix = fmt.Sprintf("agentupgrade-%s-", deployment.ImageVersionString())
+		deploymentID := s.controlPlane.MustDeployDeploymentSpec(s.ctx, s.T(), &deployment)
+		s.T().Cleanup(func() {
+			s.controlPlane.MustRemoveDeployment(s.ctx, s.T(), deploymentID)
+			s.controlPlane.MustWaitForDeploymentRemoved(s.ctx, s.T(), deploymentID)
+		})
+		deploymentIDs = append(deploymentIDs, deploymentID)
+	}
+
+	scheduleBackupIDs := make(map[string]string, len(deploymentIDs))
+	for _, deploymentID := range deploymentIDs {
+		s.controlPlane.MustWaitForDeploymentHealthy(s.ctx, s.T(), deploymentID)
+		s.crossCluster.MustWaitForDeploymentInitialized(s.ctx, s.T(), deploymentID)
+		s.crossCluster.MustWaitForStatefulSetReady(s.ctx, s.T(), deploymentID)
+
+		scheduleBackup := s.controlPlane.MustWaitForScheduleBackup(s.ctx, s.T(), deploymentID)
+		s.controlPlane.MustEnsureNBackupJobsSuccessFromSchedule(s.ctx, s.T(), s.controlPlane.TestPDSProjectID, scheduleBackup.GetId(), 1)
+		scheduleBackupIDs[deploymentID] = scheduleBackup.GetId()
+	}
+	s.crossCluster.MustWaitForCapabilitiesReported(s.ctx, s.T())
+
+	podStates := make(map[string]map[string]crosscluster.PodState, len(deploymentIDs))
+	for _, deploymentID := range deploymentIDs {
+		podStates[deploymentID] = s.crossCluster.MustGetDeploymentPodStates(s.ctx, s.T(), deploymentID)
+	}
+
+	backgroundWorkloads := make(map[string]*crosscluster.BackgroundWorkload, len(deploymentIDs))
+	for _, deploymentID := range deploymentIDs {
+		backgroundWorkloads[deploymentID] = s.crossCluster.StartBackgroundWorkload(s.ctx, s.T(), deploymentID)
+	}
+
+	// When.
+	s.targetCluster.PDSChartConfig.Version = s.toVersion
+	err := s.targetCluster.UpgradePDSChart(s.ctx)
+	s.Require().NoError(err, "Upgrading PDS chart from %s to %s.", s.fromVersion, s.toVersion)
+	s.targetCluster.MustWaitForPDSOperatorsReady(s.ctx, s.T())
+	upgradedAt := time.Now()
+
+	// Then.
+	s.crossCluster.MustWaitForCapabilitiesReported(s.ctx, s.T())
+	for _, deploymentID := range deploymentIDs {
+		s.controlPlane.MustWaitForDeploymentHealthy(s.ctx, s.T(), deploymentID)
+		s.crossCluster.MustWaitForStatefulSetReady(s.ctx, s.T(), deploymentID)
+		s.crossCluster.MustVerifyDeploymentPodsNotRestarted(s.ctx, s.T(), deploymentID, podStates[deploymentID])
+		// The data services must stay available while the agent is upgraded.
+		backgroundWorkloads[deploymentID].MustStopWithTolerance(s.T(), workload.NoDisruption)
+	}
+	for _, deploymentID := range deploymentIDs {
+		// A scheduled backup started after the upgrade has to succeed.
+		backupID := scheduleBackupIDs[deploymentID]
+		s.controlPlane.MustWaitForBackupJobSuccessAfter(s.ctx, s.T(), s.controlPlane.TestPDSProjectID, backupID, upgradedAt)
+	}
+}
@@ -0,0 +1,121 @@
+package agentupgrade_test
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/crosscluster"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/suites/framework"
+)
+
+var (
+	upgradeFromVersion string
+	keepRegistered     bool
+)
+
+// AgentUpgradeTestSuite registers the target cluster with the previous PDS chart release and upgrades it
+// to the version set by -pdsHelmChartVersion. The target cluster must not be registered before the suite runs.
+type AgentUpgradeTestSuite struct {
+	suite.Suite
+	framework.LogScanner
+	ctx              context.Context
+	controlPlane     *controlplane.ControlPlane
+	targetCluster    *targetcluster.TargetCluster
+	crossCluster     *crosscluster.CrossClusterHelper
+	backupTargetCfg  framework.BackupTargetConfig
+	dsVersions       framework.DSVersionMatrix
+	fromVersion      string
+	toVersion        string
+	cleanupNamespace bool
+}
+
+func init() {
+	framework.AuthenticationFlags()
+	framework.ControlPlaneFlags()
+	framework.TargetClusterFlags()
+	framework.BackupCredentialFlags()
+	framework.DataserviceFlags()
+
+	flag.StringVar(&upgradeFromVersion, "upgradeFromVersion", "", "PDS chart version to upgrade from. If empty, the latest version of the previous release is used")
+	flag.BoolVar(&keepRegistered, "keepRegistered", false, "Set this to true to keep the target cluster registered after the suite")
+}
+
+func TestAgentUpgradeTestSuite(t *testing.T) {
+	suite.Run(t, new(AgentUpgradeTestSuite))
+}
+
+func (s *AgentUpgradeTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	dsVersionMatrix, err := framework.NewDSVersionMatrixFromFlags()
+	s.Require().NoError(err, "load dataservice versions")
+	s.dsVersions = dsVersionMatrix
+
+	apiClient, err := api.NewPDSClient(
+		s.ctx,
+		framework.PDSControlPlaneAPI,
+		framework.NewLoginCredentialsFromFlags(),
+	)
+	s.Require().NoError(err, "could not create Control Plane API client")
+
+	s.controlPlane = framework.NewControlPlane(
+		s.T(),
+		apiClient,
+		controlplane.WithAccountName(framework.PDSAccountName),
+		controlplane.WithTenantName(framework.PDSTenantName),
+		controlplane.WithProjectName(framework.PDSProjectName),
+		controlplane.WithLoadImageVersions(),
+		controlplane.WithCreateTemplatesAndStorageOptions(framework.NewRandomName("temp")),
+	)
+	s.backupTargetCfg = framework.NewBackupTargetConfigFromFlags()
+
+	token := s.controlPlane.MustGetServiceAccountToken(s.ctx, s.T(), framework.ServiceAccountName)
+	framework.InitializePDSHelmChartVersion(s.T(), apiClient)
+
+	s.targetCluster, err = framework.NewTargetClusterFromFlags(s.controlPlane.TestPDSTenantID, token)
+	require.NoError(s.T(), err, "Cannot create target cluster.")
+
+	s.toVersion = s.targetCluster.PDSChartConfig.Version
+	s.fromVersion = upgradeFromVersion
+	if s.fromVersion == "" {
+		s.fromVersion, err = s.targetCluster.PreviousPDSChartVersion()
+		s.Require().NoError(err, "Cannot find the previous PDS chart release.")
+	}
+	s.T().Logf("Upgrading PDS chart from %s to %s.", s.fromVersion, s.toVersion)
+
+	s.targetCluster.PDSChartConfig.Version = s.fromVersion
+	framework.RegisterTargetCluster(&s.Suite, s.controlPlane, s.targetCluster)
+	s.StartLogScan(s.T(), s.targetCluster)
+
+	if framework.TestNamespace == "" {
+		framework.TestNamespace = framework.NewRandomName("ns-agentupgrade")
+		framework.EnsureTestNamespace(s.T(), s.targetCluster, framework.TestNamespace)
+		s.cleanupNamespace = true
+	}
+	s.controlPlane.MustWaitForTestNamespace(s.ctx, s.T(), framework.TestNamespace)
+
+	s.crossCluster = crosscluster.NewHelper(s.controlPlane, s.targetCluster, time.Now()).WithArtifactsDir(framework.ArtifactsDir)
+}
+
+func (s *AgentUpgradeTestSuite) TearDownSuite() {
+	s.StopLogScan(s.T())
+
+	if s.cleanupNamespace {
+		framework.CleanupTestNamespace(s.T(), s.targetCluster, framework.TestNamespace)
+	}
+
+	s.controlPlane.DeleteTestApplicationTemplates(s.ctx, s.T())
+	s.controlPlane.DeleteTestStorageOptions(s.ctx, s.T())
+
+	if !keepRegistered {
+		framework.DeregisterTargetCluster(&s.Suite, s.controlPlane, s.targetCluster)
+	}
+}
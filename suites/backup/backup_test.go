@@ -8,7 +8,6 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/portworx/pds-integration-test/internal/api"
-	"github.com/portworx/pds-integration-test/internal/backuptargets"
 	"github.com/portworx/pds-integration-test/internal/controlplane"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
 	"github.com/portworx/pds-integration-test/internal/random"
@@ -25,12 +24,11 @@ func (s *BackupTestSuite) TestBackup_WithSchedule() {
 	// Setup backup creds
 	name := framework.NewRandomName("backup-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 	// Setup backup policy
 	backupPolicyName1 := fmt.Sprintf("integration-test-%s", random.AlphaNumericString(random.NameSuffixLength))
@@ -116,6 +114,9 @@ func (s *BackupTestSuite) TestBackup_WithSchedule() {
 }
 
 func (s *BackupTestSuite) TestBackupData_AfterDeleteDeployment() {
+	if backupTargetCfg.Kind == controlplane.ObjectStoreAzure {
+		s.T().Skip("Listing backup objects is supported only for S3 and S3-compatible object stores.")
+	}
 	s.T().Skip("Disabled for DS-5978")
 
 	// Given
@@ -142,12 +143,11 @@ func (s *BackupTestSuite) TestBackupData_AfterDeleteDeployment() {
 	// Setup backup creds
 	name := framework.NewRandomName("backup-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 	// Take Adhoc backup
 	backup := controlPlane.MustCreateBackup(ctx, s.T(), deploymentID, backupTarget.GetId())
@@ -163,7 +163,8 @@ func (s *BackupTestSuite) TestBackupData_AfterDeleteDeployment() {
 	backupJob := controlPlane.MustGetBackupJob(ctx, s.T(), backupJobId)
 	s.Require().NotNil(backupJob.CloudSnapId)
 	backupPathPrefix := getBackupPathPrefix(s.T(), *backupJob.CloudSnapId)
-	provider := backuptargets.NewAwsS3StorageProvider(backupTargetConfig.Bucket, backupTargetConfig.Region, s3Creds.AccessKey, s3Creds.SecretKey)
+	provider, err := backupTargetConfig.NewStorageProvider()
+	s.Require().NoError(err)
 	objsBeforeDeletion, err := provider.ListObjectsWithPrefix(backupPathPrefix)
 	s.Require().NoError(err)
 	s.Require().NotNil(objsBeforeDeletion)
@@ -158,7 +158,7 @@ func (s *BackupTestSuite) TestBackupCredentials_UpdateCredsAssociatedWithTarget_
 		controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId())
 		controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId())
 	})
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 
 	// When.
 	_, httpResponse, err := controlPlane.UpdateBackupCredentials(ctx, backupCredentials.GetId(), "new-name", updatedCredentials)
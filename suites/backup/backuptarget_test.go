@@ -1,6 +1,7 @@
 package backup_test
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net/http"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/portworx/pds-integration-test/internal/api"
 	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/crosscluster"
 	"github.com/portworx/pds-integration-test/internal/portworx"
 	"github.com/portworx/pds-integration-test/suites/framework"
 )
@@ -54,25 +56,90 @@ func (s *BackupTestSuite) TestBackupTarget_InvalidNonemptyBackupTarget_Fail() {
 
 	// Then.
 	api.RequireNoError(s.T(), response, err)
-	controlPlane.MustWaitForBackupTargetState(ctx, s.T(), backupTarget.GetId(), "failed_create")
+	failure := crossCluster.MustWaitForBackupTargetFailure(ctx, s.T(), backupTarget.GetId())
+	s.Require().Equal("failed_create", failure.State)
+	s.Require().Equal(crosscluster.BackupTargetFailurePXCredentials, failure.Cause)
+	s.Require().Equal(crosscluster.BackupTargetReasonEndpointUnreachable, failure.Reason, failure.String())
+	s.Require().NotEmpty(failure.ErrorDetails)
+	s.Require().NotEmpty(failure.ErrorMessage)
+	s.Require().Nil(failure.PXCloudCredential)
 	backupTargetState := controlPlane.MustGetBackupTargetState(ctx, s.T(), backupTarget.GetId())
-	s.Require().NotEmpty(backupTargetState.GetErrorDetails())
-	s.Require().NotEmpty(backupTargetState.GetErrorMessage())
-	s.Require().Equal("failed_to_create_px_credentials", backupTargetState.GetErrorCode())
 	s.Require().Empty(backupTargetState.GetPxCredentialsName())
 	s.Require().Equal(uuid.Nil.String(), backupTargetState.GetPxCredentialsId())
 }
 
+func (s *BackupTestSuite) TestBackupTarget_InvalidObjectStore_Fail() {
+	testCases := []struct {
+		name           string
+		modify         func(config *framework.BackupTargetConfig)
+		s3Only         bool
+		expectedReason crosscluster.BackupTargetFailureReason
+	}{
+		{
+			name: "bad bucket",
+			modify: func(config *framework.BackupTargetConfig) {
+				config.Bucket = framework.NewRandomName("integration-test-missing")
+			},
+			expectedReason: crosscluster.BackupTargetReasonBucketNotFound,
+		},
+		{
+			name: "wrong region",
+			modify: func(config *framework.BackupTargetConfig) {
+				config.Region = "ap-southeast-2"
+				if config.Region == backupTargetCfg.Region {
+					config.Region = "eu-north-1"
+				}
+			},
+			// Region is ignored by Azure and usually by S3-compatible object stores.
+			s3Only:         true,
+			expectedReason: crosscluster.BackupTargetReasonWrongRegion,
+		},
+		{
+			name: "bad credentials",
+			modify: func(config *framework.BackupTargetConfig) {
+				config.Credentials.S3.SecretKey = "integration-test-invalid"
+				config.Credentials.S3Compatible.SecretKey = "integration-test-invalid"
+				config.Credentials.Azure.AccountKey = base64.StdEncoding.EncodeToString([]byte("integration-test-invalid"))
+			},
+			expectedReason: crosscluster.BackupTargetReasonInvalidCredentials,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			if tc.s3Only && backupTargetCfg.Kind != controlplane.ObjectStoreS3 {
+				s.T().Skipf("Not applicable to %s object stores.", backupTargetCfg.Kind)
+			}
+
+			// Given.
+			backupTargetConfig := backupTargetCfg
+			tc.modify(&backupTargetConfig)
+			backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, framework.NewRandomName(backupCredPrefix))
+			s.T().Cleanup(func() { controlPlane.DeleteBackupCredentialsIfExists(ctx, s.T(), backupCredentials.GetId()) })
+
+			// When.
+			backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+			s.T().Cleanup(func() { controlPlane.DeleteBackupTargetIfExists(ctx, s.T(), backupTarget.GetId()) })
+
+			// Then.
+			failure := crossCluster.MustWaitForBackupTargetFailure(ctx, s.T(), backupTarget.GetId())
+			s.Require().Equal("failed_create", failure.State)
+			s.Require().Equal(crosscluster.BackupTargetFailurePXCredentials, failure.Cause)
+			s.Require().Equal(tc.expectedReason, failure.Reason, failure.String())
+		})
+	}
+}
+
 func (s *BackupTestSuite) TestBackupTarget_CreateAndDeleteInTC_Succeed() {
 	// Given.
 	backupTargetConfig := backupTargetCfg
-	backupCredentialsConfig := backupTargetConfig.Credentials.S3
 
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), backupCredentialsConfig, framework.NewRandomName(backupCredPrefix))
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, framework.NewRandomName(backupCredPrefix))
 	s.T().Cleanup(func() { controlPlane.DeleteBackupCredentialsIfExists(ctx, s.T(), backupCredentials.GetId()) })
 
 	// When.
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
 	s.T().Cleanup(func() { controlPlane.DeleteBackupTargetIfExists(ctx, s.T(), backupTarget.GetId()) })
 
 	// Then.
@@ -80,7 +147,7 @@ func (s *BackupTestSuite) TestBackupTarget_CreateAndDeleteInTC_Succeed() {
 	backupTargetState := pds.ModelsBackupTargetState{}
 
 	s.Run(fmt.Sprintf("Check Backup Target State for ID:%s", backupTarget.GetId()), func() {
-		controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+		crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 		backupTargetState = controlPlane.MustGetBackupTargetState(ctx, s.T(), backupTarget.GetId())
 		s.Require().Empty(backupTargetState.GetErrorCode())
 		s.Require().Empty(backupTargetState.GetErrorDetails())
@@ -96,10 +163,21 @@ func (s *BackupTestSuite) TestBackupTarget_CreateAndDeleteInTC_Succeed() {
 		foundPXCloudCredential := s.findCloudCredentialByName(pxCloudCredentials, backupTargetState.GetPxCredentialsName())
 		s.Require().NotNil(foundPXCloudCredential)
 		s.Require().Equal(backupTargetState.GetPxCredentialsId(), foundPXCloudCredential.ID)
-		s.Require().Equal(backupTargetCfg.Bucket, foundPXCloudCredential.Bucket)
-		s.Require().Equal(backupTargetCfg.Region, foundPXCloudCredential.AwsCredential.Region)
-		s.Require().Equal(backupCredentialsConfig.AccessKey, foundPXCloudCredential.AwsCredential.AccessKey)
-		s.Require().Equal(backupCredentialsConfig.Endpoint, foundPXCloudCredential.AwsCredential.Endpoint)
+		s.Require().Equal(backupTargetConfig.Bucket, foundPXCloudCredential.Bucket)
+		switch backupTargetConfig.Kind {
+		case controlplane.ObjectStoreS3:
+			s3Creds := backupTargetConfig.Credentials.S3
+			s.Require().Equal(backupTargetConfig.Region, foundPXCloudCredential.AwsCredential.Region)
+			s.Require().Equal(s3Creds.AccessKey, foundPXCloudCredential.AwsCredential.AccessKey)
+			s.Require().Equal(s3Creds.Endpoint, foundPXCloudCredential.AwsCredential.Endpoint)
+		case controlplane.ObjectStoreS3Compatible:
+			s3Creds := backupTargetConfig.Credentials.S3Compatible
+			s.Require().Equal(backupTargetConfig.Region, foundPXCloudCredential.AwsCredential.Region)
+			s.Require().Equal(s3Creds.AccessKey, foundPXCloudCredential.AwsCredential.AccessKey)
+			s.Require().Contains(s3Creds.Endpoint, foundPXCloudCredential.AwsCredential.Endpoint)
+		case controlplane.ObjectStoreAzure:
+			s.Require().Equal(backupTargetConfig.Credentials.Azure.AccountName, foundPXCloudCredential.AzureCredential.AccountName)
+		}
 	})
 
 	s.Run("Test deletion of the backup target", func() {
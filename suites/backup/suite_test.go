@@ -89,7 +89,7 @@ func (s *BackupTestSuite) SetupSuite() {
 
 	cp.MustWaitForTestNamespace(context.Background(), s.T(), framework.TestNamespace)
 
-	crossCluster = crosscluster.NewHelper(controlPlane, targetCluster, time.Now())
+	crossCluster = crosscluster.NewHelper(controlPlane, targetCluster, time.Now()).WithArtifactsDir(framework.ArtifactsDir)
 }
 
 func (s *BackupTestSuite) TearDownSuite() {
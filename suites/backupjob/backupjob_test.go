@@ -45,13 +45,12 @@ func (s *BackupJobTestSuite) TestBackupJobReporting_CP() {
 
 	// Setup backup creds
 	name := framework.NewRandomName("backupjob-creds")
-	s3Creds := backupTargetCfg.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetCfg.Kind, backupTargetCfg.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetCfg.Kind, backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup
@@ -101,13 +100,12 @@ func (s *BackupJobTestSuite) TestBackupJobDeletionFromTC_WithDisconnected_TC() {
 
 	// Setup backup creds
 	name := framework.NewRandomName("backupjob-creds")
-	s3Creds := backupTargetCfg.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetCfg.Kind, backupTargetCfg.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetCfg.Kind, backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup
@@ -164,12 +162,11 @@ func (s *BackupJobTestSuite) TestBackupJobDeletion_FromCP_WithAdhocBackup() {
 	namespace := namespaceModel.GetName()
 	// Setup backup creds
 	name := framework.NewRandomName("backupjob-creds")
-	s3Creds := backupTargetCfg.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetCfg.Kind, backupTargetCfg.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetCfg.Kind, backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 	// Take Adhoc backup
 	backup := controlPlane.MustCreateBackup(ctx, s.T(), deploymentID, backupTarget.GetId())
@@ -205,12 +202,11 @@ func (s *BackupJobTestSuite) TestBackupJobDeletion_FromCP_WithBackupSchedule() {
 	}
 	// Setup backup creds
 	name := framework.NewRandomName("backupjob-creds")
-	s3Creds := backupTargetCfg.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetCfg.Kind, backupTargetCfg.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetCfg.Kind, backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 	// Setup backup policy
 	nameSuffix := random.AlphaNumericString(random.NameSuffixLength)
@@ -289,12 +285,11 @@ func (s *BackupJobTestSuite) TestBackupJobDeletion_FromTC() {
 	namespace := namespaceModel.GetName()
 	// Setup backup creds
 	name := framework.NewRandomName("backupjob-creds")
-	s3Creds := backupTargetCfg.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetCfg.Kind, backupTargetCfg.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetCfg.Kind, backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 	// Take Adhoc backup
 	backup := controlPlane.MustCreateBackup(ctx, s.T(), deploymentID, backupTarget.GetId())
@@ -347,12 +342,11 @@ func (s *BackupJobTestSuite) TestBackupJobDeletionFromCP_WithDisconnected_TC() {
 
 	// Setup backup creds
 	name := framework.NewRandomName("backupjob-creds")
-	s3Creds := backupTargetCfg.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetCfg.Kind, backupTargetCfg.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetCfg.Kind, backupCredentials.GetId(), backupTargetCfg.Bucket, backupTargetCfg.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup
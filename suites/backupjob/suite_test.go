@@ -85,7 +85,7 @@ func (s *BackupJobTestSuite) SetupSuite() {
 
 	cp.MustWaitForTestNamespace(context.Background(), s.T(), framework.TestNamespace)
 
-	crossCluster = crosscluster.NewHelper(controlPlane, targetCluster, time.Now())
+	crossCluster = crosscluster.NewHelper(controlPlane, targetCluster, time.Now()).WithArtifactsDir(framework.ArtifactsDir)
 }
 
 func (s *BackupJobTestSuite) TearDownSuite() {
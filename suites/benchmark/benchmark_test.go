@@ -0,0 +1,104 @@
+package benchmark_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/benchmark"
+	"github.com/portworx/pds-integration-test/internal/crosscluster"
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/suites/framework"
+)
+
+// benchmarkTemplate is the label of the resource template all workloads run on in the results.
+const benchmarkTemplate = "med"
+
+// workloads are run in order with the same seed, so the reads of each workload find the records written before.
+var workloads = []struct {
+	name      string
+	readRatio float64
+}{
+	{name: "write-heavy", readRatio: 0.1},
+	{name: "read-heavy", readRatio: 0.9},
+	{name: "mixed", readRatio: 0.5},
+}
+
+// benchmarkNodeCounts are the node counts of the benchmarked deployments, 1 if not set.
+var benchmarkNodeCounts = map[string]int32{
+	dataservices.ZooKeeper: 3,
+}
+
+func (s *BenchmarkSuite) TestBenchmark_Workloads() {
+	for _, each := range s.activeVersions.Dataservices {
+		dsName := each.Name
+		for _, version := range each.Versions {
+			nodeCount, ok := benchmarkNodeCounts[dsName]
+			if !ok {
+				nodeCount = 1
+			}
+			deployment := api.ShortDeploymentSpec{
+				DataServiceName:              dsName,
+				ImageVersionTag:              version,
+				NodeCount:                    nodeCount,
+				ResourceSettingsTemplateName: dataservices.TemplateNameMed,
+			}
+
+			// Workloads run one deployment at a time, so they don't compete for the resources of the cluster.
+			s.T().Run(fmt.Sprintf("benchmark-%s-%s-n%d", dsName, deployment.ImageVersionString(), nodeCount), func(t *testing.T) {
+				deployment.NamePrefix = fmt.Sprintf("benchmark-%s-", deployment.ImageVersionString())
+				deploymentID := s.controlPlane.MustDeployDeploymentSpec(s.ctx, t, &deployment)
+				t.Cleanup(func() {
+					s.controlPlane.MustRemoveDeployment(s.ctx, t, deploymentID)
+					s.controlPlane.MustWaitForDeploymentRemoved(s.ctx, t, deploymentID)
+					s.crossCluster.MustDeleteDeploymentVolumes(s.ctx, t, deploymentID)
+				})
+				s.targetCluster.CollectDiagnosticsOnFailure(s.ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
+				s.controlPlane.MustWaitForDeploymentHealthy(s.ctx, t, deploymentID)
+				s.crossCluster.MustWaitForDeploymentInitialized(s.ctx, t, deploymentID)
+				s.crossCluster.MustWaitForStatefulSetReady(s.ctx, t, deploymentID)
+
+				var regressions []benchmark.Regression
+				for _, workload := range workloads {
+					loadTestResults := s.crossCluster.MustRunLoadTest(s.ctx, t, deploymentID, crosscluster.LoadTestConfig{
+						Mode:       crosscluster.LoadTestMixed,
+						ReadRatio:  workload.readRatio,
+						Seed:       deploymentID,
+						Duration:   workloadDuration,
+						RecordSize: workloadRecordSize,
+						// All runs of a workload run at the same time.
+						Runs:        workloadConcurrency,
+						Concurrency: workloadConcurrency,
+						// Failed operations are compared with the baseline error rate.
+						AllowErrors: true,
+					})
+					require.Positivef(t, loadTestResults.Ops, "Workload %s has no operations.", workload.name)
+
+					result := benchmark.NewResult(benchmark.Key{
+						DataService:  dsName,
+						Version:      deployment.ImageVersionString(),
+						Template:     benchmarkTemplate,
+						ChartVersion: framework.PDSHelmChartVersion,
+						Workload:     workload.name,
+					}, loadTestResults)
+					s.results.Add(result)
+
+					if updateBaseline {
+						continue
+					}
+					workloadRegressions, ok := benchmark.Compare(s.baseline, result, tolerances)
+					if !ok {
+						t.Logf("No baseline for %s.", result.Key)
+						continue
+					}
+					regressions = append(regressions, workloadRegressions...)
+				}
+				for _, regression := range regressions {
+					t.Errorf("Regression of %s", regression)
+				}
+			})
+		}
+	}
+}
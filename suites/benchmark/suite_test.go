@@ -0,0 +1,152 @@
+package benchmark_test
+
+import (
+	"context"
+	"flag"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/benchmark"
+	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/crosscluster"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/suites/framework"
+)
+
+const resultsFileName = "benchmark-results.json"
+
+var (
+	baselineFile        string
+	updateBaseline      bool
+	workloadDuration    time.Duration
+	workloadRecordSize  int
+	workloadConcurrency int
+	tolerances          = benchmark.DefaultTolerances
+)
+
+// BenchmarkSuite runs standardized workloads against every data service version on a fixed resource template and
+// compares the throughput and latencies with a baseline.
+type BenchmarkSuite struct {
+	suite.Suite
+	ctx       context.Context
+	startTime time.Time
+
+	controlPlane  *controlplane.ControlPlane
+	targetCluster *targetcluster.TargetCluster
+	crossCluster  *crosscluster.CrossClusterHelper
+
+	activeVersions framework.DSVersionMatrix
+	baseline       *benchmark.Report
+	results        *benchmark.Report
+}
+
+func init() {
+	framework.AuthenticationFlags()
+	framework.ControlPlaneFlags()
+	framework.TargetClusterFlags()
+	framework.DataserviceFlags()
+
+	flag.StringVar(&baselineFile, "benchmarkBaseline", "", "Path to the benchmark baseline file. If empty, the results are not compared")
+	flag.BoolVar(&updateBaseline, "benchmarkUpdateBaseline", false, "Set this to true to add the results to the baseline file instead of comparing them")
+	flag.DurationVar(&workloadDuration, "benchmarkDuration", 2*time.Minute, "Duration of each benchmark workload")
+	flag.IntVar(&workloadRecordSize, "benchmarkRecordSize", 1024, "Size of the records written by the benchmark workloads in bytes")
+	flag.IntVar(&workloadConcurrency, "benchmarkConcurrency", 4, "Number of parallel load-test runs of each benchmark workload")
+	flag.Float64Var(&tolerances.Throughput, "benchmarkThroughputTolerance", benchmark.DefaultTolerances.Throughput, "Accepted throughput decrease relative to the baseline")
+	flag.Float64Var(&tolerances.Latency, "benchmarkLatencyTolerance", benchmark.DefaultTolerances.Latency, "Accepted p50 and p99 latency increase relative to the baseline")
+	flag.Float64Var(&tolerances.ErrorRate, "benchmarkErrorRateTolerance", benchmark.DefaultTolerances.ErrorRate, "Accepted absolute error rate increase over the baseline")
+}
+
+func TestBenchmarkSuite(t *testing.T) {
+	suite.Run(t, new(BenchmarkSuite))
+}
+
+func (s *BenchmarkSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.startTime = time.Now()
+	s.results = benchmark.NewReport()
+
+	activeVersions, err := framework.NewDSVersionMatrixFromFlags()
+	s.Require().NoError(err, "Initialize dataservices version matrix")
+	s.activeVersions = activeVersions
+
+	s.baseline = benchmark.NewReport()
+	if baselineFile != "" {
+		s.baseline, err = benchmark.Load(baselineFile)
+		if updateBaseline && err != nil {
+			// The baseline is created by the first run.
+			s.baseline, err = benchmark.NewReport(), nil
+		}
+		s.Require().NoError(err, "Loading benchmark baseline.")
+	}
+
+	apiClient, err := api.NewPDSClient(
+		s.ctx,
+		framework.PDSControlPlaneAPI,
+		framework.NewLoginCredentialsFromFlags(),
+	)
+	s.Require().NoError(err, "could not create Control Plane API client")
+
+	s.controlPlane = framework.NewControlPlane(
+		s.T(),
+		apiClient,
+		controlplane.WithAccountName(framework.PDSAccountName),
+		controlplane.WithTenantName(framework.PDSTenantName),
+		controlplane.WithProjectName(framework.PDSProjectName),
+		controlplane.WithLoadImageVersions(),
+		controlplane.WithCreateTemplatesAndStorageOptions(
+			framework.NewRandomName("benchmark"),
+		),
+	)
+
+	token := s.controlPlane.MustGetServiceAccountToken(s.ctx, s.T(), framework.ServiceAccountName)
+	framework.InitializePDSHelmChartVersion(s.T(), apiClient)
+
+	targetClusters, err := framework.NewTargetClusterRegistryFromFlags(s.controlPlane.TestPDSTenantID, token)
+	require.NoError(s.T(), err, "Cannot create target clusters.")
+	s.targetCluster = targetClusters.Default()
+
+	targetClusters.MustWaitForDeploymentTargets(s.ctx, s.T(), s.controlPlane)
+	s.controlPlane.SetTestDeploymentTarget(targetClusters.DeploymentTargetID(framework.DeploymentTargetName))
+
+	if framework.TestNamespace == "" {
+		framework.TestNamespace = framework.NewRandomName("benchmark")
+		framework.EnsureTestNamespace(s.T(), s.targetCluster, framework.TestNamespace)
+	}
+	s.controlPlane.MustWaitForTestNamespace(s.ctx, s.T(), framework.TestNamespace)
+
+	s.crossCluster = crosscluster.NewHelper(s.controlPlane, s.targetCluster, time.Now()).WithTargetClusters(targetClusters)
+}
+
+func (s *BenchmarkSuite) TearDownSuite() {
+	s.reportResults()
+
+	s.controlPlane.DeleteTestApplicationTemplates(s.ctx, s.T())
+	s.controlPlane.DeleteTestStorageOptions(s.ctx, s.T())
+}
+
+// reportResults logs the results and writes them to the artifacts directory. With -benchmarkUpdateBaseline the
+// results are added to the baseline file.
+func (s *BenchmarkSuite) reportResults() {
+	if len(s.results.Results) == 0 {
+		return
+	}
+	for _, result := range s.results.Sorted() {
+		s.T().Logf("%s: %.1f ops/s, p50 %.2fms, p99 %.2fms, error rate %.4f",
+			result.Key, result.Throughput, result.P50Millis, result.P99Millis, result.ErrorRate)
+	}
+
+	if err := s.results.Save(filepath.Join(framework.ArtifactsDir, resultsFileName)); err != nil {
+		s.T().Logf("Cannot write benchmark results: %v", err)
+	}
+
+	if updateBaseline && baselineFile != "" {
+		s.baseline.Merge(s.results)
+		s.Require().NoError(s.baseline.Save(baselineFile), "Updating benchmark baseline.")
+		s.T().Logf("Added %d results to benchmark baseline %s.", len(s.results.Results), baselineFile)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/portworx/pds-integration-test/internal/controlplane"
 	"github.com/portworx/pds-integration-test/internal/crosscluster"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
 	"github.com/portworx/pds-integration-test/suites/framework"
 )
@@ -34,7 +35,7 @@ type BackupRestoreSuite struct {
 func (s *BackupRestoreSuite) SetupSuite() {
 	s.startTime = time.Now()
 
-	s.controlPlane, s.targetCluster, s.crossCluster = SetupSuite(
+	s.controlPlane, s.targetCluster, s.crossCluster, _ = SetupSuite(
 		s.T(),
 		"ds-br",
 		controlplane.WithAccountName(framework.PDSAccountName),
@@ -109,12 +110,14 @@ func (s *BackupRestoreSuite) TestDataService_BackupRestore() {
 						s.controlPlane.MustRemoveDeploymentIfExists(ctx, t, deploymentID)
 						s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
 					})
+					s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, namespace, s.startTime)
 					s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
 					s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
 					s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
 
-					seed := deploymentID
-					s.crossCluster.MustRunWriteLoadTestJob(ctx, t, deploymentID, seed)
+					dataset := datastore.NewDataset(deploymentID, framework.DatasetSize)
+					s.crossCluster.MustWriteDataset(ctx, t, deploymentID, dataset)
+					s.crossCluster.MustVerifyDataset(ctx, t, deploymentID, dataset)
 
 					// This is a temporary change and once DS-5768 is done this sleep can be removed
 					if deployment.DataServiceName == dataservices.Couchbase {
@@ -123,13 +126,12 @@ func (s *BackupRestoreSuite) TestDataService_BackupRestore() {
 
 					name := framework.NewRandomName("backup-creds")
 					backupTargetConfig := s.backupTargetCfg
-					s3Creds := backupTargetConfig.Credentials.S3
-					backupCredentials = s.controlPlane.MustCreateS3BackupCredentials(ctx, t, s3Creds, name)
+					backupCredentials = s.controlPlane.MustCreateBackupCredentialsForKind(ctx, t, backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 					t.Cleanup(func() { s.controlPlane.MustDeleteBackupCredentials(ctx, t, backupCredentials.GetId()) })
 
-					backupTarget = s.controlPlane.MustCreateS3BackupTarget(ctx, t, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+					backupTarget = s.controlPlane.MustCreateBackupTargetForKind(ctx, t, backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
 					t.Cleanup(func() { s.controlPlane.MustDeleteBackupTarget(ctx, t, backupTarget.GetId()) })
-					s.controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, t, backupTarget.GetId())
+					s.crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, t, backupTarget.GetId())
 
 					backup = s.controlPlane.MustCreateBackup(ctx, t, deploymentID, backupTarget.GetId())
 					s.controlPlane.MustWaitForBackupCreated(ctx, t, backup.GetId())
@@ -140,6 +142,8 @@ func (s *BackupRestoreSuite) TestDataService_BackupRestore() {
 						controlplane.WithListBackupJobsInProjectBackupID(backup.GetId()),
 					)
 					backupJobID := backupJobs[0].GetId()
+					psaPolicy := getSupportedPSAPolicy(deployment.DataServiceName)
+					s.crossCluster.MustPassBackupPodSecurityAudit(ctx, t, namespace, backup.GetClusterResourceName(), psaPolicy)
 
 					// Remove the original deployment to save resources.
 					// TODO(DS-6032): can't delete a backup if deployment is not exist; enable after the fix.
@@ -158,6 +162,7 @@ func (s *BackupRestoreSuite) TestDataService_BackupRestore() {
 
 					waitTimeout := dataservices.GetLongTimeoutFor(deployment.NodeCount)
 					s.crossCluster.MustEnsureRestoreSuccessful(ctx, t, namespace, restore.GetClusterResourceName(), waitTimeout)
+					s.crossCluster.MustPassRestorePodSecurityAudit(ctx, t, namespace, restore.GetClusterResourceName(), psaPolicy)
 					s.crossCluster.MustWaitForStatefulSetPDSModeNormalReady(ctx, t, restore.GetDeploymentId())
 
 					// This is a temporary change and once DS-5768 is done this sleep can be removed
@@ -165,8 +170,8 @@ func (s *BackupRestoreSuite) TestDataService_BackupRestore() {
 						time.Sleep(200 * time.Second)
 					}
 
-					// Run Read load test.
-					s.crossCluster.MustRunReadLoadTestJob(ctx, t, restore.GetDeploymentId(), seed)
+					// Verify the digest of the restored dataset.
+					s.crossCluster.MustVerifyDataset(ctx, t, restore.GetDeploymentId(), dataset)
 
 					// Run CRUD load test.
 					s.crossCluster.MustRunLoadTestJob(ctx, t, restore.GetDeploymentId())
@@ -3,6 +3,8 @@ package dataservices_test
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,9 +16,11 @@ import (
 	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
 
 	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/chaos"
 	"github.com/portworx/pds-integration-test/internal/controlplane"
 	"github.com/portworx/pds-integration-test/internal/crosscluster"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/psa"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
 	"github.com/portworx/pds-integration-test/internal/random"
@@ -26,11 +30,13 @@ import (
 
 type Dataservices struct {
 	suite.Suite
+	framework.LogScanner
 	startTime time.Time
 
-	controlPlane  *controlplane.ControlPlane
-	targetCluster *targetcluster.TargetCluster
-	crossCluster  *crosscluster.CrossClusterHelper
+	controlPlane   *controlplane.ControlPlane
+	targetCluster  *targetcluster.TargetCluster
+	crossCluster   *crosscluster.CrossClusterHelper
+	targetClusters *framework.TargetClusterRegistry
 
 	activeVersions framework.DSVersionMatrix
 }
@@ -38,7 +44,7 @@ type Dataservices struct {
 func (s *Dataservices) SetupSuite() {
 	s.startTime = time.Now()
 
-	s.controlPlane, s.targetCluster, s.crossCluster = SetupSuite(
+	s.controlPlane, s.targetCluster, s.crossCluster, s.targetClusters = SetupSuite(
 		s.T(),
 		"ds",
 		controlplane.WithAccountName(framework.PDSAccountName),
@@ -54,9 +60,12 @@ func (s *Dataservices) SetupSuite() {
 	require.NoError(s.T(), err, "Initialize dataservices version matrix")
 
 	s.activeVersions = activeVersions
+
+	s.StartLogScan(s.T(), s.targetCluster)
 }
 
 func (s *Dataservices) TearDownSuite() {
+	s.StopLogScan(s.T())
 	TearDownSuite(s.T(), s.controlPlane, s.targetCluster)
 }
 
@@ -83,6 +92,7 @@ func (s *Dataservices) TestDataService_DeploymentWithPSA() {
 
 			s.T().Run(fmt.Sprintf("deploy-%s-%s-n%d", deployment.DataServiceName, deployment.ImageVersionString(), deployment.NodeCount), func(t *testing.T) {
 				t.Parallel()
+				s.Track(t)
 
 				// Create namespace with PSA policy set
 				psaPolicy := getSupportedPSAPolicy(deployment.DataServiceName)
@@ -103,18 +113,61 @@ func (s *Dataservices) TestDataService_DeploymentWithPSA() {
 					s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
 					s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
 				})
+				s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, namespaceName, s.startTime)
 				s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+				s.crossCluster.MustVerifyDeploymentVolumes(ctx, t, deploymentID)
+				if deployment.DataServiceName == dataservices.Cassandra {
+					s.crossCluster.MustWaitForCassandraRingHealthy(ctx, t, deploymentID)
+				}
 
 				s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
+				s.crossCluster.MustPassDeploymentPodSecurityAudit(ctx, t, deploymentID, psaPolicy)
 			})
 		}
 	}
 }
 
+func (s *Dataservices) TestDataService_CassandraInRestrictedNamespace_Fails() {
+	ctx := context.Background()
+	if !s.activeVersions.HasDataservice(dataservices.Cassandra) {
+		s.T().Skipf("Data service %s is not configured.", dataservices.Cassandra)
+	}
+
+	deployment := api.ShortDeploymentSpec{
+		DataServiceName: dataservices.Cassandra,
+		ImageVersionTag: s.activeVersions.GetLatestVersion(dataservices.Cassandra),
+		NodeCount:       1,
+		NamePrefix:      "psa-restricted-",
+	}
+
+	namespaceName := "it-" + psa.PSAPolicyRestricted + "-" + random.AlphaNumericString(4)
+	_, err := s.targetCluster.CreateNamespace(ctx, psa.NewNamespace(namespaceName, psa.PSAPolicyRestricted, true))
+	s.T().Cleanup(func() {
+		_ = s.targetCluster.DeleteNamespace(ctx, namespaceName)
+	})
+	s.Require().NoError(err)
+	modelsNamespace := s.controlPlane.MustWaitForNamespaceStatus(ctx, s.T(), namespaceName, "available")
+
+	deploymentID := s.controlPlane.MustDeployDeploymentSpecIntoNamespace(ctx, s.T(), &deployment, modelsNamespace.GetId())
+	s.T().Cleanup(func() {
+		s.controlPlane.MustRemoveDeployment(ctx, s.T(), deploymentID)
+		s.controlPlane.MustWaitForDeploymentRemoved(ctx, s.T(), deploymentID)
+		s.crossCluster.MustDeleteDeploymentVolumes(ctx, s.T(), deploymentID)
+	})
+
+	// Pods of the stateful set are rejected by the admission, which must be reported to the control plane.
+	s.controlPlane.MustWaitForDeploymentEventCondition(ctx, s.T(), deploymentID,
+		func(event pds.ModelsDeploymentTargetDeploymentEvent) bool {
+			return event.GetReason() == "FailedCreate" &&
+				strings.Contains(event.GetMessage(), "violates PodSecurity \""+psa.PSAPolicyRestricted)
+		},
+		"pod creation rejected by the restricted pod security level")
+}
+
 func (s *Dataservices) TestDataService_UpdateImage() {
 	ctx := context.Background()
 
@@ -154,6 +207,7 @@ func (s *Dataservices) TestDataService_UpdateImage() {
 			testName := fmt.Sprintf("update-%s-%s-to-%s", dataServiceName, fromSpec.ImageVersionString(), toSpec.ImageVersionString())
 			s.T().Run(testName, func(t *testing.T) {
 				t.Parallel()
+				s.Track(t)
 				s.updateTestImpl(ctx, t, fromSpec, toSpec)
 			})
 		}
@@ -229,6 +283,7 @@ func (s *Dataservices) TestDataService_PDSSystemUsersV1Migration() {
 			testName := fmt.Sprintf("migrate-%s-%s-to-%s-n%d", dsName, fromSpec.ImageVersionString(), toSpec.ImageVersionString(), toSpec.NodeCount)
 			s.T().Run(testName, func(t *testing.T) {
 				t.Parallel()
+				s.Track(t)
 				s.updateTestImpl(ctx, t, fromSpec, toSpec)
 			})
 		}
@@ -258,6 +313,7 @@ func (s *Dataservices) TestDataService_Recovery_FromDeletion() {
 
 			s.T().Run(fmt.Sprintf("recover-%s-%s-n%d", deployment.DataServiceName, deployment.ImageVersionString(), deployment.NodeCount), func(t *testing.T) {
 				t.Parallel()
+				s.Track(t)
 
 				deployment.NamePrefix = fmt.Sprintf("recover-%s-n%d-", deployment.ImageVersionString(), deployment.NodeCount)
 				deploymentID := s.controlPlane.MustDeployDeploymentSpec(ctx, t, &deployment)
@@ -266,6 +322,7 @@ func (s *Dataservices) TestDataService_Recovery_FromDeletion() {
 					s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
 					s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
 				})
+				s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
 				s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
@@ -277,6 +334,9 @@ func (s *Dataservices) TestDataService_Recovery_FromDeletion() {
 				s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+				if deployment.DataServiceName == dataservices.Cassandra {
+					s.crossCluster.MustWaitForCassandraRingHealthy(ctx, t, deploymentID)
+				}
 
 				s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
 			})
@@ -284,6 +344,80 @@ func (s *Dataservices) TestDataService_Recovery_FromDeletion() {
 	}
 }
 
+func (s *Dataservices) TestDataService_Resilience() {
+	ctx := context.Background()
+	const (
+		faultDuration = time.Minute
+		maxRecovery   = 10 * time.Minute
+	)
+	// Draining a node affects all deployments on it, node drains run one at a time.
+	var nodeDrainLock sync.Mutex
+	faults := map[string]func(t *testing.T, deploymentID string) chaos.Fault{
+		"pod-kill": func(t *testing.T, deploymentID string) chaos.Fault {
+			return s.crossCluster.MustNewPodKillFault(ctx, t, deploymentID, 0)
+		},
+		"network-block": func(t *testing.T, deploymentID string) chaos.Fault {
+			return s.crossCluster.MustNewNetworkBlockFault(ctx, t, deploymentID)
+		},
+		"node-drain": func(t *testing.T, deploymentID string) chaos.Fault {
+			return s.crossCluster.MustNewNodeDrainFault(ctx, t, deploymentID, 0)
+		},
+		"volume-fill": func(t *testing.T, deploymentID string) chaos.Fault {
+			return s.crossCluster.MustNewVolumeFillFault(ctx, t, deploymentID, 0, 0)
+		},
+	}
+
+	for _, each := range s.activeVersions.Dataservices {
+		dsName := each.Name
+		versions := each.Versions
+
+		for _, version := range versions {
+			nodeCounts := commonNodeCounts[dsName]
+			if len(nodeCounts) == 0 {
+				continue
+			}
+
+			for faultName, newFault := range faults {
+				faultName, newFault := faultName, newFault
+				deployment := api.ShortDeploymentSpec{
+					DataServiceName: dsName,
+					ImageVersionTag: version,
+
+					// Only test highest node count.
+					NodeCount: nodeCounts[len(nodeCounts)-1],
+				}
+
+				s.T().Run(fmt.Sprintf("%s-%s-%s-n%d", faultName, deployment.DataServiceName, deployment.ImageVersionString(), deployment.NodeCount), func(t *testing.T) {
+					t.Parallel()
+					s.Track(t)
+
+					deployment.NamePrefix = fmt.Sprintf("%s-%s-n%d-", faultName, deployment.ImageVersionString(), deployment.NodeCount)
+					deploymentID := s.controlPlane.MustDeployDeploymentSpec(ctx, t, &deployment)
+					t.Cleanup(func() {
+						s.controlPlane.MustRemoveDeployment(ctx, t, deploymentID)
+						s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
+						s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
+					})
+					s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
+					s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
+					s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
+					s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
+					s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
+					s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+
+					if faultName == "node-drain" {
+						nodeDrainLock.Lock()
+						defer nodeDrainLock.Unlock()
+					}
+					fault := newFault(t, deploymentID)
+					dataset := datastore.NewDataset(deploymentID, framework.DatasetSize)
+					s.crossCluster.MustVerifyResilience(ctx, t, deploymentID, dataset, fault, faultDuration, maxRecovery)
+				})
+			}
+		}
+	}
+}
+
 func (s *Dataservices) TestDataService_DeletePDSUser() {
 	ctx := context.Background()
 
@@ -306,6 +440,7 @@ func (s *Dataservices) TestDataService_DeletePDSUser() {
 
 			s.T().Run(fmt.Sprintf("userdel-%s-%s-n%d", deployment.DataServiceName, deployment.ImageVersionString(), deployment.NodeCount), func(t *testing.T) {
 				t.Parallel()
+				s.Track(t)
 
 				deployment.NamePrefix = fmt.Sprintf("userdel-%s-n%d-", deployment.ImageVersionString(), deployment.NodeCount)
 				deploymentID := s.controlPlane.MustDeployDeploymentSpec(ctx, t, &deployment)
@@ -314,6 +449,7 @@ func (s *Dataservices) TestDataService_DeletePDSUser() {
 					s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
 					s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
 				})
+				s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
 				s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
@@ -355,6 +491,7 @@ func (s *Dataservices) TestDataService_ImpossibleResourceAllocation_Fails() {
 		s.controlPlane.MustWaitForDeploymentRemoved(ctx, s.T(), deploymentID)
 		s.crossCluster.MustDeleteDeploymentVolumes(ctx, s.T(), deploymentID)
 	})
+	s.targetCluster.CollectDiagnosticsOnFailure(ctx, s.T(), framework.ArtifactsDir, framework.TestNamespace, s.startTime)
 
 	// Wait for the standard timeout, and then make sure the deployment is unavailable.
 	time.Sleep(wait.StandardTimeout)
@@ -0,0 +1,80 @@
+package dataservices_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/chaos"
+	"github.com/portworx/pds-integration-test/internal/crosscluster"
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/suites/framework"
+)
+
+func (s *Dataservices) TestDataService_KafkaReplication() {
+	ctx := context.Background()
+	const (
+		nodeCount    int32 = 3
+		scaleTo      int32 = 5
+		partitions   int32 = 6
+		messageCount       = 300
+	)
+
+	for _, version := range s.activeVersions.GetVersions(dataservices.Kafka) {
+		deployment := api.ShortDeploymentSpec{
+			DataServiceName: dataservices.Kafka,
+			ImageVersionTag: version,
+			NodeCount:       nodeCount,
+		}
+
+		s.T().Run(fmt.Sprintf("replication-%s-%s-n%d", deployment.DataServiceName, deployment.ImageVersionString(), deployment.NodeCount), func(t *testing.T) {
+			t.Parallel()
+			s.Track(t)
+
+			deployment.NamePrefix = fmt.Sprintf("replication-%s-n%d-", deployment.ImageVersionString(), deployment.NodeCount)
+			deploymentID := s.controlPlane.MustDeployDeploymentSpec(ctx, t, &deployment)
+			t.Cleanup(func() {
+				s.controlPlane.MustRemoveDeployment(ctx, t, deploymentID)
+				s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
+				s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
+			})
+			s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
+			s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
+			s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
+			s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
+			s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
+			s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+			s.crossCluster.MustVerifyKafkaAdvertisedListeners(ctx, t, deploymentID)
+
+			// Produce half of the messages before and half after the broker restart.
+			topic := crosscluster.KafkaTopicName("replication", deploymentID)
+			messages := datastore.NewKafkaMessages(deploymentID, messageCount, partitions)
+			s.crossCluster.MustCreateKafkaTopic(ctx, t, deploymentID, topic, partitions)
+			s.crossCluster.MustWaitForKafkaInSyncReplicas(ctx, t, deploymentID, topic)
+			s.crossCluster.MustProduceKafkaMessages(ctx, t, deploymentID, topic, messages[:messageCount/2])
+
+			// Kill a broker, reverting waits until its pod is recreated and ready.
+			revert := chaos.MustInject(ctx, t, s.crossCluster.MustNewPodKillFault(ctx, t, deploymentID, 1))
+			revert()
+			s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
+			s.crossCluster.MustWaitForKafkaInSyncReplicas(ctx, t, deploymentID, topic)
+			s.crossCluster.MustProduceKafkaMessages(ctx, t, deploymentID, topic, messages[messageCount/2:])
+			s.crossCluster.MustVerifyKafkaMessages(ctx, t, deploymentID, topic, messages)
+
+			// Scale.
+			updateSpec := deployment
+			updateSpec.NodeCount = scaleTo
+			oldUpdateRevision := s.crossCluster.MustGetStatefulSetUpdateRevision(ctx, t, deploymentID)
+			s.controlPlane.MustUpdateDeployment(ctx, t, deploymentID, &updateSpec)
+			s.crossCluster.MustWaitForStatefulSetChanged(ctx, t, deploymentID, oldUpdateRevision)
+			s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
+			s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
+			s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+			s.crossCluster.MustWaitForKafkaInSyncReplicas(ctx, t, deploymentID, topic)
+			s.crossCluster.MustVerifyKafkaMessages(ctx, t, deploymentID, topic, messages)
+			s.crossCluster.MustVerifyKafkaAdvertisedListeners(ctx, t, deploymentID)
+		})
+	}
+}
@@ -2,7 +2,11 @@ package dataservices_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -12,12 +16,19 @@ import (
 	"github.com/portworx/pds-integration-test/internal/controlplane"
 	"github.com/portworx/pds-integration-test/internal/crosscluster"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/resourceusage"
 	"github.com/portworx/pds-integration-test/suites/framework"
 
 	"github.com/portworx/pds-integration-test/internal/api"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
 )
 
+// resourceUsageLoadTest is a round of load applied while the resource usage is sampled. Rounds are repeated until
+// there are enough samples for a verdict, but at most resourceUsageMaxLoadRounds times.
+var resourceUsageLoadTest = crosscluster.LoadTestConfig{Duration: 2 * time.Minute, Runs: 2, Concurrency: 2}
+
+const resourceUsageMaxLoadRounds = 5
+
 type MetricsSuite struct {
 	suite.Suite
 	startTime time.Time
@@ -27,12 +38,15 @@ type MetricsSuite struct {
 	crossCluster  *crosscluster.CrossClusterHelper
 
 	activeVersions framework.DSVersionMatrix
+
+	resourceUsageMu     sync.Mutex
+	resourceUsageReport resourceusage.Report
 }
 
 func (s *MetricsSuite) SetupSuite() {
 	s.startTime = time.Now()
 
-	s.controlPlane, s.targetCluster, s.crossCluster = SetupSuite(
+	s.controlPlane, s.targetCluster, s.crossCluster, _ = SetupSuite(
 		s.T(),
 		"ds-metrics",
 		controlplane.WithAccountName(framework.PDSAccountName),
@@ -55,6 +69,7 @@ func (s *MetricsSuite) SetupSuite() {
 }
 
 func (s *MetricsSuite) TearDownSuite() {
+	s.reportResourceUsage()
 	TearDownSuite(s.T(), s.controlPlane, s.targetCluster)
 }
 
@@ -94,12 +109,15 @@ func (s *MetricsSuite) TestDataService_Metrics() {
 					s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
 					s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
 				})
+				s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
 				s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
-				s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
+				sampler := s.crossCluster.StartDeploymentResourceUsageSampler(ctx, t, deploymentID)
+				s.mustRunResourceUsageLoad(ctx, t, deploymentID, sampler)
+				s.recordResourceUsage(t, deployment, sampler)
 
 				// Try to get DS metrics from prometheus.
 				s.controlPlane.MustWaitForMetricsReported(ctx, t, deploymentID)
@@ -107,3 +125,58 @@ func (s *MetricsSuite) TestDataService_Metrics() {
 		}
 	}
 }
+
+// mustRunResourceUsageLoad keeps the deployment under load until the sampler collected the samples required for a
+// verdict. The metrics server refreshes the usage only every few seconds, so a single load test is too short.
+func (s *MetricsSuite) mustRunResourceUsageLoad(ctx context.Context, t *testing.T, deploymentID string, sampler *targetcluster.ResourceUsageSampler) {
+	for round := 1; ; round++ {
+		s.crossCluster.MustRunLoadTest(ctx, t, deploymentID, resourceUsageLoadTest)
+		if sampler.Len() >= resourceusage.DefaultThresholds.MinSamples || round == resourceUsageMaxLoadRounds {
+			return
+		}
+	}
+}
+
+// recordResourceUsage stops the sampler and evaluates the usage against the resource template of the deployment.
+func (s *MetricsSuite) recordResourceUsage(t *testing.T, deployment api.ShortDeploymentSpec, sampler *targetcluster.ResourceUsageSampler) {
+	samples, err := sampler.Stop()
+	if err != nil {
+		// The metrics server is optional, the report is informational only.
+		t.Logf("Cannot sample resource usage: %v", err)
+		return
+	}
+
+	templateRequest, ok := dataservices.TemplateSpecs[deployment.DataServiceName].ResourceTemplates[deployment.ResourceSettingsTemplateName]
+	if !ok {
+		t.Logf("Unknown resource template %q, skipping resource usage report.", deployment.ResourceSettingsTemplateName)
+		return
+	}
+	template, err := resourceusage.TemplateFromRequest(templateRequest)
+	require.NoError(t, err)
+
+	entry := resourceusage.Evaluate(deployment.DataServiceName, deployment.ImageVersionString(), template, resourceusage.Summarize(samples), resourceusage.DefaultThresholds)
+	t.Logf("Resource usage of template %s: %s %v", template.Name, entry.Verdict, entry.Reasons)
+
+	s.resourceUsageMu.Lock()
+	defer s.resourceUsageMu.Unlock()
+	s.resourceUsageReport.Entries = append(s.resourceUsageReport.Entries, entry)
+}
+
+// reportResourceUsage logs the resource usage report and writes it to the artifacts directory.
+func (s *MetricsSuite) reportResourceUsage() {
+	if len(s.resourceUsageReport.Entries) == 0 {
+		return
+	}
+	s.T().Logf("Resource usage during load tests:\n%s", s.resourceUsageReport)
+
+	data, err := json.MarshalIndent(s.resourceUsageReport, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(framework.ArtifactsDir, 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(filepath.Join(framework.ArtifactsDir, "resource-usage.json"), data, 0o644)
+	}
+	if err != nil {
+		s.T().Logf("Cannot write resource usage report: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package dataservices_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/suites/framework"
+)
+
+// mongoDBFailoverNodeCount is the number of members of the failover deployment. Two remaining members of three are a
+// majority and elect a new primary, a single remaining member of two can't be elected until the pod is back.
+const mongoDBFailoverNodeCount = 3
+
+func (s *Dataservices) TestDataService_MongoDBReplicaSet() {
+	ctx := context.Background()
+
+	for _, version := range s.activeVersions.GetVersions(dataservices.MongoDB) {
+		for _, nodeCount := range commonNodeCounts[dataservices.MongoDB] {
+			if nodeCount < 2 {
+				continue
+			}
+			deployment := api.ShortDeploymentSpec{
+				DataServiceName: dataservices.MongoDB,
+				ImageVersionTag: version,
+				NodeCount:       nodeCount,
+			}
+
+			s.T().Run(fmt.Sprintf("replicaset-%s-%s-n%d", deployment.DataServiceName, deployment.ImageVersionString(), deployment.NodeCount), func(t *testing.T) {
+				t.Parallel()
+				s.Track(t)
+
+				deployment.NamePrefix = fmt.Sprintf("replicaset-%s-n%d-", deployment.ImageVersionString(), deployment.NodeCount)
+				deploymentID := s.mustDeployMongoDBReplicaSet(ctx, t, &deployment)
+				s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
+				s.crossCluster.MustWaitForMongoDBReplicaSetHealthy(ctx, t, deploymentID)
+			})
+		}
+	}
+}
+
+func (s *Dataservices) TestDataService_MongoDBFailover() {
+	ctx := context.Background()
+	// Elections take about the election timeout of 10s after the primary stepped down or stopped responding.
+	const maxElection = time.Minute
+
+	for _, version := range s.activeVersions.GetVersions(dataservices.MongoDB) {
+		deployment := api.ShortDeploymentSpec{
+			DataServiceName: dataservices.MongoDB,
+			ImageVersionTag: version,
+			NodeCount:       mongoDBFailoverNodeCount,
+		}
+
+		s.T().Run(fmt.Sprintf("failover-%s-%s-n%d", deployment.DataServiceName, deployment.ImageVersionString(), deployment.NodeCount), func(t *testing.T) {
+			t.Parallel()
+			s.Track(t)
+
+			deployment.NamePrefix = fmt.Sprintf("failover-%s-n%d-", deployment.ImageVersionString(), deployment.NodeCount)
+			deploymentID := s.mustDeployMongoDBReplicaSet(ctx, t, &deployment)
+
+			dataset := datastore.NewDataset(deploymentID, framework.DatasetSize)
+			s.crossCluster.MustVerifyMongoDBFailover(ctx, t, deploymentID, dataset, maxElection)
+			s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
+			s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
+		})
+	}
+}
+
+// mustDeployMongoDBReplicaSet deploys the MongoDB deployment and waits until its replica set is healthy.
+func (s *Dataservices) mustDeployMongoDBReplicaSet(ctx context.Context, t *testing.T, deployment *api.ShortDeploymentSpec) string {
+	deploymentID := s.controlPlane.MustDeployDeploymentSpec(ctx, t, deployment)
+	t.Cleanup(func() {
+		s.controlPlane.MustRemoveDeployment(ctx, t, deploymentID)
+		s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
+		s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
+	})
+	s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
+	s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
+	s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
+	s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
+	s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
+	s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+	s.crossCluster.MustWaitForMongoDBReplicaSetHealthy(ctx, t, deploymentID)
+	return deploymentID
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/portworx/pds-integration-test/internal/api"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
 )
 
 type ScaleSuite struct {
@@ -32,7 +33,7 @@ type ScaleSuite struct {
 func (s *ScaleSuite) SetupSuite() {
 	s.startTime = time.Now()
 
-	s.controlPlane, s.targetCluster, s.crossCluster = SetupSuite(
+	s.controlPlane, s.targetCluster, s.crossCluster, _ = SetupSuite(
 		s.T(),
 		"ds-scale",
 		controlplane.WithAccountName(framework.PDSAccountName),
@@ -97,6 +98,7 @@ func (s *ScaleSuite) TestDataService_ScaleUp() {
 					s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
 					s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
 				})
+				s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
 
 				// Create.
 				s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
@@ -106,15 +108,36 @@ func (s *ScaleSuite) TestDataService_ScaleUp() {
 				s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
 				s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
 
+				// Check the topology before scaling, keys written to Redis Cluster have to be readable after the slots
+				// were rebalanced.
+				dataset := datastore.NewDataset(deploymentID, framework.DatasetSize)
+				switch deployment.DataServiceName {
+				case dataservices.Cassandra:
+					s.crossCluster.MustWaitForCassandraRingHealthy(ctx, t, deploymentID)
+				case dataservices.Redis:
+					s.crossCluster.MustWaitForRedisClusterHealthy(ctx, t, deploymentID)
+					s.crossCluster.MustWriteDataset(ctx, t, deploymentID, dataset)
+				}
+
 				// Update.
 				updateSpec := deployment
 				updateSpec.NodeCount = scaleTo
+				backgroundWorkload := s.crossCluster.StartBackgroundWorkload(ctx, t, deploymentID)
 				oldUpdateRevision := s.crossCluster.MustGetStatefulSetUpdateRevision(ctx, t, deploymentID)
 				s.controlPlane.MustUpdateDeployment(ctx, t, deploymentID, &updateSpec)
 				s.crossCluster.MustWaitForStatefulSetChanged(ctx, t, deploymentID, oldUpdateRevision)
 				s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+				backgroundWorkload.MustStop(t)
+
+				switch deployment.DataServiceName {
+				case dataservices.Cassandra:
+					s.crossCluster.MustWaitForCassandraRingHealthy(ctx, t, deploymentID)
+				case dataservices.Redis:
+					s.crossCluster.MustWaitForRedisClusterHealthy(ctx, t, deploymentID)
+					s.crossCluster.MustVerifyDataset(ctx, t, deploymentID, dataset)
+				}
 
 				s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
 			})
@@ -159,6 +182,7 @@ func (s *ScaleSuite) TestDataService_ScaleResources() {
 					s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
 					s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
 				})
+				s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
 
 				// Create.
 				s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
@@ -171,12 +195,14 @@ func (s *ScaleSuite) TestDataService_ScaleResources() {
 				// Update.
 				updateSpec := deployment
 				updateSpec.ResourceSettingsTemplateName = dataservices.TemplateNameMed
+				backgroundWorkload := s.crossCluster.StartBackgroundWorkload(ctx, t, deploymentID)
 				oldUpdateRevision := s.crossCluster.MustGetStatefulSetUpdateRevision(ctx, t, deploymentID)
 				s.controlPlane.MustUpdateDeployment(ctx, t, deploymentID, &updateSpec)
 				s.crossCluster.MustWaitForStatefulSetChanged(ctx, t, deploymentID, oldUpdateRevision)
 				s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
 				s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+				backgroundWorkload.MustStop(t)
 
 				s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
 			})
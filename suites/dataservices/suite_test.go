@@ -45,6 +45,7 @@ func SetupSuite(t *testing.T, prefix string, options ...controlplane.InitializeO
 	*controlplane.ControlPlane,
 	*targetcluster.TargetCluster,
 	*crosscluster.CrossClusterHelper,
+	*framework.TargetClusterRegistry,
 ) {
 	ctx := context.Background()
 
@@ -64,11 +65,12 @@ func SetupSuite(t *testing.T, prefix string, options ...controlplane.InitializeO
 	token := controlPlane.MustGetServiceAccountToken(ctx, t, framework.ServiceAccountName)
 	framework.InitializePDSHelmChartVersion(t, apiClient)
 
-	targetCluster, err := framework.NewTargetClusterFromFlags(controlPlane.TestPDSTenantID, token)
-	require.NoError(t, err, "Cannot create target cluster.")
+	targetClusters, err := framework.NewTargetClusterRegistryFromFlags(controlPlane.TestPDSTenantID, token)
+	require.NoError(t, err, "Cannot create target clusters.")
+	targetCluster := targetClusters.Default()
 
-	targetID := controlPlane.MustWaitForDeploymentTarget(ctx, t, framework.DeploymentTargetName)
-	controlPlane.SetTestDeploymentTarget(targetID)
+	targetClusters.MustWaitForDeploymentTargets(ctx, t, controlPlane)
+	controlPlane.SetTestDeploymentTarget(targetClusters.DeploymentTargetID(framework.DeploymentTargetName))
 
 	if framework.TestNamespace == "" {
 		framework.TestNamespace = framework.NewRandomName(prefix)
@@ -77,9 +79,9 @@ func SetupSuite(t *testing.T, prefix string, options ...controlplane.InitializeO
 
 	controlPlane.MustWaitForTestNamespace(ctx, t, framework.TestNamespace)
 
-	crossCluster := crosscluster.NewHelper(controlPlane, targetCluster, time.Now())
+	crossCluster := crosscluster.NewHelper(controlPlane, targetCluster, time.Now()).WithArtifactsDir(framework.ArtifactsDir).WithTargetClusters(targetClusters)
 
-	return controlPlane, targetCluster, crossCluster
+	return controlPlane, targetCluster, crossCluster, targetClusters
 }
 
 func TearDownSuite(t *testing.T, cp *controlplane.ControlPlane, tc *targetcluster.TargetCluster) {
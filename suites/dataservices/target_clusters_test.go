@@ -0,0 +1,75 @@
+package dataservices_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/crosscluster"
+	"github.com/portworx/pds-integration-test/suites/framework"
+)
+
+// TestDataService_OtherTargetClusters deploys every data service to each target cluster registered with the
+// --targetCluster flag and checks that the deployment runs in that cluster and not in the default one.
+func (s *Dataservices) TestDataService_OtherTargetClusters() {
+	ctx := context.Background()
+
+	names := s.targetClusters.Names()[1:]
+	if len(names) == 0 {
+		s.T().Skip("No other target clusters are registered with --targetCluster.")
+	}
+
+	for _, name := range names {
+		targetCluster := s.targetClusters.MustGet(s.T(), name)
+		// Volumes are deleted after the deployment was removed from the control plane, so they are looked up in the
+		// target cluster directly.
+		targetCrossCluster := crosscluster.NewHelper(s.controlPlane, targetCluster, s.startTime)
+		deploymentTargetID := s.targetClusters.DeploymentTargetID(name)
+		framework.EnsureTestNamespace(s.T(), targetCluster, framework.TestNamespace)
+		namespace := s.controlPlane.MustWaitForNamespaceStatusOnTarget(ctx, s.T(), deploymentTargetID, framework.TestNamespace, "available")
+
+		for _, each := range s.activeVersions.Dataservices {
+			nodeCounts := commonNodeCounts[each.Name]
+			if len(each.Versions) == 0 || len(nodeCounts) == 0 {
+				continue
+			}
+			deployment := api.ShortDeploymentSpec{
+				DataServiceName: each.Name,
+				ImageVersionTag: each.Versions[0],
+
+				// Only test lowest node count.
+				NodeCount: nodeCounts[0],
+			}
+
+			s.T().Run(fmt.Sprintf("target-%s-%s-%s-n%d", name, deployment.DataServiceName, deployment.ImageVersionString(), deployment.NodeCount), func(t *testing.T) {
+				t.Parallel()
+				s.Track(t)
+
+				deployment.NamePrefix = fmt.Sprintf("target-%s-n%d-", deployment.ImageVersionString(), deployment.NodeCount)
+				deploymentID := s.controlPlane.MustDeployDeploymentSpecToTarget(ctx, t, &deployment, deploymentTargetID, namespace.GetId())
+				t.Cleanup(func() {
+					s.controlPlane.MustRemoveDeployment(ctx, t, deploymentID)
+					s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
+					targetCrossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
+				})
+				targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
+				s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
+				s.crossCluster.MustWaitForDeploymentInitialized(ctx, t, deploymentID)
+				s.crossCluster.MustWaitForStatefulSetReady(ctx, t, deploymentID)
+
+				pdsDeployment, _, _ := s.crossCluster.MustGetDeploymentInfo(ctx, t, deploymentID)
+				require.Equal(t, deploymentTargetID, pdsDeployment.GetDeploymentTargetId())
+				_, err := targetCluster.GetStatefulSet(ctx, framework.TestNamespace, pdsDeployment.GetClusterResourceName())
+				require.NoErrorf(t, err, "Getting statefulSet of deployment %s from target cluster %s.", deploymentID, name)
+				_, err = s.targetCluster.GetStatefulSet(ctx, framework.TestNamespace, pdsDeployment.GetClusterResourceName())
+				require.Truef(t, apierrors.IsNotFound(err), "StatefulSet of deployment %s exists in the default target cluster: %v", deploymentID, err)
+
+				s.crossCluster.MustRunLoadTestJob(ctx, t, deploymentID)
+			})
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/portworx/pds-integration-test/internal/api"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/psa"
+	"github.com/portworx/pds-integration-test/suites/framework"
 )
 
 const pdsSystemUsersCapabilityName = "pds_system_users"
@@ -59,6 +60,7 @@ func (s *Dataservices) updateTestImpl(ctx context.Context, t *testing.T, fromSpe
 		s.controlPlane.MustWaitForDeploymentRemoved(ctx, t, deploymentID)
 		s.crossCluster.MustDeleteDeploymentVolumes(ctx, t, deploymentID)
 	})
+	s.targetCluster.CollectDiagnosticsOnFailure(ctx, t, framework.ArtifactsDir, framework.TestNamespace, s.startTime)
 
 	// Create.
 	s.controlPlane.MustWaitForDeploymentHealthy(ctx, t, deploymentID)
@@ -70,6 +72,7 @@ func (s *Dataservices) updateTestImpl(ctx context.Context, t *testing.T, fromSpe
 	s.crossCluster.MustRunLoadTestJobWithUser(ctx, t, deploymentID, loadTestUser)
 
 	// Update.
+	backgroundWorkload := s.crossCluster.StartBackgroundWorkload(ctx, t, deploymentID)
 	oldUpdateRevision := s.crossCluster.MustGetStatefulSetUpdateRevision(ctx, t, deploymentID)
 	s.controlPlane.MustUpdateDeployment(ctx, t, deploymentID, &toSpec)
 	s.crossCluster.MustWaitForStatefulSetChanged(ctx, t, deploymentID, oldUpdateRevision)
@@ -78,6 +81,7 @@ func (s *Dataservices) updateTestImpl(ctx context.Context, t *testing.T, fromSpe
 	s.crossCluster.MustWaitForStatefulSetImage(ctx, t, deploymentID, targetTag)
 	s.crossCluster.MustWaitForLoadBalancerServicesReady(ctx, t, deploymentID)
 	s.crossCluster.MustWaitForLoadBalancerHostsAccessibleIfNeeded(ctx, t, deploymentID)
+	backgroundWorkload.MustStop(t)
 
 	s.crossCluster.MustRunLoadTestJobWithUser(ctx, t, deploymentID, loadTestUser)
 }
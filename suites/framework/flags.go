@@ -2,6 +2,10 @@ package framework
 
 import (
 	"flag"
+	"strings"
+
+	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/datastore"
 )
 
 const (
@@ -15,6 +19,7 @@ const (
 	DefaultCertManagerNamespace    = "cert-manager"
 	DefaultAWSRegion               = "us-west-2"
 	DefaultIssuerTokenURL          = "https://apicentral.portworx.com/api"
+	DefaultArtifactsDir            = "artifacts"
 )
 
 var (
@@ -28,6 +33,7 @@ var (
 	TargetClusterKubeconfig string
 	DeploymentTargetName    string
 	ServiceAccountName      string
+	TargetClusters          TargetClusterFlag
 
 	// Authentication flags.
 	IssuerTokenURL     string
@@ -41,6 +47,14 @@ var (
 	PDSHelmChartVersion     string
 	CertManagerChartVersion string
 	DataServiceTLSEnabled   bool
+	PDSChartSource          string
+	CertManagerChartSource  string
+	PDSChartValuesFiles     RepeatedFlag
+	PDSChartSetValues       RepeatedFlag
+
+	// Log scan flags.
+	PDSLogScan       bool
+	PDSLogScanConfig string
 
 	// Backup Target flags.
 	AWSAccessKey    string
@@ -49,11 +63,29 @@ var (
 	AWSRegion       string
 	AWSS3BucketName string
 
+	BackupTargetKind string
+
+	S3CompatibleEndpoint   string
+	S3CompatibleAccessKey  string
+	S3CompatibleSecretKey  string
+	S3CompatibleRegion     string
+	S3CompatibleBucketName string
+	S3CompatiblePathStyle  bool
+
+	AzureAccountName   string
+	AzureAccountKey    string
+	AzureContainerName string
+
 	// Test Namespace
 	TestNamespace string
 
+	// ArtifactsDir is the directory for diagnostics of failed tests.
+	ArtifactsDir string
+
 	// Dataservice Flags
 	DSVersionMatrixFile string
+	// DatasetSize is the number of records of the seeded datasets written before backups and faults.
+	DatasetSize int
 )
 
 func ControlPlaneFlags() {
@@ -78,6 +110,28 @@ func TargetClusterFlags() {
 		DefaultCertManagerChartVersion,
 		"PDS Helm Chart Version",
 	)
+	flag.StringVar(
+		&PDSChartSource,
+		"pdsChartSource",
+		"",
+		"Local chart directory, .tgz archive or oci:// reference of the PDS chart. If empty, the pds Helm repository is used",
+	)
+	flag.StringVar(
+		&CertManagerChartSource,
+		"certManagerChartSource",
+		"",
+		"Local chart directory, .tgz archive or oci:// reference of the cert-manager chart. If empty, the jetstack Helm repository is used",
+	)
+	flag.Var(
+		&PDSChartValuesFiles,
+		"pdsChartValues",
+		"Values file of the PDS chart. Can be repeated, later files override earlier ones",
+	)
+	flag.Var(
+		&PDSChartSetValues,
+		"pdsChartSet",
+		"Value override of the PDS chart as key=value, e.g. image.registry=registry.example.com. Can be repeated, applied after the values files",
+	)
 	flag.StringVar(
 		&TargetClusterKubeconfig,
 		"targetClusterKubeconfig",
@@ -90,6 +144,11 @@ func TargetClusterFlags() {
 		"",
 		"Deployment Target Name of the cluster",
 	)
+	flag.Var(
+		&TargetClusters,
+		"targetCluster",
+		"Additional target cluster as name=kubeconfig, where name is its Deployment Target Name. Can be repeated",
+	)
 	flag.StringVar(
 		&ServiceAccountName,
 		"serviceAccountName",
@@ -103,6 +162,13 @@ func TargetClusterFlags() {
 		"Test namespace to run tests",
 	)
 
+	flag.StringVar(
+		&ArtifactsDir,
+		"artifactsDir",
+		DefaultArtifactsDir,
+		"Directory where diagnostics of failed tests are written",
+	)
+
 	flag.BoolVar(
 		&DataServiceTLSEnabled,
 		"dataServicesTLSEnabled",
@@ -110,6 +176,19 @@ func TargetClusterFlags() {
 		"Flag for data services TLS configuration",
 	)
 
+	flag.BoolVar(
+		&PDSLogScan,
+		"pdsLogScan",
+		false,
+		"Scan logs of the PDS containers for errors and panics while the suite runs. Supported by the dataservices and agentupgrade suites",
+	)
+	flag.StringVar(
+		&PDSLogScanConfig,
+		"pdsLogScanConfig",
+		"",
+		"YAML file with the log scan patterns and allow-list. If empty, the default patterns are used and nothing is allowed",
+	)
+
 	if DeploymentTargetName == "" {
 		DeploymentTargetName = NewRandomName("tc")
 	}
@@ -130,8 +209,42 @@ func BackupCredentialFlags() {
 	flag.StringVar(&AWSRegion, "awsRegion", DefaultAWSRegion, "AWS Region")
 	flag.StringVar(&AWSAccessKey, "awsAccessKey", "", "AWS Access Key")
 	flag.StringVar(&AWSSecretKey, "awsSecretKey", "", "AWS Secret Key")
+
+	flag.StringVar(
+		&BackupTargetKind,
+		"backupTargetKind",
+		string(controlplane.ObjectStoreS3),
+		"Object store kind used as backup target: s3, s3-compatible or azure",
+	)
+
+	flag.StringVar(&S3CompatibleEndpoint, "s3CompatibleEndpoint", "", "S3-compatible object store endpoint, e.g. http://minio.minio.svc:9000")
+	flag.StringVar(&S3CompatibleAccessKey, "s3CompatibleAccessKey", "", "S3-compatible object store Access Key")
+	flag.StringVar(&S3CompatibleSecretKey, "s3CompatibleSecretKey", "", "S3-compatible object store Secret Key")
+	flag.StringVar(&S3CompatibleRegion, "s3CompatibleRegion", DefaultAWSRegion, "S3-compatible object store Region")
+	flag.StringVar(&S3CompatibleBucketName, "s3CompatibleBucketName", "", "S3-compatible object store Bucket Name")
+	flag.BoolVar(&S3CompatiblePathStyle, "s3CompatiblePathStyle", true, "Use path-style addressing for the S3-compatible object store")
+
+	flag.StringVar(&AzureAccountName, "azureAccountName", "", "Azure Storage Account Name")
+	flag.StringVar(&AzureAccountKey, "azureAccountKey", "", "Azure Storage Account Key")
+	flag.StringVar(&AzureContainerName, "azureContainerName", "", "Azure Blob Container Name")
 }
 
 func DataserviceFlags() {
 	flag.StringVar(&DSVersionMatrixFile, "dsVersionMatrixFile", "", "File path to Dataservice version matrix")
+	flag.IntVar(&DatasetSize, "datasetSize", datastore.DefaultDatasetSize, "Number of records of seeded datasets verified after restores and faults")
+}
+
+// RepeatedFlag collects the values of a flag which can be specified multiple times.
+type RepeatedFlag []string
+
+func (f *RepeatedFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(*f, ",")
+}
+
+func (f *RepeatedFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/portworx/pds-integration-test/internal/api"
 	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/helminstaller"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
 	"github.com/portworx/pds-integration-test/internal/random"
 	"github.com/portworx/pds-integration-test/internal/tests"
@@ -53,12 +54,16 @@ func NewPDSChartConfigFromFlags(
 		ControlPlaneAPI:       controlPlaneAPI,
 		DeploymentTargetName:  DeploymentTargetName,
 		DataServiceTLSEnabled: DataServiceTLSEnabled,
+		Source:                helminstaller.ParseChartSource(PDSChartSource),
+		ValuesFiles:           PDSChartValuesFiles,
+		SetValues:             PDSChartSetValues,
 	}
 }
 
 func NewCertManagerChartConfigFromFlags() targetcluster.CertManagerChartConfig {
 	return targetcluster.CertManagerChartConfig{
 		Version: CertManagerChartVersion,
+		Source:  helminstaller.ParseChartSource(CertManagerChartSource),
 	}
 }
 
@@ -86,15 +91,43 @@ func NewBackupCredentialFromFlags() controlplane.BackupCredentials {
 			SecretKey: AWSSecretKey,
 			Endpoint:  AWSS3Endpoint,
 		},
+		S3Compatible: controlplane.S3CompatibleCredentials{
+			AccessKey: S3CompatibleAccessKey,
+			SecretKey: S3CompatibleSecretKey,
+			Endpoint:  S3CompatibleEndpoint,
+			PathStyle: S3CompatiblePathStyle,
+		},
+		Azure: controlplane.AzureCredentials{
+			AccountName: AzureAccountName,
+			AccountKey:  AzureAccountKey,
+		},
 	}
 }
 
 func NewBackupTargetConfigFromFlags() BackupTargetConfig {
-	return BackupTargetConfig{
+	kind := controlplane.ObjectStoreKind(BackupTargetKind)
+	if kind == "" {
+		kind = controlplane.ObjectStoreS3
+	}
+
+	config := BackupTargetConfig{
+		Kind:        kind,
 		Bucket:      AWSS3BucketName,
 		Region:      AWSRegion,
 		Credentials: NewBackupCredentialFromFlags(),
 	}
+	switch kind {
+	case controlplane.ObjectStoreS3Compatible:
+		config.Bucket = S3CompatibleBucketName
+		config.Region = S3CompatibleRegion
+		if config.Region == "" {
+			config.Region = DefaultAWSRegion
+		}
+	case controlplane.ObjectStoreAzure:
+		config.Bucket = AzureContainerName
+		config.Region = ""
+	}
+	return config
 }
 
 func ShouldRegister() bool {
@@ -0,0 +1,84 @@
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/logscan"
+)
+
+// LogScanner scans logs of all containers in the PDS namespace of the target cluster while a suite runs.
+// Embed it into a testify suite, call StartLogScan in SetupSuite and StopLogScan in TearDownSuite.
+// The BeforeTest and AfterTest hooks attribute the matches to the running test method. Subtests which run in parallel
+// only start after AfterTest, so they have to be tracked with Track.
+// All methods are no-ops unless the -pdsLogScan flag is set.
+type LogScanner struct {
+	scanner *logscan.Scanner
+	watcher *targetcluster.LogWatcher
+}
+
+// StartLogScan starts following the logs with the patterns and allow rules of -pdsLogScanConfig.
+func (l *LogScanner) StartLogScan(t *testing.T, tc *targetcluster.TargetCluster) {
+	if !PDSLogScan {
+		return
+	}
+
+	var config logscan.Config
+	if PDSLogScanConfig != "" {
+		var err error
+		config, err = logscan.LoadConfig(PDSLogScanConfig)
+		require.NoError(t, err, "Cannot load log scan config.")
+	}
+	scanner, err := logscan.NewScanner(config)
+	require.NoError(t, err, "Cannot create log scanner.")
+
+	l.scanner = scanner
+	l.watcher = tc.StartLogWatcher(context.Background(), targetcluster.PDSChartNamespace, scanner)
+}
+
+// BeforeTest implements suite.BeforeTest.
+func (l *LogScanner) BeforeTest(_, testName string) {
+	if l.scanner != nil {
+		l.scanner.BeginTest(testName, time.Now())
+	}
+}
+
+// AfterTest implements suite.AfterTest.
+func (l *LogScanner) AfterTest(_, testName string) {
+	if l.scanner != nil {
+		l.scanner.EndTest(testName, time.Now())
+	}
+}
+
+// Track attributes the matches to the test until it and its subtests finished. Call it at the start of each subtest.
+func (l *LogScanner) Track(t *testing.T) {
+	if l.scanner == nil {
+		return
+	}
+	name := t.Name()
+	l.scanner.BeginTest(name, time.Now())
+	t.Cleanup(func() {
+		l.scanner.EndTest(name, time.Now())
+	})
+}
+
+// StopLogScan stops following the logs and reports the matches.
+// Allowed matches are logged, any other match fails the suite.
+func (l *LogScanner) StopLogScan(t *testing.T) {
+	if l.watcher == nil {
+		return
+	}
+	l.watcher.Stop()
+
+	for _, hit := range l.scanner.Hits() {
+		if hit.Allowed {
+			t.Logf("Allowed log match (%s): %s", hit.Reason, hit)
+		} else {
+			t.Errorf("Log match: %s", hit)
+		}
+	}
+}
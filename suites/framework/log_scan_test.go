@@ -0,0 +1,40 @@
+package framework
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/logscan"
+)
+
+func TestLogScanner_Track(t *testing.T) {
+	scanner, err := logscan.NewScanner(logscan.Config{})
+	require.NoError(t, err)
+	l := &LogScanner{scanner: scanner}
+
+	t.Run("group", func(t *testing.T) {
+		l.BeforeTest("", t.Name())
+		defer l.AfterTest("", t.Name())
+
+		for _, name := range []string{"first", "second"} {
+			name := name
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				l.Track(t)
+				scanner.Scan("pod-1", "manager", "panic: "+name, time.Now())
+			})
+		}
+	})
+	scanner.Scan("pod-1", "manager", "panic: after", time.Now())
+
+	hits := scanner.Hits()
+	require.Len(t, hits, 3)
+	// Parallel subtests run after AfterTest of their parent, their matches are attributed to a running subtest.
+	for _, hit := range hits[:2] {
+		assert.Contains(t, []string{t.Name() + "/group/first", t.Name() + "/group/second"}, hit.Test, hit.Line)
+	}
+	assert.Empty(t, hits[2].Test, "Match after the test is attributed to a test.")
+}
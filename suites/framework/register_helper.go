@@ -185,7 +185,7 @@ func RegisterTargetCluster(s *suite.Suite, cp *controlplane.ControlPlane, tc *ta
 		require.NoError(s.T(), EnsurePDSNamespace(context.Background(), tc))
 	})
 
-	s.Run(fmt.Sprintf("Install PDS Chart v%s", PDSHelmChartVersion), func() {
+	s.Run(fmt.Sprintf("Install PDS Chart v%s", tc.PDSChartConfig.Version), func() {
 		require.NoError(s.T(), tc.InstallPDSChart(context.Background()))
 	})
 
@@ -0,0 +1,184 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/internal/tests"
+)
+
+// TargetClusterSpec is a target cluster configured by the --targetCluster flag.
+// The name is the deployment target name of the cluster in the control plane.
+type TargetClusterSpec struct {
+	Name       string
+	Kubeconfig string
+}
+
+// TargetClusterFlag collects the repeated --targetCluster name=kubeconfig flags.
+type TargetClusterFlag []TargetClusterSpec
+
+func (f *TargetClusterFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	values := make([]string, 0, len(*f))
+	for _, spec := range *f {
+		values = append(values, spec.Name+"="+spec.Kubeconfig)
+	}
+	return strings.Join(values, ",")
+}
+
+func (f *TargetClusterFlag) Set(value string) error {
+	name, kubeconfig, found := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	kubeconfig = strings.TrimSpace(kubeconfig)
+	if !found || name == "" || kubeconfig == "" {
+		return fmt.Errorf("invalid target cluster %q, expected name=kubeconfig", value)
+	}
+	for _, spec := range *f {
+		if spec.Name == name {
+			return fmt.Errorf("target cluster %q is specified more than once", name)
+		}
+	}
+	*f = append(*f, TargetClusterSpec{Name: name, Kubeconfig: kubeconfig})
+	return nil
+}
+
+// TargetClusterRegistry holds all target clusters used by a suite, keyed by their deployment target name.
+// The first registered target cluster is the default one. It is safe for use by parallel tests.
+type TargetClusterRegistry struct {
+	mu                  sync.RWMutex
+	names               []string
+	clusters            map[string]*targetcluster.TargetCluster
+	deploymentTargetIDs map[string]string
+}
+
+func NewTargetClusterRegistry() *TargetClusterRegistry {
+	return &TargetClusterRegistry{
+		clusters:            make(map[string]*targetcluster.TargetCluster),
+		deploymentTargetIDs: make(map[string]string),
+	}
+}
+
+// NewTargetClusterRegistryFromFlags creates a registry with the default target cluster configured by
+// --deploymentTargetName and --targetClusterKubeconfig, followed by all clusters from the --targetCluster flags.
+func NewTargetClusterRegistryFromFlags(tenantID, serviceAccountToken string) (*TargetClusterRegistry, error) {
+	registry := NewTargetClusterRegistry()
+
+	defaultCluster, err := NewTargetClusterFromFlags(tenantID, serviceAccountToken)
+	if err != nil {
+		return nil, err
+	}
+	err = registry.Register(DeploymentTargetName, defaultCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, spec := range TargetClusters {
+		pdsChartCfg := NewPDSChartConfigFromFlags(tenantID, serviceAccountToken, PDSControlPlaneAPI)
+		pdsChartCfg.DeploymentTargetName = spec.Name
+
+		tc, err := targetcluster.NewTargetCluster(
+			context.Background(),
+			spec.Kubeconfig,
+			pdsChartCfg,
+			NewCertManagerChartConfigFromFlags(),
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "initialize target cluster %s", spec.Name)
+		}
+
+		err = registry.Register(spec.Name, tc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// Register adds the target cluster with the given deployment target name.
+func (r *TargetClusterRegistry) Register(name string, tc *targetcluster.TargetCluster) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clusters[name]; ok {
+		return fmt.Errorf("target cluster %q is already registered", name)
+	}
+	r.names = append(r.names, name)
+	r.clusters[name] = tc
+	return nil
+}
+
+// Names returns the names of all registered target clusters in the order of registration.
+func (r *TargetClusterRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string(nil), r.names...)
+}
+
+// Default returns the first registered target cluster.
+func (r *TargetClusterRegistry) Default() *targetcluster.TargetCluster {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.names) == 0 {
+		return nil
+	}
+	return r.clusters[r.names[0]]
+}
+
+func (r *TargetClusterRegistry) Get(name string) (*targetcluster.TargetCluster, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tc, ok := r.clusters[name]
+	return tc, ok
+}
+
+func (r *TargetClusterRegistry) MustGet(t tests.T, name string) *targetcluster.TargetCluster {
+	tc, ok := r.Get(name)
+	require.Truef(t, ok, "Target cluster %q is not registered.", name)
+	return tc
+}
+
+// DeploymentTargetID returns the control plane ID of the named target cluster, empty if it is not resolved yet.
+func (r *TargetClusterRegistry) DeploymentTargetID(name string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.deploymentTargetIDs[name]
+}
+
+// MustWaitForDeploymentTargets waits until all registered target clusters are healthy deployment targets
+// in the control plane and stores their IDs.
+func (r *TargetClusterRegistry) MustWaitForDeploymentTargets(ctx context.Context, t tests.T, cp *controlplane.ControlPlane) {
+	for _, name := range r.Names() {
+		targetID := cp.MustWaitForDeploymentTarget(ctx, t, name)
+
+		r.mu.Lock()
+		r.deploymentTargetIDs[name] = targetID
+		r.mu.Unlock()
+	}
+}
+
+// TargetClusterForDeploymentTarget returns the target cluster registered in the control plane with the given ID.
+func (r *TargetClusterRegistry) TargetClusterForDeploymentTarget(deploymentTargetID string) (*targetcluster.TargetCluster, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for name, id := range r.deploymentTargetIDs {
+		if id == deploymentTargetID {
+			return r.clusters[name], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,153 @@
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/kubernetes/fixtures"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+)
+
+func TestTargetClusterFlag_Set(t *testing.T) {
+	testCases := []struct {
+		name        string
+		values      []string
+		expected    TargetClusterFlag
+		expectedErr string
+	}{
+		{
+			name:     "single",
+			values:   []string{"tc-1=/kube/tc-1"},
+			expected: TargetClusterFlag{{Name: "tc-1", Kubeconfig: "/kube/tc-1"}},
+		},
+		{
+			name:   "multiple in order",
+			values: []string{"tc-2=/kube/tc-2", "tc-1=/kube/tc-1"},
+			expected: TargetClusterFlag{
+				{Name: "tc-2", Kubeconfig: "/kube/tc-2"},
+				{Name: "tc-1", Kubeconfig: "/kube/tc-1"},
+			},
+		},
+		{
+			name:     "whitespace",
+			values:   []string{" tc-1 = /kube/tc-1 "},
+			expected: TargetClusterFlag{{Name: "tc-1", Kubeconfig: "/kube/tc-1"}},
+		},
+		{
+			name:     "equals sign in kubeconfig",
+			values:   []string{"tc-1=/kube/a=b"},
+			expected: TargetClusterFlag{{Name: "tc-1", Kubeconfig: "/kube/a=b"}},
+		},
+		{
+			name:        "duplicate",
+			values:      []string{"tc-1=/kube/tc-1", "tc-1=/kube/other"},
+			expected:    TargetClusterFlag{{Name: "tc-1", Kubeconfig: "/kube/tc-1"}},
+			expectedErr: `target cluster "tc-1" is specified more than once`,
+		},
+		{
+			name:        "missing separator",
+			values:      []string{"/kube/tc-1"},
+			expectedErr: `invalid target cluster "/kube/tc-1", expected name=kubeconfig`,
+		},
+		{
+			name:        "empty name",
+			values:      []string{" =/kube/tc-1"},
+			expectedErr: `invalid target cluster " =/kube/tc-1", expected name=kubeconfig`,
+		},
+		{
+			name:        "empty kubeconfig",
+			values:      []string{"tc-1="},
+			expectedErr: `invalid target cluster "tc-1=", expected name=kubeconfig`,
+		},
+		{
+			name:        "empty",
+			values:      []string{""},
+			expectedErr: `invalid target cluster "", expected name=kubeconfig`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var flag TargetClusterFlag
+			var err error
+			for _, value := range tc.values {
+				err = flag.Set(value)
+				if err != nil {
+					break
+				}
+			}
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, flag)
+		})
+	}
+}
+
+func TestTargetClusterFlag_String(t *testing.T) {
+	flag := TargetClusterFlag{
+		{Name: "tc-1", Kubeconfig: "/kube/tc-1"},
+		{Name: "tc-2", Kubeconfig: "/kube/tc-2"},
+	}
+	assert.Equal(t, "tc-1=/kube/tc-1,tc-2=/kube/tc-2", flag.String())
+	assert.Equal(t, "", (*TargetClusterFlag)(nil).String())
+}
+
+func TestTargetClusterRegistry_Default(t *testing.T) {
+	first, second := fixtures.NewFakeTargetCluster(t), fixtures.NewFakeTargetCluster(t)
+	testCases := []struct {
+		name     string
+		register []string
+		expected *targetcluster.TargetCluster
+	}{
+		{name: "empty"},
+		{name: "single", register: []string{"default"}, expected: first},
+		{name: "first registered", register: []string{"default", "tc-2"}, expected: first},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := NewTargetClusterRegistry()
+			clusters := []*targetcluster.TargetCluster{first, second}
+			for i, name := range tc.register {
+				require.NoError(t, registry.Register(name, clusters[i]))
+			}
+
+			assert.Same(t, tc.expected, registry.Default())
+			assert.Equal(t, tc.register, registry.Names())
+		})
+	}
+}
+
+func TestTargetClusterRegistry_Register(t *testing.T) {
+	registry := NewTargetClusterRegistry()
+	require.NoError(t, registry.Register("default", fixtures.NewFakeTargetCluster(t)))
+
+	err := registry.Register("default", fixtures.NewFakeTargetCluster(t))
+
+	assert.EqualError(t, err, `target cluster "default" is already registered`)
+	assert.Equal(t, []string{"default"}, registry.Names())
+}
+
+func TestTargetClusterRegistry_TargetClusterForDeploymentTarget(t *testing.T) {
+	first, second := fixtures.NewFakeTargetCluster(t), fixtures.NewFakeTargetCluster(t)
+	registry := NewTargetClusterRegistry()
+	require.NoError(t, registry.Register("default", first))
+	require.NoError(t, registry.Register("tc-2", second))
+	// Resolved by MustWaitForDeploymentTargets from the control plane.
+	registry.deploymentTargetIDs["default"] = "id-1"
+	registry.deploymentTargetIDs["tc-2"] = "id-2"
+
+	tc, ok := registry.TargetClusterForDeploymentTarget("id-2")
+	require.True(t, ok)
+	assert.Same(t, second, tc)
+	assert.Equal(t, "id-1", registry.DeploymentTargetID("default"))
+
+	_, ok = registry.TargetClusterForDeploymentTarget("unknown")
+	assert.False(t, ok)
+	_, ok = registry.Get("unknown")
+	assert.False(t, ok)
+}
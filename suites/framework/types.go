@@ -1,9 +1,29 @@
 package framework
 
-import "github.com/portworx/pds-integration-test/internal/controlplane"
+import (
+	"fmt"
+
+	"github.com/portworx/pds-integration-test/internal/backuptargets"
+	"github.com/portworx/pds-integration-test/internal/controlplane"
+)
 
 type BackupTargetConfig struct {
+	Kind        controlplane.ObjectStoreKind
 	Bucket      string
 	Region      string
 	Credentials controlplane.BackupCredentials
 }
+
+// NewStorageProvider returns a client for the objects stored in the backup target.
+func (c BackupTargetConfig) NewStorageProvider() (*backuptargets.AwsS3StorageProvider, error) {
+	switch c.Kind {
+	case controlplane.ObjectStoreS3, "":
+		s3 := c.Credentials.S3
+		return backuptargets.NewAwsS3StorageProvider(c.Bucket, c.Region, s3.AccessKey, s3.SecretKey), nil
+	case controlplane.ObjectStoreS3Compatible:
+		s3 := c.Credentials.S3Compatible
+		return backuptargets.NewS3CompatibleStorageProvider(c.Bucket, c.Region, s3.Endpoint, s3.AccessKey, s3.SecretKey, s3.PathStyle), nil
+	default:
+		return nil, fmt.Errorf("no storage provider for object store kind %q", c.Kind)
+	}
+}
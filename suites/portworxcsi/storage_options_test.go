@@ -0,0 +1,71 @@
+package portworxcsi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/portworx/pds-integration-test/internal/api"
+	"github.com/portworx/pds-integration-test/internal/controlplane"
+	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
+	"github.com/portworx/pds-integration-test/suites/framework"
+)
+
+// TestPortworxCSI_StorageOptionsCombinations tests the data service deployment with various storage options templates
+// Steps:
+// 1. Generate pairwise combinations of replication, filesystem, secure, force spread and provisioner
+// 2. Create Storage Options Template for every combination
+// 3. Deploy Data service with the above created template
+// 4. Validate the parameters and provisioner in Storage Class
+// 5. Validate the provisioner in PVC
+// 6. Validate the replication level, filesystem, encryption and replica group of the Portworx volumes
+// Expected:
+// 1. Data Service should be deployed successfully for every combination
+// 2. Storage Class, PVC and Portworx volumes should match the requested storage options
+func (s *PortworxCSITestSuite) TestPortworxCSI_StorageOptionsCombinations() {
+	s.targetCluster.MustSetStorageClusterCSIEnabled(s.ctx, s.T(), true)
+	_, err := s.targetCluster.GetPortworxCSIDriver(s.ctx)
+	s.Require().NoError(err)
+
+	combinations := controlplane.PairwiseStorageOptions(controlplane.StorageOptionsDimensions{
+		Repl:        []int32{1, 2, 3},
+		Fs:          []string{"xfs", "ext4"},
+		Secure:      []bool{false, true},
+		ForceSpread: []bool{false, true},
+		Provisioner: []string{"auto", targetcluster.PortworxCSIDriverName},
+	})
+	namePrefix := framework.NewRandomName("so")
+
+	for _, combination := range combinations {
+		options := combination // Make a copy for the closure.
+		s.T().Run(options.String(), func(t *testing.T) {
+			t.Parallel()
+			template := options.ToTemplateRequest(namePrefix + "-" + options.String())
+			templateID := s.controlPlane.MustCreateStorageOptions(s.ctx, t, template)
+			t.Cleanup(func() { s.controlPlane.MustDeleteStorageOptions(s.ctx, t, templateID) })
+
+			// Create a new deployment.
+			deployment := api.ShortDeploymentSpec{
+				DataServiceName:   dataservices.Postgres,
+				ImageVersionTag:   dsVersions.GetLatestVersion(dataservices.Postgres),
+				NodeCount:         1,
+				NamePrefix:        dataservices.Postgres,
+				StorageOptionName: *template.Name,
+			}
+
+			deploymentID, err := s.controlPlane.DeployDeploymentSpec(context.Background(), &deployment, s.controlPlane.TestPDSNamespaceID)
+			if deploymentID != "" {
+				t.Cleanup(func() {
+					s.controlPlane.MustRemoveDeployment(context.Background(), t, deploymentID)
+					s.controlPlane.MustWaitForDeploymentRemoved(context.Background(), t, deploymentID)
+				})
+			}
+			require.NoError(t, err)
+			s.controlPlane.MustWaitForDeploymentAvailable(context.Background(), t, deploymentID)
+
+			s.crossCluster.MustVerifyStorageOptions(context.Background(), t, deploymentID, options, targetcluster.PortworxCSIDriverName)
+		})
+	}
+}
@@ -15,6 +15,9 @@ import (
 
 	"github.com/portworx/pds-integration-test/internal/api"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/datastore"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/psa"
+	"github.com/portworx/pds-integration-test/internal/random"
 	"github.com/portworx/pds-integration-test/internal/tests"
 	"github.com/portworx/pds-integration-test/internal/wait"
 )
@@ -47,18 +50,18 @@ func (s *RestoreTestSuite) TestRestore_MissingPXCloudCredentials() {
 	// Setup backup creds
 	name := framework.NewRandomName("backup-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(
 		ctx, s.T(),
+		backupTargetConfig.Kind,
 		backupCredentials.GetId(),
 		backupTargetConfig.Bucket,
 		backupTargetConfig.Region,
 	)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup
@@ -130,13 +133,12 @@ func (s *RestoreTestSuite) TestRestoreDelete_CP() {
 	// Setup backup creds
 	name := framework.NewRandomName("backup-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup
@@ -212,16 +214,19 @@ func (s *RestoreTestSuite) TestRestoreSuccessful() {
 	namespace := namespaceModel.GetName()
 	restoreName := framework.NewRandomName("restore")
 
+	// Write a dataset to verify on the restored deployment.
+	dataset := datastore.NewDataset(deploymentID, framework.DatasetSize)
+	crossCluster.MustWriteDataset(ctx, s.T(), deploymentID, dataset)
+
 	// Setup backup creds.
 	name := framework.NewRandomName("pds-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target.
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup.
@@ -251,7 +256,76 @@ func (s *RestoreTestSuite) TestRestoreSuccessful() {
 	crossCluster.MustWaitForStatefulSetReady(ctx, s.T(), *restore.DeploymentId)
 	controlPlane.MustWaitForDeploymentAvailable(ctx, s.T(), *restore.DeploymentId)
 	controlPlane.MustWaitForDeploymentPodHealthy(ctx, s.T(), *restore.DeploymentId)
+	crossCluster.MustVerifyDataset(ctx, s.T(), *restore.DeploymentId, dataset)
+}
+
+func (s *RestoreTestSuite) TestRestore_IntoDifferentNamespace() {
+	// Given.
+	deployment := api.ShortDeploymentSpec{
+		DataServiceName: dataservices.Postgres,
+		ImageVersionTag: dsVersions.GetLatestVersion(dataservices.Postgres),
+		NodeCount:       1,
+	}
+
+	// Deploy DS.
+	deployment.NamePrefix = fmt.Sprintf("restore-ns-%s-", deployment.ImageVersionString())
+	deploymentID := controlPlane.MustDeployDeploymentSpec(ctx, s.T(), &deployment)
+	s.T().Cleanup(func() {
+		controlPlane.MustRemoveDeployment(ctx, s.T(), deploymentID)
+		controlPlane.MustWaitForDeploymentRemoved(ctx, s.T(), deploymentID)
+	})
+	controlPlane.MustWaitForDeploymentHealthy(ctx, s.T(), deploymentID)
+	crossCluster.MustWaitForDeploymentInitialized(ctx, s.T(), deploymentID)
+	crossCluster.MustWaitForStatefulSetReady(ctx, s.T(), deploymentID)
+
+	// Write a dataset to verify on the restored deployment.
+	dataset := datastore.NewDataset(deploymentID, framework.DatasetSize)
+	crossCluster.MustWriteDataset(ctx, s.T(), deploymentID, dataset)
+
+	// Create the namespace to restore into.
+	restoreNamespaceName := "it-restore-" + random.AlphaNumericString(4)
+	_, err := targetCluster.CreateNamespace(ctx, psa.NewNamespace(restoreNamespaceName, psa.PSAPolicyRestricted, true))
+	s.T().Cleanup(func() {
+		_ = targetCluster.DeleteNamespace(ctx, restoreNamespaceName)
+	})
+	s.Require().NoError(err)
+	restoreNamespace := controlPlane.MustWaitForNamespaceStatus(ctx, s.T(), restoreNamespaceName, "available")
+
+	// Setup backup creds.
+	name := framework.NewRandomName("pds-creds")
+	backupTargetConfig := backupTargetCfg
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
+	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
+	// Setup backup target.
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
+
+	// Take Adhoc backup.
+	backup := controlPlane.MustCreateBackup(ctx, s.T(), deploymentID, backupTarget.GetId())
+	crossCluster.MustEnsureBackupSuccessful(ctx, s.T(), deploymentID, backup.GetClusterResourceName())
+	s.T().Cleanup(func() { controlPlane.MustDeleteBackup(ctx, s.T(), backup.GetId(), false) })
+	backupJobs := controlPlane.MustListBackupJobsInProject(
+		ctx, s.T(), backup.GetProjectId(),
+		controlplane.WithListBackupJobsInProjectBackupID(backup.GetId()),
+	)
+
+	// When.
+	restoreName := framework.NewRandomName("restore")
+	restore := controlPlane.MustCreateRestore(ctx, s.T(), backupJobs[0].GetId(), restoreName, restoreNamespace.GetId(), backup.GetDeploymentTargetId())
+	s.T().Cleanup(func() {
+		controlPlane.MustRemoveDeployment(ctx, s.T(), restore.GetDeploymentId())
+		controlPlane.MustWaitForDeploymentRemoved(ctx, s.T(), restore.GetDeploymentId())
+	})
+
+	// Then.
+	controlPlane.MustWaitForRestoreSuccessful(ctx, s.T(), restore.GetId())
+	controlPlane.MustWaitForDeploymentHealthy(ctx, s.T(), restore.GetDeploymentId())
+	crossCluster.MustWaitForDeploymentInitialized(ctx, s.T(), restore.GetDeploymentId())
+	crossCluster.MustWaitForStatefulSetReady(ctx, s.T(), restore.GetDeploymentId())
+	s.Require().Equal(restoreNamespaceName, controlPlane.MustGetNamespaceForDeployment(ctx, s.T(), restore.GetDeploymentId()))
+	crossCluster.MustVerifyDataset(ctx, s.T(), restore.GetDeploymentId(), dataset)
 }
 
 func (s *RestoreTestSuite) TestRestore_TargetClusterNotSupported() {
@@ -306,9 +380,10 @@ func (s *RestoreTestSuite) TestRestore_TargetClusterNotSupported() {
 	})
 
 	s.Run("Setup backup creds", func() {
-		backupCredentials := controlPlane.MustCreateS3BackupCredentials(
+		backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(
 			ctx, s.T(),
-			backupTarget.Credentials.S3,
+			backupTarget.Kind,
+			backupTarget.Credentials,
 			framework.NewRandomName("backup-creds"),
 		)
 
@@ -320,8 +395,9 @@ func (s *RestoreTestSuite) TestRestore_TargetClusterNotSupported() {
 	})
 
 	s.Run("Setup backup target", func() {
-		backupTarget := controlPlane.MustCreateS3BackupTarget(
+		backupTarget := controlPlane.MustCreateBackupTargetForKind(
 			ctx, s.T(),
+			backupTarget.Kind,
 			backupCredentialsID,
 			backupTarget.Bucket,
 			backupTarget.Region,
@@ -329,7 +405,7 @@ func (s *RestoreTestSuite) TestRestore_TargetClusterNotSupported() {
 
 		backupTargetID = backupTarget.GetId()
 
-		controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTargetID)
+		crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTargetID)
 	})
 
 	s.T().Cleanup(func() {
@@ -400,13 +476,12 @@ func (s *RestoreTestSuite) TestRestore_EditDeploymentWhenRestoreFailed() {
 	// Setup backup creds.
 	name := framework.NewRandomName("pds-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target.
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup.
@@ -471,13 +546,12 @@ func (s *RestoreTestSuite) TestRestore_RetryRestore() {
 	// Setup backup creds.
 	name := framework.NewRandomName("pds-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target.
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup.
@@ -508,7 +582,7 @@ func (s *RestoreTestSuite) TestRestore_RetryRestore() {
 	controlPlane.MustWaitForRestoreFailed(ctx, s.T(), *restore.Id)
 
 	// Recreate the credentials with same name in PXNamespace
-	err = targetCluster.CreatePXCloudCredentialsForS3(ctx, pdsBackup.Spec.CloudCredentialName, backupTargetConfig.Bucket, s3Creds)
+	err = targetCluster.CreatePXCloudCredentialsForKind(ctx, backupTargetConfig.Kind, pdsBackup.Spec.CloudCredentialName, backupTargetConfig.Bucket, backupTargetConfig.Region, backupTargetConfig.Credentials)
 	s.Require().NoError(err)
 
 	// Then.
@@ -552,13 +626,12 @@ func (s *RestoreTestSuite) TestRestore_RetryRestore_WithNewRequestBody() {
 	// Setup backup creds.
 	name := framework.NewRandomName("pds-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target.
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup.
@@ -590,7 +663,7 @@ func (s *RestoreTestSuite) TestRestore_RetryRestore_WithNewRequestBody() {
 	controlPlane.MustWaitForRestoreFailed(ctx, s.T(), *restore.Id)
 
 	// Recreate the credentials with same name in PXNamespace
-	err = targetCluster.CreatePXCloudCredentialsForS3(ctx, pdsBackup.Spec.CloudCredentialName, backupTargetConfig.Bucket, s3Creds)
+	err = targetCluster.CreatePXCloudCredentialsForKind(ctx, backupTargetConfig.Kind, pdsBackup.Spec.CloudCredentialName, backupTargetConfig.Bucket, backupTargetConfig.Region, backupTargetConfig.Credentials)
 	s.Require().NoError(err)
 
 	// Then.
@@ -634,13 +707,12 @@ func (s *RestoreTestSuite) TestUpdateDeploymentAfterRestore() {
 	// Setup backup creds.
 	name := framework.NewRandomName("pds-creds")
 	backupTargetConfig := backupTargetCfg
-	s3Creds := backupTargetConfig.Credentials.S3
-	backupCredentials := controlPlane.MustCreateS3BackupCredentials(ctx, s.T(), s3Creds, name)
+	backupCredentials := controlPlane.MustCreateBackupCredentialsForKind(ctx, s.T(), backupTargetConfig.Kind, backupTargetConfig.Credentials, name)
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupCredentials(ctx, s.T(), backupCredentials.GetId()) })
 
 	// Setup backup target.
-	backupTarget := controlPlane.MustCreateS3BackupTarget(ctx, s.T(), backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
-	controlPlane.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
+	backupTarget := controlPlane.MustCreateBackupTargetForKind(ctx, s.T(), backupTargetConfig.Kind, backupCredentials.GetId(), backupTargetConfig.Bucket, backupTargetConfig.Region)
+	crossCluster.MustEnsureBackupTargetCreatedInTC(ctx, s.T(), backupTarget.GetId())
 	s.T().Cleanup(func() { controlPlane.MustDeleteBackupTarget(ctx, s.T(), backupTarget.GetId()) })
 
 	// Take Adhoc backup.
@@ -92,7 +92,7 @@ func (s *RestoreTestSuite) SetupSuite() {
 
 	cp.MustWaitForTestNamespace(context.Background(), s.T(), framework.TestNamespace)
 
-	crossCluster = crosscluster.NewHelper(controlPlane, targetCluster, time.Now())
+	crossCluster = crosscluster.NewHelper(controlPlane, targetCluster, time.Now()).WithArtifactsDir(framework.ArtifactsDir)
 }
 
 func (s *RestoreTestSuite) TearDownSuite() {
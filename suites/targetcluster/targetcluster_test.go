@@ -7,6 +7,7 @@ import (
 	"github.com/portworx/pds-integration-test/internal/api"
 	"github.com/portworx/pds-integration-test/internal/crosscluster"
 	"github.com/portworx/pds-integration-test/internal/dataservices"
+	"github.com/portworx/pds-integration-test/internal/kubernetes/psa"
 	"github.com/portworx/pds-integration-test/internal/kubernetes/targetcluster"
 	"github.com/portworx/pds-integration-test/internal/wait"
 )
@@ -103,3 +104,9 @@ func (s *TargetClusterTestSuite) MustWaitForDeploymentInitialized(deploymentID s
 	}, wait.LongTimeout, wait.LongRetryInterval)
 
 }
+
+// TestTargetCluster_PDSSystemPodSecurity audits the pod specs of all PDS agent workloads in the pds-system namespace
+// against the restricted pod security level.
+func (s *TargetClusterTestSuite) TestTargetCluster_PDSSystemPodSecurity() {
+	s.targetCluster.MustPassPodSecurityAudit(s.ctx, s.T(), targetcluster.PDSChartNamespace, psa.PSAPolicyRestricted, nil)
+}
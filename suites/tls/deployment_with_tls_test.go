@@ -3,8 +3,10 @@ package tls_test
 import (
 	"fmt"
 	"net/http"
+	"testing"
 
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	pds "github.com/portworx/pds-api-go-client/pds/v1alpha1"
@@ -22,7 +24,7 @@ func (s *TLSSuite) Test_ConfigureTLSIssuer_OK() {
 	}
 
 	// Given.
-	var dt = controlPlane.MustGetDeploymentTarget(ctx, s.T())
+	var dt = s.controlPlane.MustGetDeploymentTarget(s.ctx, s.T())
 	var issuer = random.AlphaNumericString(10)
 
 	// When & Then.
@@ -32,7 +34,7 @@ func (s *TLSSuite) Test_ConfigureTLSIssuer_OK() {
 	})
 
 	// When.
-	dtResponse, httpResponse, err := controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdGet(ctx, *dt.Id).Execute()
+	dtResponse, httpResponse, err := s.controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdGet(s.ctx, *dt.Id).Execute()
 
 	// Then.
 	s.Require().NoError(err)
@@ -46,7 +48,7 @@ func (s *TLSSuite) Test_ConfigureTLSRequired_OK() {
 		s.T().Skipf(errStr)
 	}
 	// Given.
-	var dt = controlPlane.MustGetDeploymentTarget(ctx, s.T())
+	var dt = s.controlPlane.MustGetDeploymentTarget(s.ctx, s.T())
 	var issuer = random.AlphaNumericString(10)
 
 	s.setUpIssuer(issuer, dt)
@@ -61,7 +63,7 @@ func (s *TLSSuite) Test_ConfigureTLSRequired_OK() {
 	})
 
 	// When.
-	dtResponse, httpResponse, err := controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdGet(ctx, *dt.Id).Execute()
+	dtResponse, httpResponse, err := s.controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdGet(s.ctx, *dt.Id).Execute()
 
 	// Then.
 	s.Require().NoError(err)
@@ -75,7 +77,7 @@ func (s *TLSSuite) Test_CreateDeploymentWithoutTLS_WhenTLSRequired_Fail() {
 		s.T().Skipf(errStr)
 	}
 	// Given.
-	var dt = controlPlane.MustGetDeploymentTarget(ctx, s.T())
+	var dt = s.controlPlane.MustGetDeploymentTarget(s.ctx, s.T())
 	var issuer = random.AlphaNumericString(10)
 
 	s.setUpIssuer(issuer, dt)
@@ -90,13 +92,13 @@ func (s *TLSSuite) Test_CreateDeploymentWithoutTLS_WhenTLSRequired_Fail() {
 
 	deploymentSpec := api.ShortDeploymentSpec{
 		DataServiceName: dataservices.Postgres,
-		ImageVersionTag: dsVersions.GetLatestVersion(dataservices.Postgres),
+		ImageVersionTag: s.dsVersions.GetLatestVersion(dataservices.Postgres),
 		NodeCount:       1,
 		TLSEnabled:      false,
 	}
 
 	// When.
-	_, err := controlPlane.DeployDeploymentSpec(ctx, &deploymentSpec, controlPlane.TestPDSNamespaceID)
+	_, err := s.controlPlane.DeployDeploymentSpec(s.ctx, &deploymentSpec, s.controlPlane.TestPDSNamespaceID)
 
 	// Then.
 	s.Require().Error(err)
@@ -110,7 +112,7 @@ func (s *TLSSuite) Test_CreateDeploymentWithTLS_WhenTLSRequired_OK() {
 		s.T().Skipf(errStr)
 	}
 	// Given.
-	var dt = controlPlane.MustGetDeploymentTarget(ctx, s.T())
+	var dt = s.controlPlane.MustGetDeploymentTarget(s.ctx, s.T())
 	var issuer = random.AlphaNumericString(10)
 
 	s.setUpIssuer(issuer, dt)
@@ -125,34 +127,168 @@ func (s *TLSSuite) Test_CreateDeploymentWithTLS_WhenTLSRequired_OK() {
 
 	deploymentSpec := api.ShortDeploymentSpec{
 		DataServiceName: dataservices.Postgres,
-		ImageVersionTag: dsVersions.GetLatestVersion(dataservices.Postgres),
+		ImageVersionTag: s.dsVersions.GetLatestVersion(dataservices.Postgres),
 		NodeCount:       1,
 		TLSEnabled:      true,
 	}
 
 	// When.
-	deploymentID, err := controlPlane.DeployDeploymentSpec(ctx, &deploymentSpec, controlPlane.TestPDSNamespaceID)
+	deploymentID, err := s.controlPlane.DeployDeploymentSpec(s.ctx, &deploymentSpec, s.controlPlane.TestPDSNamespaceID)
 	s.T().Cleanup(func() {
-		controlPlane.MustRemoveDeployment(ctx, s.T(), deploymentID)
-		controlPlane.MustWaitForDeploymentRemoved(ctx, s.T(), deploymentID)
+		s.controlPlane.MustRemoveDeployment(s.ctx, s.T(), deploymentID)
+		s.controlPlane.MustWaitForDeploymentRemoved(s.ctx, s.T(), deploymentID)
 	})
 
 	// Then.
 	s.Require().NoError(err)
-	controlPlane.MustWaitForDeploymentHealthy(ctx, s.T(), deploymentID)
-	crossCluster.MustWaitForDeploymentInitialized(ctx, s.T(), deploymentID)
-	crossCluster.MustWaitForStatefulSetReady(ctx, s.T(), deploymentID)
-	controlPlane.MustWaitForDeploymentAvailable(ctx, s.T(), deploymentID)
+	s.controlPlane.MustWaitForDeploymentHealthy(s.ctx, s.T(), deploymentID)
+	s.crossCluster.MustWaitForDeploymentInitialized(s.ctx, s.T(), deploymentID)
+	s.crossCluster.MustWaitForStatefulSetReady(s.ctx, s.T(), deploymentID)
+	s.controlPlane.MustWaitForDeploymentAvailable(s.ctx, s.T(), deploymentID)
+	s.crossCluster.MustVerifyDeploymentTLS(s.ctx, s.T(), deploymentID, issuer, true)
+}
+
+func (s *TLSSuite) Test_TLSHandshake_WhenTLSRequired_OK() {
+	errStr, enabled := s.checkTLSPreconditions()
+	if !enabled {
+		s.T().Skipf(errStr)
+	}
+	// Given.
+	var dt = s.controlPlane.MustGetDeploymentTarget(s.ctx, s.T())
+	var issuer = random.AlphaNumericString(10)
+
+	s.setUpIssuer(issuer, dt)
+	s.T().Cleanup(func() {
+		s.cleanTCIssuer(issuer)
+	})
+
+	s.setUpRequiredTLS(issuer, dt)
+	s.T().Cleanup(func() {
+		s.cleanupCP(dt)
+	})
+
+	dataServices := []string{
+		dataservices.Postgres,
+		dataservices.MySQL,
+		dataservices.Redis,
+		dataservices.Kafka,
+		dataservices.MongoDB,
+	}
+	for _, dataService := range dataServices {
+		dataService := dataService
+		s.T().Run(dataService, func(t *testing.T) {
+			if !s.dsVersions.HasDataservice(dataService) {
+				t.Skipf("Data service %s is not configured.", dataService)
+			}
+
+			deploymentSpec := api.ShortDeploymentSpec{
+				DataServiceName: dataService,
+				ImageVersionTag: s.dsVersions.GetLatestVersion(dataService),
+				NodeCount:       1,
+				TLSEnabled:      true,
+			}
+
+			// When.
+			deploymentID, err := s.controlPlane.DeployDeploymentSpec(s.ctx, &deploymentSpec, s.controlPlane.TestPDSNamespaceID)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				s.controlPlane.MustRemoveDeployment(s.ctx, t, deploymentID)
+				s.controlPlane.MustWaitForDeploymentRemoved(s.ctx, t, deploymentID)
+			})
+			s.controlPlane.MustWaitForDeploymentHealthy(s.ctx, t, deploymentID)
+			s.crossCluster.MustWaitForStatefulSetReady(s.ctx, t, deploymentID)
+
+			// Then.
+			s.crossCluster.MustVerifyDeploymentTLS(s.ctx, t, deploymentID, issuer, true)
+		})
+	}
+}
+
+func (s *TLSSuite) Test_CAIssuer_CertificatesSignedByCA_OK() {
+	errStr, enabled := s.checkTLSPreconditions()
+	if !enabled {
+		s.T().Skipf(errStr)
+	}
+	// Given.
+	issuer, deploymentID := s.deployWithCAIssuer()
+
+	// Then.
+	s.crossCluster.MustVerifyDeploymentTLS(s.ctx, s.T(), deploymentID, issuer, false)
+}
+
+func (s *TLSSuite) Test_CAIssuer_RotateCA_OK() {
+	errStr, enabled := s.checkTLSPreconditions()
+	if !enabled {
+		s.T().Skipf(errStr)
+	}
+	// Given.
+	issuer, deploymentID := s.deployWithCAIssuer()
+	pods := s.crossCluster.MustGetDeploymentPodUIDs(s.ctx, s.T(), deploymentID)
+
+	// When.
+	err := s.targetCluster.RotateClusterIssuerCA(s.ctx, issuer)
+	s.Require().NoError(err)
+	s.crossCluster.MustRenewDeploymentCertificates(s.ctx, s.T(), deploymentID)
+
+	// Then.
+	s.crossCluster.MustWaitForDeploymentPodsRolled(s.ctx, s.T(), deploymentID, pods)
+	// The CA of the issuer is the rotated one, clients trusting only the new CA have to keep working.
+	s.crossCluster.MustVerifyDeploymentTLS(s.ctx, s.T(), deploymentID, issuer, false)
+}
+
+func (s *TLSSuite) Test_CAIssuer_ForceCertificateRenewal_OK() {
+	errStr, enabled := s.checkTLSPreconditions()
+	if !enabled {
+		s.T().Skipf(errStr)
+	}
+	// Given.
+	issuer, deploymentID := s.deployWithCAIssuer()
+	pods := s.crossCluster.MustGetDeploymentPodUIDs(s.ctx, s.T(), deploymentID)
+
+	// When.
+	s.crossCluster.MustRenewDeploymentCertificates(s.ctx, s.T(), deploymentID)
+
+	// Then.
+	s.crossCluster.MustWaitForDeploymentPodsRolled(s.ctx, s.T(), deploymentID, pods)
+	s.crossCluster.MustVerifyDeploymentTLS(s.ctx, s.T(), deploymentID, issuer, false)
+}
+
+// deployWithCAIssuer sets up a CA cluster issuer for the deployment target and deploys a TLS enabled Postgres.
+func (s *TLSSuite) deployWithCAIssuer() (string, string) {
+	var dt = s.controlPlane.MustGetDeploymentTarget(s.ctx, s.T())
+	var issuer = random.AlphaNumericString(10)
+
+	s.setUpCAIssuer(issuer, dt)
+	s.T().Cleanup(func() {
+		s.cleanTCCAIssuer(issuer)
+	})
+	s.cleanupCP(dt)
+
+	deploymentSpec := api.ShortDeploymentSpec{
+		DataServiceName: dataservices.Postgres,
+		ImageVersionTag: s.dsVersions.GetLatestVersion(dataservices.Postgres),
+		NodeCount:       1,
+		TLSEnabled:      true,
+	}
+	deploymentID, err := s.controlPlane.DeployDeploymentSpec(s.ctx, &deploymentSpec, s.controlPlane.TestPDSNamespaceID)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() {
+		s.controlPlane.MustRemoveDeployment(s.ctx, s.T(), deploymentID)
+		s.controlPlane.MustWaitForDeploymentRemoved(s.ctx, s.T(), deploymentID)
+	})
+	s.controlPlane.MustWaitForDeploymentHealthy(s.ctx, s.T(), deploymentID)
+	s.crossCluster.MustWaitForStatefulSetReady(s.ctx, s.T(), deploymentID)
+	return issuer, deploymentID
 }
 
 func (s *TLSSuite) checkTLSPreconditions() (string, bool) {
 	// Pre-condition at TC
-	if !targetCluster.PDSChartConfig.DataServiceTLSEnabled {
+	if !s.targetCluster.PDSChartConfig.DataServiceTLSEnabled {
 		return "DataServiceTLSEnabled not enabled on TC", false
 	}
 
 	// Pre-condition at CP
-	request := controlPlane.PDS.AccountsApi.ApiAccountsIdGet(ctx, controlPlane.TestPDSAccountID)
+	request := s.controlPlane.PDS.AccountsApi.ApiAccountsIdGet(s.ctx, s.controlPlane.TestPDSAccountID)
 	account, httpResponse, err := request.Execute()
 	s.Require().NoError(err)
 	s.Require().Equal(http.StatusOK, httpResponse.StatusCode)
@@ -165,13 +301,24 @@ func (s *TLSSuite) checkTLSPreconditions() (string, bool) {
 }
 
 func (s *TLSSuite) setUpIssuer(issuer string, dt *pds.ModelsDeploymentTarget) {
-	err := targetCluster.CreateClusterIssuer(ctx, getClusterIssuer(issuer))
+	err := s.targetCluster.CreateClusterIssuer(s.ctx, getClusterIssuer(issuer))
 	s.Require().NoError(err)
 
+	s.patchTLSIssuer(issuer, dt)
+}
+
+func (s *TLSSuite) setUpCAIssuer(issuer string, dt *pds.ModelsDeploymentTarget) {
+	err := s.targetCluster.CreateCAClusterIssuer(s.ctx, issuer)
+	s.Require().NoError(err)
+
+	s.patchTLSIssuer(issuer, dt)
+}
+
+func (s *TLSSuite) patchTLSIssuer(issuer string, dt *pds.ModelsDeploymentTarget) {
 	patchBody := pds.RequestsPatchDeploymentTargetRequest{
 		TlsIssuer: &issuer,
 	}
-	patch := controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdPatch(ctx, *dt.Id)
+	patch := s.controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdPatch(s.ctx, *dt.Id)
 	patch = patch.Body(patchBody)
 	dtResponse, httpResponse, err := patch.Execute()
 	s.Require().NoError(err)
@@ -196,7 +343,12 @@ func getClusterIssuer(issuer string) *certmanagerv1.ClusterIssuer {
 }
 
 func (s *TLSSuite) cleanTCIssuer(issuer string) {
-	err := targetCluster.DeleteClusterIssuer(ctx, getClusterIssuer(issuer))
+	err := s.targetCluster.DeleteClusterIssuer(s.ctx, getClusterIssuer(issuer))
+	s.Require().NoError(err)
+}
+
+func (s *TLSSuite) cleanTCCAIssuer(issuer string) {
+	err := s.targetCluster.DeleteCAClusterIssuer(s.ctx, issuer)
 	s.Require().NoError(err)
 }
 
@@ -209,7 +361,7 @@ func (s *TLSSuite) cleanupCP(dt *pds.ModelsDeploymentTarget) {
 			TlsIssuer:   &issuer,
 		}
 
-		patch := controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdPatch(ctx, *dt.Id)
+		patch := s.controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdPatch(s.ctx, *dt.Id)
 		patch = patch.Body(patchBody)
 		target, resp, err := patch.Execute()
 		s.Require().NoError(err)
@@ -228,7 +380,7 @@ func (s *TLSSuite) setUpRequiredTLS(issuer string, dt *pds.ModelsDeploymentTarge
 	patchBody := pds.RequestsPatchDeploymentTargetRequest{
 		TlsRequired: &tlsRequired,
 	}
-	patch := controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdPatch(ctx, *dt.Id)
+	patch := s.controlPlane.PDS.DeploymentTargetsApi.ApiDeploymentTargetsIdPatch(s.ctx, *dt.Id)
 	patch = patch.Body(patchBody)
 	dtResponse, httpResponse, err := patch.Execute()
 
@@ -15,17 +15,16 @@ import (
 	"github.com/portworx/pds-integration-test/suites/framework"
 )
 
-var (
+type TLSSuite struct {
+	suite.Suite
+
 	ctx              context.Context
 	controlPlane     *controlplane.ControlPlane
+	targetClusters   *framework.TargetClusterRegistry
 	targetCluster    *targetcluster.TargetCluster
 	crossCluster     *crosscluster.CrossClusterHelper
 	dsVersions       framework.DSVersionMatrix
 	cleanupNamespace bool
-)
-
-type TLSSuite struct {
-	suite.Suite
 }
 
 func init() {
@@ -41,14 +40,14 @@ func TestTLSSuite(t *testing.T) {
 }
 
 func (s *TLSSuite) SetupSuite() {
-	ctx = context.Background()
+	s.ctx = context.Background()
 
 	dsVersionMatrix, err := framework.NewDSVersionMatrixFromFlags()
 	s.Require().NoError(err, "load dataservice versions")
-	dsVersions = dsVersionMatrix
+	s.dsVersions = dsVersionMatrix
 
 	apiClient, err := api.NewPDSClient(
-		ctx,
+		s.ctx,
 		framework.PDSControlPlaneAPI,
 		framework.NewLoginCredentialsFromFlags(),
 	)
@@ -63,34 +62,35 @@ func (s *TLSSuite) SetupSuite() {
 		controlplane.WithLoadImageVersions(),
 		controlplane.WithCreateTemplatesAndStorageOptions(framework.NewRandomName("temp")),
 	)
-	controlPlane = cp
+	s.controlPlane = cp
 
 	token := cp.MustGetServiceAccountToken(context.Background(), s.T(), framework.ServiceAccountName)
 	framework.InitializePDSHelmChartVersion(s.T(), apiClient)
 
-	targetCluster, err = framework.NewTargetClusterFromFlags(cp.TestPDSTenantID, token)
-	require.NoError(s.T(), err, "Cannot create target cluster.")
+	s.targetClusters, err = framework.NewTargetClusterRegistryFromFlags(cp.TestPDSTenantID, token)
+	require.NoError(s.T(), err, "Cannot create target clusters.")
+	s.targetCluster = s.targetClusters.Default()
 
-	targetID := cp.MustWaitForDeploymentTarget(context.Background(), s.T(), framework.DeploymentTargetName)
-	cp.SetTestDeploymentTarget(targetID)
+	s.targetClusters.MustWaitForDeploymentTargets(s.ctx, s.T(), cp)
+	cp.SetTestDeploymentTarget(s.targetClusters.DeploymentTargetID(framework.DeploymentTargetName))
 
 	if framework.TestNamespace == "" {
 		framework.TestNamespace = framework.NewRandomName("ns-tls")
-		framework.EnsureTestNamespace(s.T(), targetCluster, framework.TestNamespace)
-		cleanupNamespace = true
+		framework.EnsureTestNamespace(s.T(), s.targetCluster, framework.TestNamespace)
+		s.cleanupNamespace = true
 	}
 
-	cp.MustWaitForTestNamespace(context.Background(), s.T(), framework.TestNamespace)
+	cp.MustWaitForTestNamespace(s.ctx, s.T(), framework.TestNamespace)
 
-	crossCluster = crosscluster.NewHelper(controlPlane, targetCluster, time.Now())
+	s.crossCluster = crosscluster.NewHelper(s.controlPlane, s.targetCluster, time.Now()).WithTargetClusters(s.targetClusters)
 }
 
 func (s *TLSSuite) TearDownSuite() {
-	if cleanupNamespace {
-		framework.CleanupTestNamespace(s.T(), targetCluster, framework.TestNamespace)
+	if s.cleanupNamespace {
+		framework.CleanupTestNamespace(s.T(), s.targetCluster, framework.TestNamespace)
 	}
 
-	controlPlane.DeleteTestApplicationTemplates(context.Background(), s.T())
-	controlPlane.DeleteTestStorageOptions(context.Background(), s.T())
+	s.controlPlane.DeleteTestApplicationTemplates(s.ctx, s.T())
+	s.controlPlane.DeleteTestStorageOptions(s.ctx, s.T())
 
 }